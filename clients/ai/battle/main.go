@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/golang/glog"
 
@@ -34,7 +35,14 @@ func main() {
 
 	glog.Infof("Simulating %v games", *numGames)
 	for i := 0; i < *numGames; i++ {
+		// The computer players share the game, so they (and we) must
+		// serialize access to it. The lock is held until the game is
+		// dealt so that no player acts on a game that is still being
+		// set up.
 		g := rummy.NewGame()
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		mu.Lock()
 		id2StratName := make(map[int32]string, len(stratNames))
 		for j, s := range stratNames {
 			id, err := g.AddPlayer(fmt.Sprintf("CP%d", j))
@@ -50,9 +58,11 @@ func main() {
 				os.Exit(1)
 			}
 
+			wg.Add(1)
 			go func(s string) {
-				if err := ai.PlayGame(g, id, strat); err != nil {
-					glog.Fatal("Strategy %v errored: %v", s, err)
+				defer wg.Done()
+				if err := ai.PlayGame(g, &mu, id, strat); err != nil {
+					glog.Fatalf("Strategy %v errored: %v", s, err)
 				}
 			}(s)
 		}
@@ -62,12 +72,10 @@ func main() {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		mu.Unlock()
 
 		// Wait for game to finish.
-		events := make(chan *rummy.GameEvent, 1)
-		g.Subscribe(events)
-		for ok := true; ok; _, ok = <-events {
-		}
+		wg.Wait()
 
 		// Collect game results for statistics.
 		gs := g.GameState()
@@ -1,6 +1,9 @@
 package ai
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/timpalpant/rummy"
 	"github.com/timpalpant/rummy/clients/ai/strategy"
 	"github.com/timpalpant/rummy/deck"
@@ -15,9 +18,12 @@ import (
 // while playing.
 const eventBufferSize = 1000
 
-// Play the given game with the given strategy.
-func PlayGame(g *rummy.Game, playerId int32, strategy strategy.Strategy) error {
-	p := &computerPlayer{g, playerId, strategy}
+// PlayGame plays the given game with the given strategy, holding mu
+// while acting on g. The Game may be shared with other goroutines
+// (including other computer players), provided that they serialize
+// their access to it with the same lock.
+func PlayGame(g *rummy.Game, mu sync.Locker, playerId int32, strategy strategy.Strategy) error {
+	p := &computerPlayer{g, mu, playerId, strategy}
 	return p.Play()
 }
 
@@ -25,30 +31,70 @@ func PlayGame(g *rummy.Game, playerId int32, strategy strategy.Strategy) error {
 // is their turn according to a certain strategy.
 type computerPlayer struct {
 	g        *rummy.Game
+	mu       sync.Locker
 	playerId int32
 	strategy strategy.Strategy
 }
 
 func (cp *computerPlayer) Play() error {
-	// TODO(palpant): Fix race, computer needs to be subscribed to events
-	// before game is dealt, otherwise this can deadlock because the computer
-	// will miss the event that signals it to start its turn.
-	events := make(chan *rummy.GameEvent, eventBufferSize)
-	cp.g.Subscribe(events)
-
-	for event := range events {
-		if event.PlayerId == cp.playerId {
-			if event.Type == rummy.GameEvent_TURN_START {
-				if err := cp.playTurn(); err != nil {
-					return err
+	var lastSequence int64
+	for {
+		events := make(chan *rummy.GameEvent, eventBufferSize)
+		cp.mu.Lock()
+		var history []*rummy.GameEvent
+		if lastSequence > 0 {
+			history = cp.g.SubscribeFrom(events, lastSequence+1)
+		} else {
+			cp.g.Subscribe(events)
+		}
+		cp.mu.Unlock()
+
+		for _, event := range history {
+			lastSequence = event.Sequence
+			if event.PlayerId != cp.playerId {
+				cp.strategy.OnGameEvent(event)
+			}
+		}
+
+		// If we subscribed after our turn started (e.g. because the game
+		// was dealt before we subscribed, was restored mid-turn, we took
+		// over the seat, or we fell behind), then we will not receive the
+		// TURN_START event and must play our turn now.
+		if err := cp.playTurn(); err != nil {
+			cp.unsubscribe(events)
+			return err
+		}
+
+		for event := range events {
+			lastSequence = event.Sequence
+			if event.PlayerId == cp.playerId {
+				if event.Type == rummy.GameEvent_TURN_START {
+					if err := cp.playTurn(); err != nil {
+						cp.unsubscribe(events)
+						return err
+					}
 				}
+			} else {
+				cp.strategy.OnGameEvent(event)
 			}
-		} else {
-			cp.strategy.OnGameEvent(event)
+		}
+
+		// The channel is closed once the game is over, but also if we
+		// fell behind, in which case we pick up where we left off.
+		cp.mu.Lock()
+		status := cp.g.Status()
+		cp.mu.Unlock()
+		if status != rummy.GameState_LOBBY && status != rummy.GameState_IN_PROGRESS {
+			return nil
 		}
 	}
+}
 
-	return nil
+// unsubscribe stops receiving game events.
+func (cp *computerPlayer) unsubscribe(events chan *rummy.GameEvent) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.g.Unsubscribe(events)
 }
 
 func valueSlice(cards []*deck.Card) []deck.Card {
@@ -60,44 +106,150 @@ func valueSlice(cards []*deck.Card) []deck.Card {
 }
 
 func (cp *computerPlayer) playTurn() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	// Our turn may already have been played, e.g. if it timed out.
+	if cp.g.GameState().CurrentPlayerTurn != cp.playerId {
+		return nil
+	}
+
 	glog.V(1).Infof("Starting CP turn")
-	discardPile := valueSlice(cp.g.GameState().DiscardPile)
-	n := cp.strategy.PickUpCards(discardPile)
-	glog.V(1).Infof("CP chose to pick up %d cards from discard", n)
-	if n > 0 {
-		_, err := cp.g.PickUpDiscard(cp.playerId, n)
-		if err != nil {
-			return err
-		}
-	} else {
-		_, err := cp.g.PickUpStock(cp.playerId)
-		if err != nil {
+	return PlayTurn(cp.g, cp.playerId, cp.strategy)
+}
+
+// table is the interface through which a computer player
+// acts on a game, as a particular player.
+type table interface {
+	// PlayerView returns the game as seen by the player.
+	PlayerView() (*rummy.PlayerView, error)
+	PickUpStock() error
+	PickUpDiscard(nCards int) error
+	PlayCards(cards []deck.Card) error
+	DiscardCard(card deck.Card) error
+}
+
+// localTable acts directly on a Game. The caller is responsible
+// for serializing access to the Game.
+type localTable struct {
+	g        *rummy.Game
+	playerId int32
+}
+
+func (t *localTable) PlayerView() (*rummy.PlayerView, error) {
+	return t.g.PlayerView(t.playerId)
+}
+
+func (t *localTable) PickUpStock() error {
+	_, err := t.g.PickUpStock(t.playerId)
+	return err
+}
+
+func (t *localTable) PickUpDiscard(nCards int) error {
+	_, err := t.g.PickUpDiscard(t.playerId, nCards)
+	return err
+}
+
+func (t *localTable) PlayCards(cards []deck.Card) error {
+	_, err := t.g.PlayCards(t.playerId, cards)
+	return err
+}
+
+func (t *localTable) DiscardCard(card deck.Card) error {
+	return t.g.DiscardCard(t.playerId, card)
+}
+
+// PlayTurn completes the current turn of the given player with the
+// given strategy, from whatever point in the turn the player has
+// reached. It is used by computer players, and to finish the turns
+// of players who have run out of time.
+func PlayTurn(g *rummy.Game, playerId int32, strategy strategy.Strategy) error {
+	return playTurn(&localTable{g, playerId}, strategy)
+}
+
+func playTurn(t table, strategy strategy.Strategy) error {
+	view, err := t.PlayerView()
+	if err != nil {
+		return err
+	}
+	if view.TurnState == rummy.GameState_TURN_START {
+		if err := pickUpCards(t, view, strategy); err != nil {
 			return err
 		}
 	}
 
 	for {
-		hand, err := cp.g.PlayerHand(cp.playerId)
+		view, err := t.PlayerView()
 		if err != nil {
 			return err
 		}
-		cards := cp.strategy.PlayCards(rummy.NewHand(hand))
+		cards := strategy.PlayCards(rummy.NewHand(valueSlice(view.Hand)))
 		if len(cards) == 0 {
 			break
 		}
 
 		glog.V(1).Infof("CP chose to play cards: %v", cards)
-		_, err = cp.g.PlayCards(cp.playerId, cards)
-		if err != nil {
+		if err := t.PlayCards(cards); err != nil {
 			return err
 		}
 	}
 
-	hand, err := cp.g.PlayerHand(cp.playerId)
+	if err := playMustPlayCard(t); err != nil {
+		return err
+	}
+
+	view, err = t.PlayerView()
 	if err != nil {
 		return err
 	}
-	discard := cp.strategy.Discard(rummy.NewHand(hand))
+	discard := strategy.Discard(rummy.NewHand(valueSlice(view.Hand)))
 	glog.V(1).Infof("CP chose to dicard: %v", deck.CardString(discard))
-	return cp.g.DiscardCard(cp.playerId, discard)
+	return t.DiscardCard(discard)
+}
+
+func pickUpCards(t table, view *rummy.PlayerView, strategy strategy.Strategy) error {
+	discardPile := valueSlice(view.GameState.DiscardPile)
+	n := strategy.PickUpCards(discardPile)
+	glog.V(1).Infof("CP chose to pick up %d cards from discard", n)
+	if n > 0 {
+		// The pick up may not be allowed, e.g. by the game's rules variant,
+		// in which case we fall back to the stock.
+		err := t.PickUpDiscard(n)
+		if err == nil {
+			return nil
+		}
+		glog.V(1).Infof("CP could not pick up from discard: %v", err)
+	}
+
+	return t.PickUpStock()
+}
+
+// playMustPlayCard plays the card picked up from the discard pile
+// this turn, if the strategy has not already played it, so that
+// the turn can be completed.
+func playMustPlayCard(t table) error {
+	view, err := t.PlayerView()
+	if err != nil || view.MustPlayCard == nil {
+		return err
+	}
+
+	card := *view.MustPlayCard
+	hand := rummy.NewHand(valueSlice(view.Hand))
+	candidates := [][]deck.Card{{card}} // Rummy off an existing meld.
+	for _, m := range hand.Melds() {
+		for _, c := range m {
+			if c == card && len(hand) > len(m) {
+				candidates = append(candidates, m)
+				break
+			}
+		}
+	}
+
+	for _, cards := range candidates {
+		if err := t.PlayCards(cards); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to play required card %v", deck.CardString(card))
 }
@@ -0,0 +1,182 @@
+package ai
+
+import (
+	"io"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
+	"github.com/timpalpant/rummy/deck"
+)
+
+// PlayRemoteGame plays the given game through a RummyService client
+// with the given strategy, as the player with the given id and secret.
+// It sees only what the server shows to that player. It returns once
+// the game is over, the server closes the event stream, or ctx is done.
+func PlayRemoteGame(ctx context.Context, client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string, strategy strategy.Strategy) error {
+	t := &remoteTable{ctx, client, gameName, playerId, playerSecret}
+	rp := &remotePlayer{t, strategy}
+	view, err := t.PlayerView()
+	if err != nil {
+		return err
+	}
+
+	subscribe := func(fromSequence int64) (rummy.RummyService_SubscribeGameClient, error) {
+		return client.SubscribeGame(ctx, &rummy.SubscribeGameRequest{
+			GameName:     gameName,
+			FromSequence: fromSequence,
+			IsPlayer:     true,
+			PlayerId:     playerId,
+			PlayerSecret: playerSecret,
+		})
+	}
+	// Subscribe from just after the state we have seen, so that we do
+	// not miss our turn starting before the subscription is open.
+	lastSequence := view.GameState.LastSequence
+	stream, err := subscribe(lastSequence + 1)
+	if err != nil {
+		return err
+	}
+
+	// Events are received in the background so that the server is
+	// never blocked sending them while we are taking our turn.
+	events := make(chan *rummy.GameEvent, eventBufferSize)
+	streamErr := make(chan error, 1)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if status.Code(err) == codes.ResourceExhausted {
+				// We fell behind the game's events, so the server
+				// closed the stream. Pick up where we left off.
+				stream, err = subscribe(lastSequence + 1)
+				if err == nil {
+					continue
+				}
+			}
+			if err != nil {
+				streamErr <- err
+				return
+			}
+			if event.Sequence > lastSequence {
+				lastSequence = event.Sequence
+			}
+			events <- event
+		}
+	}()
+
+	// As with computerPlayer, our turn may already have started.
+	if view.IsMyTurn {
+		rp.playTurn()
+	}
+
+	for event := range events {
+		if event.PlayerId == playerId {
+			if event.Type == rummy.GameEvent_TURN_START {
+				rp.playTurn()
+			}
+		} else {
+			strategy.OnGameEvent(event)
+		}
+	}
+
+	if err := <-streamErr; err != io.EOF && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// remotePlayer is a computer player that plays through a RummyService client.
+type remotePlayer struct {
+	t        *remoteTable
+	strategy strategy.Strategy
+}
+
+// playTurn plays the player's turn, if it is their turn. Errors are
+// logged rather than returned, since the turn may be finished by
+// someone else at any time (e.g. if it times out), and the player
+// should keep playing in later turns.
+func (rp *remotePlayer) playTurn() {
+	view, err := rp.t.PlayerView()
+	if err != nil {
+		glog.Warningf("Error getting view of game %v for CP %v: %v",
+			rp.t.gameName, rp.t.playerId, err)
+		return
+	} else if !view.IsMyTurn {
+		return
+	}
+
+	glog.V(1).Infof("Starting CP turn")
+	if err := playTurn(rp.t, rp.strategy); err != nil {
+		glog.Warningf("Error playing turn in game %v for CP %v: %v",
+			rp.t.gameName, rp.t.playerId, err)
+	}
+}
+
+// remoteTable acts on a game through a RummyService client.
+type remoteTable struct {
+	ctx          context.Context
+	client       rummy.RummyServiceClient
+	gameName     string
+	playerId     int32
+	playerSecret string
+}
+
+func (t *remoteTable) PlayerView() (*rummy.PlayerView, error) {
+	return t.client.GetPlayerView(t.ctx, &rummy.GetPlayerViewRequest{
+		GameName:     t.gameName,
+		PlayerId:     t.playerId,
+		PlayerSecret: t.playerSecret,
+	})
+}
+
+func (t *remoteTable) PickUpStock() error {
+	_, err := t.client.PickUpStock(t.ctx, &rummy.PickUpStockRequest{
+		GameName:     t.gameName,
+		PlayerId:     t.playerId,
+		PlayerSecret: t.playerSecret,
+	})
+	return err
+}
+
+func (t *remoteTable) PickUpDiscard(nCards int) error {
+	_, err := t.client.PickUpDiscard(t.ctx, &rummy.PickUpDiscardRequest{
+		GameName:     t.gameName,
+		PlayerId:     t.playerId,
+		PlayerSecret: t.playerSecret,
+		NCards:       int32(nCards),
+	})
+	return err
+}
+
+func (t *remoteTable) PlayCards(cards []deck.Card) error {
+	_, err := t.client.PlayCards(t.ctx, &rummy.PlayCardsRequest{
+		GameName:     t.gameName,
+		PlayerId:     t.playerId,
+		PlayerSecret: t.playerSecret,
+		Cards:        protoCards(cards),
+	})
+	return err
+}
+
+func (t *remoteTable) DiscardCard(card deck.Card) error {
+	_, err := t.client.DiscardCard(t.ctx, &rummy.DiscardCardRequest{
+		GameName:     t.gameName,
+		PlayerId:     t.playerId,
+		PlayerSecret: t.playerSecret,
+		Card:         &card,
+	})
+	return err
+}
+
+func protoCards(cards []deck.Card) []*deck.Card {
+	result := make([]*deck.Card, len(cards))
+	for i := range cards {
+		result[i] = &cards[i]
+	}
+	return result
+}
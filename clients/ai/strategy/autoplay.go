@@ -0,0 +1,72 @@
+package strategy
+
+import (
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/deck"
+	"github.com/timpalpant/rummy/scoring"
+)
+
+// autoplayStrategy plays conservatively on behalf of a player who
+// has run out of time: it always picks up from the stock, plays any
+// melds in its hand, and discards the highest-valued card that is not
+// part of a meld, to limit the points left in hand.
+type autoplayStrategy struct {
+}
+
+func newAutoplayStrategy(params Params) Strategy {
+	return &autoplayStrategy{}
+}
+
+func (as *autoplayStrategy) PickUpCards(discardPile []deck.Card) int {
+	return 0
+}
+
+func (as *autoplayStrategy) PlayCards(hand rummy.Hand) []deck.Card {
+	for _, m := range hand.Melds() {
+		// We must keep a card to discard after playing the meld.
+		if len(hand) > len(m) {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (as *autoplayStrategy) Discard(hand rummy.Hand) deck.Card {
+	return highestDeadwood(hand)
+}
+
+// highestDeadwood returns the highest-valued card in the hand that
+// is not part of a meld, or the highest-valued card if they all are.
+func highestDeadwood(hand rummy.Hand) deck.Card {
+	inMeld := make(map[deck.Card]bool)
+	for _, m := range hand.Melds() {
+		for _, c := range m {
+			inMeld[c] = true
+		}
+	}
+
+	var toDiscard deck.Card
+	best := -1
+	for _, c := range hand.AsSlice() {
+		value := scoring.Value(c)
+		if !inMeld[c] {
+			// Prefer to discard deadwood over cards in melds.
+			value += 100
+		}
+		if value > best || (value == best && lessCard(c, toDiscard)) {
+			toDiscard, best = c, value
+		}
+	}
+
+	return toDiscard
+}
+
+func (as *autoplayStrategy) OnGameEvent(event *rummy.GameEvent) {
+}
+
+// lessCard orders cards by suit and then rank, so that ties are
+// broken deterministically.
+func lessCard(a, b deck.Card) bool {
+	return deck.BySuitAndRank([]deck.Card{a, b}).Less(0, 1)
+}
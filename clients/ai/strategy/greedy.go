@@ -12,13 +12,30 @@ import (
 // are possible.
 type greedyStrategy struct {
 	currentHand rummy.Hand
+	// The bottom card picked up from the discard pile this turn,
+	// if any, which must be played before discarding.
+	mustPlayCard *deck.Card
+	// Probability of picking up from the discard pile when
+	// the cards can be melded.
+	aggressiveness float64
+	// If true, discard the highest-valued deadwood
+	// rather than a random card.
+	discardDeadwood bool
 }
 
-func newGreedyStrategy() Strategy {
-	return &greedyStrategy{}
+func newGreedyStrategy(params Params) Strategy {
+	return &greedyStrategy{
+		aggressiveness:  params.Float("aggressiveness"),
+		discardDeadwood: params.Bool("discard_deadwood"),
+	}
 }
 
 func (gs *greedyStrategy) PickUpCards(discardPile []deck.Card) int {
+	gs.mustPlayCard = nil
+	if rand.Float64() >= gs.aggressiveness {
+		return 0
+	}
+
 	for i := 1; i <= len(discardPile); i++ {
 		bottomCard := len(discardPile) - i
 		cardsToPickUp := discardPile[bottomCard:]
@@ -30,10 +47,15 @@ func (gs *greedyStrategy) PickUpCards(discardPile []deck.Card) int {
 			hypotheticalHand[card] = struct{}{}
 		}
 
-		// Forms a meld, pick up from discard.
-		melds := hypotheticalHand.Melds()
-		if len(melds) > 0 {
-			return i
+		// Forms a meld with the bottom card (which must be played
+		// this turn), pick up from discard.
+		mustPlayCard := discardPile[bottomCard]
+		for _, m := range hypotheticalHand.Melds() {
+			// We must keep a card to discard after playing the meld.
+			if len(hypotheticalHand) > len(m) && containsCard(m, mustPlayCard) {
+				gs.mustPlayCard = &mustPlayCard
+				return i
+			}
 		}
 	}
 
@@ -42,8 +64,19 @@ func (gs *greedyStrategy) PickUpCards(discardPile []deck.Card) int {
 
 // greedyStrategy always plays all possible melds and rummies.
 func (gs *greedyStrategy) PlayCards(hand rummy.Hand) []deck.Card {
+	melds := hand.Melds()
+	// Play the card we are required to play first.
+	if gs.mustPlayCard != nil {
+		for _, m := range melds {
+			if len(hand) > len(m) && containsCard(m, *gs.mustPlayCard) {
+				gs.mustPlayCard = nil
+				return m
+			}
+		}
+	}
+
 	// Play any melds in our hand.
-	for _, m := range hand.Melds() {
+	for _, m := range melds {
 		if len(hand) > len(m) {
 			return m
 		}
@@ -54,10 +87,24 @@ func (gs *greedyStrategy) PlayCards(hand rummy.Hand) []deck.Card {
 	return nil
 }
 
+func containsCard(cards []deck.Card, card deck.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
+
 func (gs *greedyStrategy) Discard(hand rummy.Hand) deck.Card {
-	// Discard a random card.
-	i := rand.Intn(len(hand))
-	toDiscard := hand.AsSlice()[i]
+	var toDiscard deck.Card
+	if gs.discardDeadwood {
+		toDiscard = highestDeadwood(hand)
+	} else {
+		// Discard a random card.
+		i := rand.Intn(len(hand))
+		toDiscard = hand.AsSlice()[i]
+	}
 	delete(hand, toDiscard)
 	// Save current hand after discarding to assess picking up cards.
 	gs.currentHand = hand
@@ -2,22 +2,173 @@ package strategy
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/timpalpant/rummy"
 )
 
-type StrategyFactory func() Strategy
+// StrategyFactory creates a new instance of a Strategy with the given
+// parameter values, which have already been validated.
+type StrategyFactory func(params Params) Strategy
 
-var allStrategies = map[string]StrategyFactory{
-	"nop":    newNopStrategy,
-	"greedy": newGreedyStrategy,
+type registration struct {
+	factory     StrategyFactory
+	description string
+	// Numeric parameters must give their bounds.
+	params []*rummy.StrategyParam
 }
 
-// Get a new instance of the Strategy with the given name.
+var allStrategies = map[string]registration{
+	"nop": {
+		factory:     newNopStrategy,
+		description: "Always picks up from the stock and discards a random card.",
+	},
+	"greedy": {
+		factory: newGreedyStrategy,
+		description: "Plays every meld it can, and picks up from the discard pile " +
+			"whenever it can meld the cards it picks up.",
+		params: []*rummy.StrategyParam{
+			{
+				Name:         "aggressiveness",
+				Description:  "Probability of picking up from the discard pile when it can meld the cards.",
+				Type:         rummy.StrategyParam_FLOAT,
+				DefaultValue: "1",
+				Min:          0,
+				Max:          1,
+			},
+			{
+				Name:         "discard_deadwood",
+				Description:  "Discard the highest-valued card that is not in a meld, rather than a random card.",
+				Type:         rummy.StrategyParam_BOOL,
+				DefaultValue: "false",
+			},
+		},
+	},
+	"autoplay": {
+		factory: newAutoplayStrategy,
+		description: "Plays conservatively: always picks up from the stock, plays any melds, " +
+			"and discards the highest-valued card that is not in a meld.",
+	},
+}
+
+// Get a new instance of the Strategy with the given name,
+// with the default values of its parameters.
 func ForName(name string) (Strategy, error) {
-	factory, ok := allStrategies[name]
+	return ForNameWithParams(name, nil)
+}
+
+// ForNameWithParams gets a new instance of the Strategy with the given
+// name and parameter values, formatted according to their type.
+// Parameters that are not given take their default values.
+func ForNameWithParams(name string, values map[string]string) (Strategy, error) {
+	r, ok := allStrategies[name]
 	if !ok {
 		return nil, fmt.Errorf("unknown strategy: %v", name)
 	}
 
-	s := factory()
+	params, err := parseParams(r.params, values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters for strategy %v: %v", name, err)
+	}
+
+	s := r.factory(params)
 	return s, nil
 }
+
+// List describes all of the registered strategies, in order of name.
+func List() []*rummy.StrategyInfo {
+	result := make([]*rummy.StrategyInfo, 0, len(allStrategies))
+	for name, r := range allStrategies {
+		info := &rummy.StrategyInfo{
+			Name:        name,
+			Description: r.description,
+		}
+		for _, p := range r.params {
+			info.Params = append(info.Params, proto.Clone(p).(*rummy.StrategyParam))
+		}
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Params are the values of a strategy's parameters, by name, converted
+// to their type: int, float64 or bool. Every parameter of the strategy
+// has a value.
+type Params map[string]interface{}
+
+func (p Params) Int(name string) int {
+	return p[name].(int)
+}
+
+func (p Params) Float(name string) float64 {
+	return p[name].(float64)
+}
+
+func (p Params) Bool(name string) bool {
+	return p[name].(bool)
+}
+
+// parseParams validates the given values against the parameters of a
+// strategy, and converts them to their type.
+func parseParams(specs []*rummy.StrategyParam, values map[string]string) (Params, error) {
+	params := make(Params, len(specs))
+	for _, spec := range specs {
+		value, ok := values[spec.Name]
+		if !ok {
+			value = spec.DefaultValue
+		}
+
+		v, err := parseParam(spec, value)
+		if err != nil {
+			return nil, err
+		}
+		params[spec.Name] = v
+	}
+
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("unknown parameter: %v", name)
+		}
+	}
+
+	return params, nil
+}
+
+func parseParam(spec *rummy.StrategyParam, value string) (interface{}, error) {
+	switch spec.Type {
+	case rummy.StrategyParam_INT:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%v must be an integer", spec.Name)
+		}
+		return n, checkRange(spec, float64(n))
+	case rummy.StrategyParam_FLOAT:
+		x, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v must be a number", spec.Name)
+		}
+		return x, checkRange(spec, x)
+	case rummy.StrategyParam_BOOL:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%v must be true or false", spec.Name)
+		}
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("%v has unknown type %v", spec.Name, spec.Type)
+}
+
+func checkRange(spec *rummy.StrategyParam, x float64) error {
+	if x < spec.Min || x > spec.Max {
+		return fmt.Errorf("%v must be between %v and %v", spec.Name, spec.Min, spec.Max)
+	}
+	return nil
+}
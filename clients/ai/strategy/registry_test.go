@@ -0,0 +1,52 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestParseParams(t *testing.T) {
+	specs := []*rummy.StrategyParam{
+		{Name: "n", Type: rummy.StrategyParam_INT, DefaultValue: "3", Min: 1, Max: 10},
+		{Name: "p", Type: rummy.StrategyParam_FLOAT, DefaultValue: "0.5", Min: 0, Max: 1},
+		{Name: "b", Type: rummy.StrategyParam_BOOL, DefaultValue: "false"},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected Params
+		ok       bool
+	}{
+		{"defaults", nil, Params{"n": 3, "p": 0.5, "b": false}, true},
+		{"values", map[string]string{"n": "10", "p": "0", "b": "true"},
+			Params{"n": 10, "p": 0.0, "b": true}, true},
+		{"some values", map[string]string{"p": "1"}, Params{"n": 3, "p": 1.0, "b": false}, true},
+		{"int below min", map[string]string{"n": "0"}, nil, false},
+		{"int above max", map[string]string{"n": "11"}, nil, false},
+		{"float for int", map[string]string{"n": "2.5"}, nil, false},
+		{"float out of range", map[string]string{"p": "1.5"}, nil, false},
+		{"not a number", map[string]string{"p": "half"}, nil, false},
+		{"not a bool", map[string]string{"b": "yes"}, nil, false},
+		{"unknown parameter", map[string]string{"x": "1"}, nil, false},
+	}
+
+	for _, tc := range tests {
+		result, err := parseParams(specs, tc.values)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: parseParams returned %v, expected ok = %v", tc.name, err, tc.ok)
+		} else if tc.ok && !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("%v: parseParams = %v, expected %v", tc.name, result, tc.expected)
+		}
+	}
+}
+
+func TestDefaultParams(t *testing.T) {
+	for _, info := range List() {
+		if _, err := ForName(info.Name); err != nil {
+			t.Errorf("default parameters of strategy %v are invalid: %v", info.Name, err)
+		}
+	}
+}
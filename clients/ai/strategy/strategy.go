@@ -41,7 +41,7 @@ type Strategy interface {
 type nopStrategy struct {
 }
 
-func newNopStrategy() Strategy {
+func newNopStrategy(params Params) Strategy {
 	return &nopStrategy{}
 }
 
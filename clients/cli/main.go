@@ -16,36 +16,98 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/timpalpant/rummy"
 	"github.com/timpalpant/rummy/deck"
+	"github.com/timpalpant/rummy/tlsutil"
 )
 
-var stdin = bufio.NewReader(os.Stdin)
+// How long to wait before resubscribing to a game after
+// the connection to the server is lost.
+const reconnectDelay = 2 * time.Second
+
+// Lines read from stdin. They are read in the background so that
+// players can chat while waiting for their turn.
+var stdinLines = readLines(os.Stdin)
+
+// How many game events may be received while the player
+// is busy taking their turn.
+const eventBufferSize = 1000
+
+// If set, games are joined as this account, so that
+// they count towards its stats and rating.
+var (
+	accountName  = flag.String("account", "", "Account to join games as")
+	accountToken = flag.String("account_token", "", "Token of -account")
+)
+
+// readLines returns a channel of the lines read from r,
+// which is closed once there is no more input.
+func readLines(r io.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
 
 func prompt(msg string) string {
 	fmt.Print(msg)
-	result, err := stdin.ReadString('\n')
-	if err != nil {
-		panic(err)
+	result, ok := <-stdinLines
+	if !ok {
+		panic(io.EOF)
 	}
 
-	return strings.TrimRight(result, "\n")
+	return result
 }
 
-func addCP(client rummy.RummyServiceClient, gameName string) error {
+func addCP(client rummy.RummyServiceClient, gameName, inviteCode string) error {
+	resp, err := client.ListStrategies(context.Background(), &rummy.ListStrategiesRequest{})
+	if err != nil {
+		return err
+	}
+	strategies := make(map[string]*rummy.StrategyInfo, len(resp.Strategies))
+	for _, info := range resp.Strategies {
+		strategies[info.Name] = info
+	}
+
 	n := 0
 	for {
 		if prompt("Add CP? (y/n): ") != "y" {
 			break
 		}
 
+		fmt.Println("Available strategies:")
+		for _, info := range resp.Strategies {
+			fmt.Printf("\t%v: %v\n", info.Name, info.Description)
+		}
 		strategyName := prompt("Enter strategy name: ")
+		info, ok := strategies[strategyName]
+		if !ok {
+			fmt.Printf("Unknown strategy: %v\n", strategyName)
+			continue
+		}
+
+		params := make(map[string]string)
+		for _, p := range info.Params {
+			value := prompt(fmt.Sprintf("%v (%v) [%v]: ", p.Name, p.Description, p.DefaultValue))
+			if value != "" {
+				params[p.Name] = value
+			}
+		}
+
 		playerName := fmt.Sprintf("CP%d-%v", n, strategyName)
 		_, err := client.JoinGame(context.Background(), &rummy.JoinGameRequest{
-			GameName:   gameName,
-			PlayerName: playerName,
-			Strategy:   strategyName,
+			GameName:       gameName,
+			PlayerName:     playerName,
+			Strategy:       strategyName,
+			StrategyParams: params,
+			InviteCode:     inviteCode,
 		})
 		if err != nil {
 			return err
@@ -56,73 +118,96 @@ func addCP(client rummy.RummyServiceClient, gameName string) error {
 	return nil
 }
 
-func createGame(client rummy.RummyServiceClient) (string, int32, error) {
+func createGame(client rummy.RummyServiceClient) (string, int32, string, error) {
 	gameName := prompt("Enter game name: ")
-	_, err := client.CreateGame(context.Background(), &rummy.CreateGameRequest{
+	opts := rummy.DefaultGameOptions()
+	if prompt("Private game? (y/n): ") == "y" {
+		opts.Visibility = rummy.GameOptions_PRIVATE
+	}
+	createResp, err := client.CreateGame(context.Background(), &rummy.CreateGameRequest{
 		GameName: gameName,
+		Options:  opts,
 	})
 	if err != nil {
-		return "", 0, err
+		return "", 0, "", err
+	}
+	inviteCode := createResp.InviteCode
+	if inviteCode != "" {
+		fmt.Printf("Invite code: %v\n", inviteCode)
 	}
 
 	playerName := prompt("Enter player name: ")
 	resp, err := client.JoinGame(context.Background(), &rummy.JoinGameRequest{
-		GameName:   gameName,
-		PlayerName: playerName,
+		GameName:     gameName,
+		PlayerName:   playerName,
+		InviteCode:   inviteCode,
+		AccountName:  *accountName,
+		AccountToken: *accountToken,
 	})
 	if err != nil {
-		return "", 0, err
+		return "", 0, "", err
 	}
-	playerId := resp.PlayerId
+	playerId, playerSecret := resp.PlayerId, resp.PlayerSecret
 
-	if err := addCP(client, gameName); err != nil {
-		return "", 0, err
+	if err := addCP(client, gameName, inviteCode); err != nil {
+		return "", 0, "", err
 	}
 
 	var start string
 	for start != "y" {
 		resp, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
-			GameName: gameName,
+			GameName:     gameName,
+			IsPlayer:     true,
+			PlayerId:     playerId,
+			PlayerSecret: playerSecret,
 		})
 		if err != nil {
-			return "", 0, err
-		}
-
-		fmt.Println("Current players in game:")
-		for _, player := range resp.Players {
-			fmt.Printf("\t%v: %v\n", player.Id, player.Name)
+			return "", 0, "", err
 		}
 
+		printLobby(resp.Players)
 		start = prompt("Start game? (y/n): ")
 	}
 
 	_, err = client.StartGame(context.Background(), &rummy.StartGameRequest{
-		GameName: gameName,
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	})
 
-	return gameName, playerId, err
+	return gameName, playerId, playerSecret, err
 }
 
-func joinGame(client rummy.RummyServiceClient) (string, int32, error) {
+func joinGame(client rummy.RummyServiceClient) (string, int32, string, error) {
 	gameName := prompt("Enter game name: ")
 	playerName := prompt("Enter player name: ")
+	inviteCode := prompt("Enter invite code (blank for public games): ")
 
 	resp, err := client.JoinGame(context.Background(), &rummy.JoinGameRequest{
-		GameName:   gameName,
-		PlayerName: playerName,
+		GameName:     gameName,
+		PlayerName:   playerName,
+		InviteCode:   inviteCode,
+		AccountName:  *accountName,
+		AccountToken: *accountToken,
 	})
 	if err != nil {
-		return "", 0, err
+		return "", 0, "", err
 	}
 
+	playerId, playerSecret := resp.PlayerId, resp.PlayerSecret
+
 	fmt.Println("Waiting for game to start")
-	nPlayers := 0
+	optionsPrinted := false
+	var lastPlayers string
 	for {
 		resp, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
-			GameName: gameName,
+			GameName:     gameName,
+			IsPlayer:     true,
+			PlayerId:     playerId,
+			PlayerSecret: playerSecret,
 		})
 		if err != nil {
-			return "", 0, err
+			return "", 0, "", err
 		}
 
 		if resp.CurrentPlayerTurn != -1 {
@@ -130,23 +215,75 @@ func joinGame(client rummy.RummyServiceClient) (string, int32, error) {
 			break
 		}
 
-		if len(resp.Players) != nPlayers {
-			fmt.Println("Current players in game:")
-			for _, player := range resp.Players {
-				fmt.Printf("\t%v: %v\n", player.Id, player.Name)
-			}
-			nPlayers = len(resp.Players)
+		if !optionsPrinted {
+			printGameOptions(resp.Options)
+			optionsPrinted = true
+		}
+
+		// Players may join, or leave and free their seat.
+		if players := fmt.Sprint(resp.Players); players != lastPlayers {
+			printLobby(resp.Players)
+			lastPlayers = players
 		}
 
 		time.Sleep(2 * time.Second)
 	}
 
-	return gameName, resp.PlayerId, nil
+	return gameName, playerId, playerSecret, nil
 }
 
-func playGame(client rummy.RummyServiceClient, gameName string, playerId int32) {
+// printLobby prints the players who have joined a game that has not
+// started yet, skipping seats that were left.
+func printLobby(players []*rummy.PlayerState) {
+	fmt.Println("Current players in game:")
+	for _, player := range players {
+		if !player.Forfeited {
+			fmt.Printf("\t%v: %v\n", player.Id, player.Name)
+		}
+	}
+}
+
+func findMatch(client rummy.RummyServiceClient) (string, int32, string, error) {
+	playerName := prompt("Enter player name: ")
+	numPlayers, err := strconv.Atoi(prompt("Number of players: "))
+	if err != nil {
+		return "", 0, "", err
+	}
+	allowBots := prompt("Fill empty seats with CPs if no one is found? (y/n): ") == "y"
+
+	stream, err := client.FindMatch(context.Background(), &rummy.FindMatchRequest{
+		PlayerName:   playerName,
+		NumPlayers:   int32(numPlayers),
+		AllowBots:    allowBots,
+		AccountName:  *accountName,
+		AccountToken: *accountToken,
+	})
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return "", 0, "", fmt.Errorf("no match found")
+		} else if err != nil {
+			return "", 0, "", err
+		}
+
+		if resp.GameName != "" {
+			fmt.Printf("Found a match: %v\n", resp.GameName)
+			return resp.GameName, resp.PlayerId, resp.PlayerSecret, nil
+		}
+		fmt.Printf("Waiting for players (%v/%v)\n", resp.PlayersWaiting, numPlayers)
+	}
+}
+
+func playGame(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) {
 	resp, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
-		GameName: gameName,
+		GameName:     gameName,
+		IsPlayer:     true,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -157,38 +294,86 @@ func playGame(client rummy.RummyServiceClient, gameName string, playerId int32)
 		playerNames[p.Id] = p.Name
 	}
 
+	// Subscribe from just after the state we have seen. If our turn
+	// has already started, its TURN_START will not be sent again,
+	// so check whether it is our turn from the state itself.
 	req := &rummy.SubscribeGameRequest{
-		GameName: gameName,
+		GameName:     gameName,
+		FromSequence: resp.LastSequence + 1,
+		IsPlayer:     true,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	}
 	stream, err := client.SubscribeGame(context.Background(), req)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	events := receiveEvents(stream)
 
-	if resp.CurrentPlayerTurn == playerId {
-		// We're up first, play our first turn.
-		if err := playTurn(client, gameName, playerId); err != nil {
+	if resp.Status == rummy.GameState_IN_PROGRESS && resp.CurrentPlayerTurn == playerId {
+		if err := playTurn(client, gameName, playerId, playerSecret); err != nil {
 			fmt.Println(err)
 			return
 		}
-	} else {
-		fmt.Printf("%v's turn\n", playerNames[resp.CurrentPlayerTurn])
 	}
 
+	fmt.Println("Type a message and press enter to chat while you wait")
+	lines := stdinLines
 	for {
-		resp, err := stream.Recv()
-		if err == io.EOF {
+		var r streamResult
+		select {
+		case r = <-events:
+		case line, ok := <-lines:
+			if !ok {
+				lines = nil
+			} else if line != "" {
+				sendChatMessage(client, gameName, playerId, playerSecret, line)
+			}
+			continue
+		}
+
+		if r.err == io.EOF {
 			break
 		}
-		if err != nil {
-			fmt.Println(err)
+		if r.err != nil {
+			// Reconnect and pick up where we left off.
+			fmt.Printf("Lost connection to game: %v, reconnecting\n", r.err)
+			time.Sleep(reconnectDelay)
+			stream, err = client.SubscribeGame(context.Background(), req)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			events = receiveEvents(stream)
+			// If we were away long enough for a computer player to take
+			// over our seat, take it back.
+			if _, err := client.ReclaimSeat(context.Background(), &rummy.ReclaimSeatRequest{
+				GameName:     gameName,
+				PlayerId:     playerId,
+				PlayerSecret: playerSecret,
+			}); err == nil {
+				fmt.Println("Reclaimed seat from computer player")
+			}
+			continue
+		}
+		resp := r.event
+		req.FromSequence = resp.Sequence + 1
+		if resp.Type == rummy.GameEvent_PLAYER_JOINED && int(resp.PlayerId) >= len(playerNames) {
+			playerNames = append(playerNames, resp.PlayerName)
+		}
+
+		if resp.Type == rummy.GameEvent_GAME_EXPIRED {
+			fmt.Println("Game expired due to inactivity")
+			return
+		} else if resp.Type == rummy.GameEvent_SERVER_SHUTDOWN {
+			fmt.Println("Server is shutting down, please re-join when it restarts")
 			return
 		}
 
 		if resp.PlayerId == playerId {
 			if resp.Type == rummy.GameEvent_TURN_START {
-				if err := playTurn(client, gameName, playerId); err != nil {
+				if err := playTurn(client, gameName, playerId, playerSecret); err != nil {
 					fmt.Println(err)
 					return
 				}
@@ -198,7 +383,132 @@ func playGame(client rummy.RummyServiceClient, gameName string, playerId int32)
 		}
 	}
 
-	printEndGame(client, gameName)
+	printEndGame(client, &rummy.GetGameStateRequest{
+		GameName:     gameName,
+		IsPlayer:     true,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
+	})
+}
+
+// streamResult is an event received from a game's stream,
+// or the error that ended the stream.
+type streamResult struct {
+	event *rummy.GameEvent
+	err   error
+}
+
+// receiveEvents receives events from the stream in the background,
+// so that they are not held up while the player is taking their turn.
+func receiveEvents(stream rummy.RummyService_SubscribeGameClient) <-chan streamResult {
+	results := make(chan streamResult, eventBufferSize)
+	go func() {
+		for {
+			e, err := stream.Recv()
+			results <- streamResult{e, err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return results
+}
+
+func spectateGame(client rummy.RummyServiceClient) error {
+	gameName := prompt("Enter game name: ")
+	spectatorName := prompt("Enter spectator name: ")
+	inviteCode := prompt("Enter invite code (blank for public games): ")
+
+	joinResp, err := client.JoinSpectator(context.Background(), &rummy.JoinSpectatorRequest{
+		GameName:      gameName,
+		SpectatorName: spectatorName,
+		InviteCode:    inviteCode,
+	})
+	if err != nil {
+		return err
+	}
+	token := joinResp.SpectatorToken
+
+	stateReq := &rummy.GetGameStateRequest{
+		GameName:       gameName,
+		SpectatorToken: token,
+	}
+	resp, err := client.GetGameState(context.Background(), stateReq)
+	if err != nil {
+		return err
+	}
+	printGameOptions(resp.Options)
+
+	stream, err := client.SubscribeGame(context.Background(), &rummy.SubscribeGameRequest{
+		GameName:       gameName,
+		SpectatorToken: token,
+	})
+	if err != nil {
+		return err
+	}
+
+	var playerNames []string
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if e.Type == rummy.GameEvent_GAME_EXPIRED {
+			fmt.Println("Game expired due to inactivity")
+			return nil
+		} else if e.Type == rummy.GameEvent_SERVER_SHUTDOWN {
+			fmt.Println("Server is shutting down")
+			return nil
+		}
+
+		// Players may join after we start watching.
+		if int(e.PlayerId) >= len(playerNames) {
+			resp, err := client.GetGameState(context.Background(), stateReq)
+			if err != nil {
+				return err
+			}
+			playerNames = make([]string, len(resp.Players))
+			for _, p := range resp.Players {
+				playerNames[p.Id] = p.Name
+			}
+		}
+		if int(e.PlayerId) < len(playerNames) {
+			printGameEvent(e, playerNames)
+		}
+	}
+
+	printEndGame(client, stateReq)
+	return nil
+}
+
+func listGames(client rummy.RummyServiceClient) error {
+	resp, err := client.ListGames(context.Background(), &rummy.ListGamesRequest{})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Games) == 0 {
+		fmt.Println("No public games")
+	}
+	for _, g := range resp.Games {
+		fmt.Printf("\t%v (%v, %v/%v players)\n", g.GameName, g.Status,
+			g.NumPlayers, g.Options.MaxPlayers)
+	}
+	return nil
+}
+
+func printGameOptions(opts *rummy.GameOptions) {
+	fmt.Printf("Rules: %v, %v-%v players", opts.RulesVariant, opts.MinPlayers, opts.MaxPlayers)
+	if opts.TurnTimeLimitSeconds > 0 {
+		fmt.Printf(", %vs per turn", opts.TurnTimeLimitSeconds)
+	}
+	if opts.TargetScore > 0 {
+		fmt.Printf(", match target score %v", opts.TargetScore)
+	}
+	fmt.Println()
 }
 
 func printGameEvent(e *rummy.GameEvent, playerNames []string) {
@@ -215,6 +525,24 @@ func printGameEvent(e *rummy.GameEvent, playerNames []string) {
 		s = s + " played cards"
 	case rummy.GameEvent_DISCARD:
 		s = s + " discarded"
+	case rummy.GameEvent_TURN_TIMEOUT:
+		s = s + " ran out of time, their turn will be played automatically."
+	case rummy.GameEvent_PLAYER_AWAY:
+		s = s + " is away."
+	case rummy.GameEvent_PLAYER_RETURNED:
+		s = s + " has returned."
+	case rummy.GameEvent_PLAYER_LEFT, rummy.GameEvent_PLAYER_FORFEIT:
+		s = s + " left the game."
+	case rummy.GameEvent_PLAYER_JOINED:
+		s = s + " joined the game."
+	case rummy.GameEvent_GAME_STARTED:
+		s = "The game has started, " + s + " goes first. The discard pile is"
+	case rummy.GameEvent_STOCK_RESHUFFLED:
+		s = "The discard pile was shuffled to form a new stock"
+	case rummy.GameEvent_RUMMY_CALLED:
+		s = s + " called rummy"
+	case rummy.GameEvent_CHAT:
+		s = s + " says: " + e.Message
 	}
 
 	if len(e.Cards) > 0 {
@@ -236,21 +564,21 @@ func valueSlice(cards []*deck.Card) []deck.Card {
 	return result
 }
 
-func printCurrentStatus(client rummy.RummyServiceClient, gameName string, playerId int32) error {
-	resp, err := client.GetHandCards(context.Background(), &rummy.GetHandCardsRequest{
-		GameName: gameName,
-		PlayerId: playerId,
+func printCurrentStatus(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) error {
+	view, err := client.GetPlayerView(context.Background(), &rummy.GetPlayerViewRequest{
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	})
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Current hand: %v\n", ppCards(resp.Cards, true))
+	fmt.Printf("Current hand: %v\n", ppCards(view.Hand, true))
 
-	gs, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
-		GameName: gameName,
-	})
-	if err != nil {
-		return err
+	gs := view.GameState
+	if gs.TurnTimeRemainingMs > 0 {
+		fmt.Printf("Time remaining: %v\n",
+			time.Duration(gs.TurnTimeRemainingMs)*time.Millisecond)
 	}
 	fmt.Printf("Current discard pile: %v\n", ppCards(gs.DiscardPile, false))
 
@@ -261,6 +589,10 @@ func printCurrentStatus(client rummy.RummyServiceClient, gameName string, player
 
 	fmt.Println("Current player status:")
 	for _, p := range gs.Players {
+		// Seats left before the game started have no name.
+		if p.Name == "" {
+			continue
+		}
 		fmt.Printf("\t%v: %v cards, %v points\n",
 			p.Name, p.NumCardsInHand, p.CurrentScore)
 	}
@@ -268,17 +600,21 @@ func printCurrentStatus(client rummy.RummyServiceClient, gameName string, player
 	return nil
 }
 
-func playTurn(client rummy.RummyServiceClient, gameName string, playerId int32) error {
+func playTurn(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) error {
 	fmt.Println("\nYour turn!")
-	if err := printCurrentStatus(client, gameName, playerId); err != nil {
+	if err := printCurrentStatus(client, gameName, playerId, playerSecret); err != nil {
 		return err
 	}
 
-	pickUpCards(client, gameName, playerId)
+	if !pickUpCards(client, gameName, playerId, playerSecret) {
+		return nil
+	}
 
 	for {
-		playCards(client, gameName, playerId)
-		if err := discard(client, gameName, playerId); err == nil {
+		playCards(client, gameName, playerId, playerSecret)
+		if err := discard(client, gameName, playerId, playerSecret); err == nil {
+			break
+		} else if turnOver(client, gameName, playerId, playerSecret) {
 			break
 		} else {
 			fmt.Printf("Error discarding: %v\n", err)
@@ -288,35 +624,96 @@ func playTurn(client rummy.RummyServiceClient, gameName string, playerId int32)
 	return nil
 }
 
-func pickUpCards(client rummy.RummyServiceClient, gameName string, playerId int32) {
+// turnOver returns true if it is no longer the player's turn,
+// e.g. because they ran out of time.
+func turnOver(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) bool {
+	view, err := client.GetPlayerView(context.Background(), &rummy.GetPlayerViewRequest{
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
+	})
+	if err != nil || view.IsMyTurn {
+		return false
+	}
+
+	fmt.Println("Your turn is over")
+	return true
+}
+
+// pickUpCards returns false if the player's turn ended before
+// they picked up cards.
+func pickUpCards(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) bool {
 	for {
 		fmt.Println("\nWhat would you like to do?")
 		fmt.Println("\t1) Pick up a card from the stock")
 		fmt.Println("\t2) Pick up card(s) from the discard pile")
+		fmt.Println("\t3) Send a chat message")
+		fmt.Println("\t4) Leave the game (forfeit)")
 		choice := prompt("Selection: ")
 		switch choice {
 		case "1":
-			if err := pickUpStock(client, gameName, playerId); err != nil {
-				fmt.Printf("Error picking up from stock: %v\n", err)
+			if err := pickUpStock(client, gameName, playerId, playerSecret); err == nil {
+				return true
+			} else if turnOver(client, gameName, playerId, playerSecret) {
+				return false
 			} else {
-				return
+				fmt.Printf("Error picking up from stock: %v\n", err)
 			}
 		case "2":
-			if err := pickUpDiscard(client, gameName, playerId); err != nil {
+			if err := pickUpDiscard(client, gameName, playerId, playerSecret); err == nil {
+				return true
+			} else if turnOver(client, gameName, playerId, playerSecret) {
+				return false
+			} else {
 				fmt.Printf("Error picking up from discard: %v\n", err)
+			}
+		case "3":
+			sendChat(client, gameName, playerId, playerSecret)
+		case "4":
+			if prompt("Are you sure? (y/n): ") != "y" {
+				continue
+			}
+			if _, err := client.LeaveGame(context.Background(), &rummy.LeaveGameRequest{
+				GameName:     gameName,
+				PlayerId:     playerId,
+				PlayerSecret: playerSecret,
+			}); err != nil {
+				fmt.Printf("Error leaving game: %v\n", err)
 			} else {
-				return
+				fmt.Println("You have left the game")
+				return false
 			}
 		default:
-			fmt.Println("Invalid selection, please choose 1 or 2")
+			fmt.Println("Invalid selection, please choose 1, 2, 3 or 4")
 		}
 	}
 }
 
-func pickUpStock(client rummy.RummyServiceClient, gameName string, playerId int32) error {
+func sendChat(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) {
+	message := prompt("Message: ")
+	if message == "" {
+		return
+	}
+	sendChatMessage(client, gameName, playerId, playerSecret, message)
+}
+
+func sendChatMessage(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret, message string) {
+	_, err := client.SendChat(context.Background(), &rummy.SendChatRequest{
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
+		Message:      message,
+	})
+	if err != nil {
+		fmt.Printf("Error sending message: %v\n", err)
+	}
+}
+
+func pickUpStock(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) error {
 	resp, err := client.PickUpStock(context.Background(), &rummy.PickUpStockRequest{
-		GameName: gameName,
-		PlayerId: playerId,
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	})
 	if err != nil {
 		return err
@@ -325,7 +722,7 @@ func pickUpStock(client rummy.RummyServiceClient, gameName string, playerId int3
 	return nil
 }
 
-func pickUpDiscard(client rummy.RummyServiceClient, gameName string, playerId int32) error {
+func pickUpDiscard(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) error {
 	n := 0
 	var err error
 	for n == 0 {
@@ -337,9 +734,10 @@ func pickUpDiscard(client rummy.RummyServiceClient, gameName string, playerId in
 	}
 
 	resp, err := client.PickUpDiscard(context.Background(), &rummy.PickUpDiscardRequest{
-		GameName: gameName,
-		PlayerId: playerId,
-		NCards:   int32(n),
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
+		NCards:       int32(n),
 	})
 	if err != nil {
 		return err
@@ -348,25 +746,30 @@ func pickUpDiscard(client rummy.RummyServiceClient, gameName string, playerId in
 	return nil
 }
 
-func playCards(client rummy.RummyServiceClient, gameName string, playerId int32) {
+func playCards(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) {
 	for {
-		resp, err := client.GetHandCards(context.Background(), &rummy.GetHandCardsRequest{
-			GameName: gameName,
-			PlayerId: playerId,
+		view, err := client.GetPlayerView(context.Background(), &rummy.GetPlayerViewRequest{
+			GameName:     gameName,
+			PlayerId:     playerId,
+			PlayerSecret: playerSecret,
 		})
 		if err != nil {
 			fmt.Printf("Error getting current hand: %v\n", err)
 			continue
 		}
-		sort.Sort(bySuitAndRank(resp.Cards))
-		numbered := make([]string, len(resp.Cards))
-		card2Idx := make(map[deck.Card]int, len(resp.Cards))
-		for i, c := range resp.Cards {
+		hand := view.Hand
+		numbered := make([]string, len(hand))
+		card2Idx := make(map[deck.Card]int, len(hand))
+		for i, c := range hand {
 			numbered[i] = fmt.Sprintf("%d:%v", i, deck.CardString(*c))
 			card2Idx[*c] = i
 		}
 		fmt.Printf("Current hand: %v\n", strings.Join(numbered, " "))
-		possibleMelds := rummy.NewHand(valueSlice(resp.Cards)).Melds()
+		if view.MustPlayCard != nil {
+			fmt.Printf("You must play %v before discarding\n",
+				deck.CardString(*view.MustPlayCard))
+		}
+		possibleMelds := rummy.NewHand(valueSlice(hand)).Melds()
 		if len(possibleMelds) > 0 {
 			fmt.Println("Possible melds:")
 			for _, m := range possibleMelds {
@@ -386,16 +789,17 @@ func playCards(client rummy.RummyServiceClient, gameName string, playerId int32)
 			break
 		}
 
-		cards, err := parseCardSelection(resp.Cards, cardsToPlay)
+		cards, err := parseCardSelection(hand, cardsToPlay)
 		if err != nil {
 			fmt.Printf("Cannot select '%v': %v\n", cardsToPlay, err)
 			continue
 		}
 
 		playResp, err := client.PlayCards(context.Background(), &rummy.PlayCardsRequest{
-			GameName: gameName,
-			PlayerId: playerId,
-			Cards:    cards,
+			GameName:     gameName,
+			PlayerId:     playerId,
+			PlayerSecret: playerSecret,
+			Cards:        cards,
 		})
 		if err != nil {
 			fmt.Println(err)
@@ -421,10 +825,11 @@ func parseCardSelection(hand []*deck.Card, selectionStr string) ([]*deck.Card, e
 	return result, nil
 }
 
-func discard(client rummy.RummyServiceClient, gameName string, playerId int32) error {
+func discard(client rummy.RummyServiceClient, gameName string, playerId int32, playerSecret string) error {
 	resp, err := client.GetHandCards(context.Background(), &rummy.GetHandCardsRequest{
-		GameName: gameName,
-		PlayerId: playerId,
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
 	})
 	if err != nil {
 		return fmt.Errorf("Error getting current hand: %v", err)
@@ -442,9 +847,10 @@ func discard(client rummy.RummyServiceClient, gameName string, playerId int32) e
 	}
 
 	_, err = client.DiscardCard(context.Background(), &rummy.DiscardCardRequest{
-		GameName: gameName,
-		PlayerId: playerId,
-		Card:     cards[0],
+		GameName:     gameName,
+		PlayerId:     playerId,
+		PlayerSecret: playerSecret,
+		Card:         cards[0],
 	})
 	return err
 }
@@ -480,10 +886,8 @@ func ppCards(cards []*deck.Card, sorted bool) string {
 	return fmt.Sprintf("%v", result)
 }
 
-func printEndGame(client rummy.RummyServiceClient, gameName string) {
-	resp, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
-		GameName: gameName,
-	})
+func printEndGame(client rummy.RummyServiceClient, req *rummy.GetGameStateRequest) {
+	resp, err := client.GetGameState(context.Background(), req)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -495,11 +899,28 @@ func printEndGame(client rummy.RummyServiceClient, gameName string) {
 	}
 
 	sort.Sort(byScore(resp.Players))
-	winner := resp.Players[0]
+	// Players who left the game cannot win.
+	var winner *rummy.PlayerState
+	for _, player := range resp.Players {
+		if !player.Forfeited {
+			winner = player
+			break
+		}
+	}
 	fmt.Printf("Game over: %v wins!\n", winner.Name)
 	fmt.Println("Final scores:")
 	for _, player := range resp.Players {
-		fmt.Printf("\t%v: %v\n", player.Name, player.CurrentScore)
+		if player.Forfeited {
+			fmt.Printf("\t%v: %v (forfeited)\n", player.Name, player.CurrentScore)
+		} else {
+			fmt.Printf("\t%v: %v\n", player.Name, player.CurrentScore)
+		}
+	}
+
+	// All hands are revealed once the game is over.
+	fmt.Println("Final hands:")
+	for _, player := range resp.Players {
+		fmt.Printf("\t%v: %v\n", player.Name, ppCards(player.Hand, true))
 	}
 }
 
@@ -519,15 +940,184 @@ func (b byScore) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
 
+func createTournament(client rummy.RummyServiceClient) error {
+	req := &rummy.CreateTournamentRequest{
+		TournamentName: prompt("Enter tournament name: "),
+	}
+	switch prompt("Format (1: round robin, 2: single elimination, 3: Swiss): ") {
+	case "2":
+		req.Format = rummy.Tournament_SINGLE_ELIMINATION
+	case "3":
+		req.Format = rummy.Tournament_SWISS
+	}
+
+	fmt.Println("Enter entrants in seed order, leaving the name empty when done")
+	for {
+		name := prompt("Entrant name: ")
+		if name == "" {
+			break
+		}
+		req.Entrants = append(req.Entrants, &rummy.TournamentEntrant{
+			Name:     name,
+			Strategy: prompt("Strategy (leave empty for human players): "),
+		})
+	}
+
+	resp, err := client.CreateTournament(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Tournament started. Players must use these secrets to play:")
+	for i, e := range req.Entrants {
+		if e.Strategy == "" {
+			fmt.Printf("\t%v: %v\n", e.Name, resp.PlayerSecrets[i])
+		}
+	}
+	return nil
+}
+
+// followTournament prints the standings of a tournament as it
+// progresses, and plays the games of the given entrant, if any.
+func followTournament(client rummy.RummyServiceClient) error {
+	tournamentName := prompt("Enter tournament name: ")
+	entrantName := prompt("Enter your entrant name (leave empty to watch): ")
+	var playerSecret string
+	if entrantName != "" {
+		playerSecret = prompt("Enter your secret: ")
+	}
+
+	stream, err := client.SubscribeTournament(context.Background(), &rummy.SubscribeTournamentRequest{
+		TournamentName: tournamentName,
+	})
+	if err != nil {
+		return err
+	}
+
+	played := make(map[string]bool)
+	for {
+		t, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		printTournament(t)
+
+		for _, m := range t.Matches {
+			if m.Completed || m.GameName == "" || played[m.GameName] {
+				continue
+			}
+			for playerId, idx := range m.Entrants {
+				if t.Entrants[idx].Name == entrantName {
+					played[m.GameName] = true
+					fmt.Printf("\nYour game %v is starting\n", m.GameName)
+					playGame(client, m.GameName, int32(playerId), playerSecret)
+				}
+			}
+		}
+	}
+}
+
+func printTournament(t *rummy.Tournament) {
+	if t.Status == rummy.Tournament_COMPLETED {
+		fmt.Printf("\n%v is over: %v wins!\n", t.Name, t.Entrants[t.Standings[0]].Name)
+	} else {
+		fmt.Printf("\n%v: round %v of %v\n", t.Name, t.Round, t.NumRounds)
+	}
+
+	fmt.Println("Standings:")
+	for i, idx := range t.Standings {
+		e := t.Entrants[idx]
+		s := fmt.Sprintf("\t%d. %v: %v-%v, %v points", i+1, e.Name, e.Wins, e.Losses, e.Points)
+		if e.Eliminated {
+			s += " (eliminated)"
+		}
+		fmt.Println(s)
+	}
+}
+
+func createAccount(client rummy.RummyServiceClient) error {
+	name := prompt("Enter account name: ")
+	resp, err := client.CreateAccount(context.Background(), &rummy.CreateAccountRequest{
+		AccountName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created account %v with token %v\n", name, resp.AccountToken)
+	fmt.Printf("Restart with -account %v -account_token %v to play as this account\n",
+		name, resp.AccountToken)
+	return nil
+}
+
+func showLeaderboard(client rummy.RummyServiceClient) error {
+	resp, err := client.GetLeaderboard(context.Background(), &rummy.GetLeaderboardRequest{
+		Limit: 20,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Leaderboard:")
+	for i, p := range resp.Players {
+		fmt.Printf("\t%d. %v: %.0f (%v games, %.0f%% won)\n",
+			i+1, p.AccountName, p.Rating, p.GamesPlayed, 100*p.WinRate)
+	}
+
+	name := prompt("Enter account name for details (blank to skip): ")
+	if name == "" {
+		return nil
+	}
+
+	stats, err := client.GetPlayerStats(context.Background(), &rummy.GetPlayerStatsRequest{
+		AccountName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%v: rating %.0f, %v games, %v wins (%.0f%%)\n",
+		stats.AccountName, stats.Rating, stats.GamesPlayed, stats.Wins, 100*stats.WinRate)
+	fmt.Printf("Average points per hand: %.1f, average deadwood: %.1f\n",
+		stats.AveragePoints, stats.AverageDeadwood)
+	for _, g := range stats.History {
+		result := "lost"
+		if g.Won {
+			result = "won"
+		} else if g.Forfeited {
+			result = "left"
+		}
+		fmt.Printf("\t%v: %v vs %v, %v points (%+.1f)\n",
+			g.GameName, result, strings.Join(g.Opponents, ", "), g.Score, g.RatingChange)
+	}
+	return nil
+}
+
 func printMainMenu() {
 	fmt.Println("\nMain menu:")
 	fmt.Println("\t1) Create a new game")
 	fmt.Println("\t2) Join a game")
-	fmt.Println("\t3) Quit")
+	fmt.Println("\t3) Find a match")
+	fmt.Println("\t4) List public games")
+	fmt.Println("\t5) Spectate a game")
+	fmt.Println("\t6) Create a tournament")
+	fmt.Println("\t7) Follow a tournament")
+	fmt.Println("\t8) Create an account")
+	fmt.Println("\t9) View the leaderboard")
+	fmt.Println("\t10) Quit")
 }
 
 func main() {
 	connStr := flag.String("server", "", "Game server to connect to")
+	useTLS := flag.Bool("tls", false, "Connect to the server with TLS (implied by -ca and -cert)")
+	caFile := flag.String("ca", "",
+		"Trust server certificates signed by the CAs in this file (PEM) rather than the system's CAs")
+	serverName := flag.String("server_name", "",
+		"Name the server's certificate must be issued for, if not the host in -server")
+	certFile := flag.String("cert", "", "Certificate to present to servers that require one (PEM)")
+	keyFile := flag.String("key", "", "Key of -cert (PEM)")
 	flag.Parse()
 
 	if *connStr == "" {
@@ -535,7 +1125,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	conn, err := grpc.Dial(*connStr, grpc.WithInsecure())
+	creds := grpc.WithInsecure()
+	if *useTLS || *caFile != "" || *certFile != "" {
+		config, err := tlsutil.ClientConfig(*caFile, *serverName, *certFile, *keyFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(config))
+	}
+
+	conn, err := grpc.Dial(*connStr, creds)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -550,20 +1150,51 @@ func main() {
 
 		switch selection {
 		case "1":
-			gameName, playerId, err := createGame(client)
+			gameName, playerId, playerSecret, err := createGame(client)
 			if err != nil {
 				fmt.Println(err)
 				continue
 			}
-			playGame(client, gameName, playerId)
+			playGame(client, gameName, playerId, playerSecret)
 		case "2":
-			gameName, playerId, err := joinGame(client)
+			gameName, playerId, playerSecret, err := joinGame(client)
 			if err != nil {
 				fmt.Println(err)
 				continue
 			}
-			playGame(client, gameName, playerId)
+			playGame(client, gameName, playerId, playerSecret)
 		case "3":
+			gameName, playerId, playerSecret, err := findMatch(client)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			playGame(client, gameName, playerId, playerSecret)
+		case "4":
+			if err := listGames(client); err != nil {
+				fmt.Println(err)
+			}
+		case "5":
+			if err := spectateGame(client); err != nil {
+				fmt.Println(err)
+			}
+		case "6":
+			if err := createTournament(client); err != nil {
+				fmt.Println(err)
+			}
+		case "7":
+			if err := followTournament(client); err != nil {
+				fmt.Println(err)
+			}
+		case "8":
+			if err := createAccount(client); err != nil {
+				fmt.Println(err)
+			}
+		case "9":
+			if err := showLeaderboard(client); err != nil {
+				fmt.Println(err)
+			}
+		case "10":
 			return
 		}
 	}
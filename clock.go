@@ -0,0 +1,93 @@
+package rummy
+
+import (
+	"time"
+)
+
+// TurnDeadline returns the time by which the current player must
+// complete their turn, including their time bank. It returns false
+// if the game has no turn time limit, is not in progress, or the
+// current turn has already timed out.
+func (g *Game) TurnDeadline() (time.Time, bool) {
+	if g.options.TurnTimeLimitSeconds == 0 || g.Status() != GameState_IN_PROGRESS || g.turnTimedOut {
+		return time.Time{}, false
+	}
+
+	p := g.players[g.currentPlayer]
+	return g.turnStartedAt.Add(g.turnTimeLimit() + p.timeBank), true
+}
+
+// TurnStartedAt returns the time at which the current turn started.
+func (g *Game) TurnStartedAt() time.Time {
+	return g.turnStartedAt
+}
+
+// CheckTurnTimeout times out the current turn if its deadline has
+// passed as of now. If so, a TURN_TIMEOUT event is published and the
+// id of the current player is returned; the caller is then responsible
+// for completing the turn on their behalf.
+func (g *Game) CheckTurnTimeout(now time.Time) (int32, bool) {
+	deadline, ok := g.TurnDeadline()
+	if !ok || now.Before(deadline) {
+		return 0, false
+	}
+
+	g.turnTimedOut = true
+	g.publish(&GameEvent{
+		PlayerId: g.currentPlayer,
+		Type:     GameEvent_TURN_TIMEOUT,
+	})
+	return g.currentPlayer, true
+}
+
+func (g *Game) turnTimeLimit() time.Duration {
+	return time.Duration(g.options.TurnTimeLimitSeconds) * time.Second
+}
+
+// overtime returns how far the current turn has run past the
+// turn time limit as of now.
+func (g *Game) overtime(now time.Time) time.Duration {
+	if g.options.TurnTimeLimitSeconds == 0 {
+		return 0
+	}
+
+	overtime := now.Sub(g.turnStartedAt) - g.turnTimeLimit()
+	if overtime < 0 {
+		return 0
+	}
+	return overtime
+}
+
+// turnTimeRemaining returns the time left in the current turn,
+// including the current player's time bank.
+func (g *Game) turnTimeRemaining(now time.Time) time.Duration {
+	deadline, ok := g.TurnDeadline()
+	if !ok || now.After(deadline) {
+		return 0
+	}
+	return deadline.Sub(now)
+}
+
+// timeBankRemaining returns the time left in the given player's
+// time bank, less any overtime used in the current turn.
+func (g *Game) timeBankRemaining(playerId int32, now time.Time) time.Duration {
+	bank := g.players[playerId].timeBank
+	if playerId == g.currentPlayer {
+		bank -= g.overtime(now)
+	}
+	if bank < 0 {
+		return 0
+	}
+	return bank
+}
+
+// chargeTimeBank deducts the overtime used by the current player
+// at the end of their turn from their time bank.
+func (g *Game) chargeTimeBank(now time.Time) {
+	p := g.players[g.currentPlayer]
+	p.timeBank = g.timeBankRemaining(g.currentPlayer, now)
+}
+
+func millis(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
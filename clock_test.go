@@ -0,0 +1,86 @@
+package rummy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTurnClock(t *testing.T) {
+	start := time.Now()
+	now := start
+	limit, bank := 30*time.Second, 60*time.Second
+	g := dealTestGame(t, &GameOptions{TurnTimeLimitSeconds: 30, TimeBankSeconds: 60}, &now)
+
+	checkDeadline := func(step string, expected time.Time) {
+		deadline, ok := g.TurnDeadline()
+		if !ok || !deadline.Equal(expected) {
+			t.Errorf("%v: turn deadline %v, %v, expected %v", step, deadline, ok, expected)
+		}
+	}
+	checkTimeBank := func(step string, playerId int32, expected time.Duration) {
+		gs := g.GameState()
+		if remaining := gs.Players[playerId].TimeBankRemainingMs; remaining != millis(expected) {
+			t.Errorf("%v: player %v has %vms in their time bank, expected %v",
+				step, playerId, remaining, expected)
+		}
+	}
+
+	first := g.currentPlayer
+	second := 1 - first
+	checkDeadline("deal", start.Add(limit+bank))
+
+	// A turn within the limit does not draw on the time bank.
+	now = start.Add(20 * time.Second)
+	playStockTurn(t, g)
+	checkTimeBank("turn within the limit", first, bank)
+	checkDeadline("second turn", now.Add(limit+bank))
+
+	// Overtime is drawn from the time bank, as the turn goes on
+	// and once it ends.
+	turnStart := now
+	now = turnStart.Add(limit + 10*time.Second)
+	checkTimeBank("turn in overtime", second, bank-10*time.Second)
+	if _, ok := g.CheckTurnTimeout(now); ok {
+		t.Error("turn timed out before its deadline")
+	}
+	playStockTurn(t, g)
+	checkTimeBank("turn in overtime", second, bank-10*time.Second)
+	checkDeadline("third turn", now.Add(limit+bank))
+
+	// The next turn of the player with less time in their bank
+	// has an earlier deadline.
+	now = now.Add(time.Second)
+	playStockTurn(t, g)
+	checkDeadline("fourth turn", now.Add(limit+bank-10*time.Second))
+
+	// Once the time bank is used up, the turn times out.
+	turnStart = now
+	now = turnStart.Add(limit + bank - 10*time.Second)
+	playerId, ok := g.CheckTurnTimeout(now)
+	if !ok || playerId != second {
+		t.Fatalf("turn timed out = %v for player %v, expected player %v to time out", ok, playerId, second)
+	}
+	if e := g.history[len(g.history)-1]; e.Type != GameEvent_TURN_TIMEOUT || e.PlayerId != second {
+		t.Errorf("last event %v, expected a timeout of player %v", e, second)
+	}
+	if _, ok := g.TurnDeadline(); ok {
+		t.Error("turn that timed out still has a deadline")
+	}
+	if _, ok := g.CheckTurnTimeout(now); ok {
+		t.Error("turn timed out twice")
+	}
+	playStockTurn(t, g)
+	checkTimeBank("turn that timed out", second, 0)
+	checkDeadline("turn after the timeout", now.Add(limit+bank))
+}
+
+func TestTurnClockWithoutLimit(t *testing.T) {
+	now := time.Now()
+	g := dealTestGame(t, nil, &now)
+	if deadline, ok := g.TurnDeadline(); ok {
+		t.Errorf("game without a turn time limit has deadline %v", deadline)
+	}
+	if _, ok := g.CheckTurnTimeout(now.Add(24 * time.Hour)); ok {
+		t.Error("turn timed out in a game without a turn time limit")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
@@ -16,6 +18,8 @@ const initialNumCards = 7
 
 // Game manages the state machine for a single game of Rummy.
 type Game struct {
+	options *GameOptions
+
 	// The deck of cards that have not been picked up yet.
 	stock deck.Deck
 	// The discard pile.
@@ -35,30 +39,70 @@ type Game struct {
 	mustPlayCard *deck.Card
 	// True once one player has "gone out" and the game is over.
 	isOver bool
+	// True if the game was expired before it was over.
+	isExpired bool
+
+	// When the current turn started, for enforcing the turn time limit.
+	turnStartedAt time.Time
+	// True if the current turn has timed out.
+	turnTimedOut bool
 
+	// All public events published so far. The sequence number
+	// of each event is one more than its index in history.
+	history []*GameEvent
 	// Subscribers to public game events.
 	subscribers []chan *GameEvent
+
+	// The stock and discard pile as they were after dealing.
+	initialStock   deck.Deck
+	initialDiscard *deck.Card
+	// Hands of all players after each event, if they are revealed
+	// to spectators on a delay.
+	handHistory []handSnapshot
+
+	// Returns the current time, and may be replaced in tests.
+	now func() time.Time
 }
 
 // NewGame initializes a new Game with a shuffled Deck of cards.
 // There are initially no players. Players may join the game by
 // calling AddPlayer, until the game is started by calling Deal.
 func NewGame() *Game {
+	g, _ := NewGameWithOptions(nil)
+	return g
+}
+
+// NewGameWithOptions is like NewGame, but the game is played with the
+// given options, which are normalized with NormalizeGameOptions.
+func NewGameWithOptions(opts *GameOptions) (*Game, error) {
+	opts, err := NormalizeGameOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	d := deck.New()
 	d.Shuffle()
 	return &Game{
+		options: opts,
 		stock:   d,
 		name2id: make(map[string]int32),
 		// No one can attempt to play until Deal is called.
 		currentPlayer: -1,
-	}
+		now:           time.Now,
+	}, nil
+}
+
+// Options returns the options the game is played with.
+func (g *Game) Options() *GameOptions {
+	return proto.Clone(g.options).(*GameOptions)
 }
 
-// AddPlayer adds a player with the given name to the game.
+// AddPlayer adds a player with the given name to the game, in the
+// first seat left by a player who has left, or else in a new seat.
 // AddPlayer can be called until Deal is called to add more players.
 // Each player must have a unique name.
 func (g *Game) AddPlayer(name string) (int32, error) {
-	if g.currentPlayer != -1 {
+	if g.Status() != GameState_LOBBY {
 		return 0, fmt.Errorf("game has already started, cannot join")
 	}
 
@@ -67,34 +111,81 @@ func (g *Game) AddPlayer(name string) (int32, error) {
 		return id, fmt.Errorf("player with name %v already joined", name)
 	}
 
+	if len(g.remainingPlayers()) >= int(g.options.MaxPlayers) {
+		return 0, fmt.Errorf("game is full (%v players)", g.options.MaxPlayers)
+	}
+
 	p := &player{name: name}
 	id := int32(len(g.players))
+	for i, other := range g.players {
+		if other.forfeited {
+			id = int32(i)
+			break
+		}
+	}
+	if id < int32(len(g.players)) {
+		g.players[id] = p
+	} else {
+		g.players = append(g.players, p)
+	}
 	g.name2id[name] = id
-	g.players = append(g.players, p)
+	g.publish(&GameEvent{
+		PlayerId:   id,
+		Type:       GameEvent_PLAYER_JOINED,
+		PlayerName: name,
+	})
 	return id, nil
 }
 
 // Deal starts the game, deals a hand to each player, and randomly
-// selects the player to go first.
+// selects the player to go first. Seats left by players who left
+// the game before it started remain empty, and are skipped.
 func (g *Game) Deal() error {
-	if len(g.players) == 0 {
+	remaining := g.remainingPlayers()
+	if g.Status() != GameState_LOBBY {
+		return fmt.Errorf("game has already started")
+	} else if len(remaining) == 0 {
 		return fmt.Errorf("no players in game")
-	} else if len(g.players)*initialNumCards > len(g.stock) {
-		return fmt.Errorf("too many players for deck: %v", len(g.players))
+	} else if len(remaining) < int(g.options.MinPlayers) {
+		return fmt.Errorf("need at least %v players to start, have %v",
+			g.options.MinPlayers, len(remaining))
+	} else if len(remaining)*initialNumCards > len(g.stock) {
+		return fmt.Errorf("too many players for deck: %v", len(remaining))
 	}
 
 	// Deal initial cards to each player.
-	glog.Infof("Dealing %v cards to %v players", initialNumCards, len(g.players))
-	for id, p := range g.players {
-		p.hand = NewHand(g.stock[:initialNumCards])
+	glog.Infof("Dealing %v cards to %v players", initialNumCards, len(remaining))
+	for _, id := range remaining {
+		p := g.players[id]
+		p.initialHand = append([]deck.Card(nil), g.stock[:initialNumCards]...)
+		p.hand = NewHand(p.initialHand)
 		glog.Infof("Player %v (id: %v) initial hand: %s", p.name, id, p.hand)
 		g.stock = g.stock[initialNumCards:]
 	}
 
 	// Initialize the discard pile.
 	g.discard = []deck.Card{g.stock.Pop()}
+
+	// Record the deal, so that it can be revealed once the game is over.
+	g.initialStock = append(deck.Deck(nil), g.stock...)
+	initialDiscard := g.discard[0]
+	g.initialDiscard = &initialDiscard
+
+	// Start each player's time bank.
+	for _, p := range g.players {
+		p.timeBank = time.Duration(g.options.TimeBankSeconds) * time.Second
+	}
 	// Choose random player to start.
-	g.currentPlayer = int32(rand.Intn(len(g.players)))
+	first := rand.Intn(len(remaining))
+	g.currentPlayer = remaining[first]
+	g.turnStartedAt = g.now()
+	seatOrder := append(append([]int32(nil), remaining[first:]...), remaining[:first]...)
+	g.publish(&GameEvent{
+		PlayerId:  g.currentPlayer,
+		Type:      GameEvent_GAME_STARTED,
+		Cards:     protoSlice(g.discard),
+		SeatOrder: seatOrder,
+	})
 	// Notify any subscribers who goes first.
 	// Anyone who subscribes after this will receive the event
 	// upon subscription.
@@ -107,6 +198,7 @@ func (g *Game) Deal() error {
 
 // GameState returns the publicly observable state of the game.
 func (g *Game) GameState() *GameState {
+	now := g.now()
 	playerStates := make([]*PlayerState, len(g.players))
 	for i, p := range g.players {
 		score := p.PublicScore()
@@ -116,57 +208,263 @@ func (g *Game) GameState() *GameState {
 		playerStates[i] = &PlayerState{
 			Id:             int32(i),
 			Name:           p.name,
-			Melds:          protoMelds(p.melds),
+			Melds:          protoMelds(p.melds, p.meldIds),
 			Rummies:        protoSlice(p.rummies),
 			NumCardsInHand: int32(len(p.hand)),
 			CurrentScore:   int32(score),
+
+			TimeBankRemainingMs: millis(g.timeBankRemaining(int32(i), now)),
+			Away:                p.away,
+			Forfeited:           p.forfeited,
+		}
+		// Once the game is over, all hands and the deal are revealed.
+		if g.isOver {
+			playerStates[i].Hand = protoSlice(sortedHand(p.hand))
+			playerStates[i].InitialHand = protoSlice(p.initialHand)
 		}
 	}
 
-	return &GameState{
+	gs := &GameState{
 		NumCardsInStock:   int32(len(g.stock)),
 		DiscardPile:       protoSlice(g.discard),
-		AggregatedMelds:   protoMelds(g.aggregatedMelds()),
+		AggregatedMelds:   protoMelds(g.aggregatedMeldsWithIds()),
 		Players:           playerStates,
 		Turn:              int32(g.turn),
 		CurrentPlayerTurn: g.currentPlayer,
 		TurnState:         g.currentPlayerTurnState,
 		GameOver:          g.isOver,
+		Status:            g.Status(),
+		LastSequence:      int64(len(g.history)),
+		Options:           g.Options(),
+
+		TurnTimeRemainingMs: millis(g.turnTimeRemaining(now)),
+	}
+	if g.isOver {
+		gs.InitialStock = protoSlice(g.initialStock)
+		if g.initialDiscard != nil {
+			initialDiscard := *g.initialDiscard
+			gs.InitialDiscard = &initialDiscard
+		}
+	}
+	return gs
+}
+
+// Turn returns the number of turns that have been completed.
+func (g *Game) Turn() int {
+	return g.turn
+}
+
+// LastSequence returns the sequence number of the most recent event.
+func (g *Game) LastSequence() int64 {
+	return int64(len(g.history))
+}
+
+// Status returns the current lifecycle status of the game.
+func (g *Game) Status() GameState_Status {
+	switch {
+	case g.isExpired:
+		return GameState_EXPIRED
+	case g.isOver:
+		return GameState_COMPLETED
+	case g.currentPlayer == -1:
+		return GameState_LOBBY
+	default:
+		return GameState_IN_PROGRESS
 	}
 }
 
-func protoMelds(melds []meld.Meld) []*Meld {
+func protoMelds(melds []meld.Meld, ids []int32) []*Meld {
 	result := make([]*Meld, len(melds))
 	for i, m := range melds {
 		result[i] = &Meld{
 			Cards: protoSlice(m),
+			Id:    ids[i],
 		}
 	}
 	return result
 }
 
 func (g *Game) PlayerHand(playerId int32) ([]deck.Card, error) {
-	if playerId >= int32(len(g.players)) {
+	if playerId < 0 || playerId >= int32(len(g.players)) {
 		return nil, fmt.Errorf("no such player: %v", playerId)
 	}
 
-	p := g.players[playerId]
-	hs := p.hand.AsSlice()
+	return sortedHand(g.players[playerId].hand), nil
+}
+
+func sortedHand(hand Hand) []deck.Card {
+	hs := hand.AsSlice()
 	sort.Sort(deck.BySuitAndRank(hs))
-	return hs, nil
+	return hs
 }
 
+// PlayerView returns the game as seen by the given player:
+// the public game state along with their hand, any card that they
+// must play this turn, and the actions they may currently take.
+func (g *Game) PlayerView(playerId int32) (*PlayerView, error) {
+	hand, err := g.PlayerHand(playerId)
+	if err != nil {
+		return nil, err
+	}
+
+	isMyTurn := (g.currentPlayer == playerId)
+	view := &PlayerView{
+		GameState:    g.GameState(),
+		PlayerId:     playerId,
+		Hand:         protoSlice(hand),
+		IsMyTurn:     isMyTurn,
+		TurnState:    g.currentPlayerTurnState,
+		LegalActions: g.legalActions(playerId),
+	}
+	if isMyTurn && g.mustPlayCard != nil {
+		mustPlayCard := *g.mustPlayCard
+		view.MustPlayCard = &mustPlayCard
+	}
+	return view, nil
+}
+
+// legalActions returns the kinds of action the given player may
+// currently take. The actions may still be rejected depending
+// on the cards involved.
+func (g *Game) legalActions(playerId int32) []PlayerView_Action {
+	if g.Status() != GameState_IN_PROGRESS || g.players[playerId].forfeited {
+		return nil
+	}
+
+	var actions []PlayerView_Action
+	if g.currentPlayer == playerId {
+		p := g.players[playerId]
+		switch g.currentPlayerTurnState {
+		case GameState_TURN_START:
+			actions = append(actions, PlayerView_PICK_UP_STOCK)
+			if len(g.discard) > 0 {
+				actions = append(actions, PlayerView_PICK_UP_DISCARD)
+			}
+		case GameState_PICKED_UP_CARDS, GameState_PLAYED_CARDS:
+			// Play must leave at least one card in hand for discard.
+			if len(p.hand) > 1 {
+				actions = append(actions, PlayerView_PLAY_CARDS)
+			}
+			if g.mustPlayCard == nil {
+				actions = append(actions, PlayerView_DISCARD)
+			}
+		}
+	}
+
+	if len(g.discard) > 0 && g.options.RulesVariant != GameOptions_NO_RUMMY {
+		actions = append(actions, PlayerView_CALL_RUMMY)
+	}
+
+	return actions
+}
+
+// Subscribe sends all future public events in the game to the
+// given channel. The channel is closed once the game is over.
+// Events are never blocked on a subscriber: if the channel's buffer
+// is full when an event is published, the channel is closed and the
+// subscriber must subscribe again with SubscribeFrom, from the
+// sequence number after the last event it received.
 func (g *Game) Subscribe(events chan *GameEvent) {
-	g.subscribers = append(g.subscribers, events)
-	if g.isOver {
+	g.SubscribeFrom(events, 0)
+}
+
+// SubscribeFrom is like Subscribe, but also returns the events that
+// have already been published, starting with sequence number
+// fromSequence, so that the subscriber does not miss any events.
+// If fromSequence is 0, no past events are returned.
+func (g *Game) SubscribeFrom(events chan *GameEvent, fromSequence int64) []*GameEvent {
+	var history []*GameEvent
+	if fromSequence > 0 && fromSequence <= int64(len(g.history)) {
+		history = append(history, g.history[fromSequence-1:]...)
+	}
+
+	if g.isOver || g.isExpired {
 		close(events)
+	} else {
+		g.subscribers = append(g.subscribers, events)
+	}
+
+	return history
+}
+
+// Unsubscribe stops sending events to a channel previously passed
+// to Subscribe, and closes it. It has no effect if the channel has
+// already been closed because the game ended.
+func (g *Game) Unsubscribe(events chan *GameEvent) {
+	for i, s := range g.subscribers {
+		if s == events {
+			g.subscribers = append(g.subscribers[:i], g.subscribers[i+1:]...)
+			close(events)
+			return
+		}
+	}
+}
+
+// IsPlayerAway returns true if the player has been marked as away.
+func (g *Game) IsPlayerAway(playerId int32) (bool, error) {
+	if playerId < 0 || playerId >= int32(len(g.players)) {
+		return false, fmt.Errorf("no such player: %v", playerId)
+	}
+
+	return g.players[playerId].away, nil
+}
+
+// PlayerName returns the name that the player joined the game with.
+func (g *Game) PlayerName(playerId int32) (string, error) {
+	if playerId < 0 || playerId >= int32(len(g.players)) {
+		return "", fmt.Errorf("no such player: %v", playerId)
+	}
+
+	return g.players[playerId].name, nil
+}
+
+// SetPlayerAway marks whether the player is away from the game,
+// e.g. because they lost their connection. A PLAYER_AWAY or
+// PLAYER_RETURNED event is published if this changes.
+func (g *Game) SetPlayerAway(playerId int32, away bool) error {
+	wasAway, err := g.IsPlayerAway(playerId)
+	if err != nil || wasAway == away {
+		return err
+	}
+
+	g.players[playerId].away = away
+	eventType := GameEvent_PLAYER_RETURNED
+	if away {
+		eventType = GameEvent_PLAYER_AWAY
 	}
+	g.publish(&GameEvent{
+		PlayerId: playerId,
+		Type:     eventType,
+	})
+	return nil
 }
 
+// publish assigns the next sequence number to the event, records it
+// in the game's history, and sends it to all subscribers.
 func (g *Game) publish(event *GameEvent) {
+	event.Sequence = int64(len(g.history)) + 1
+	event.Turn = int32(g.turn)
+	event.Timestamp = g.now().UnixNano() / int64(time.Millisecond)
+	g.history = append(g.history, event)
+	if g.options.SpectatorHandDelaySeconds > 0 {
+		g.recordHands(event)
+	}
+	g.broadcast(event)
+}
+
+// broadcast sends the event to all subscribers, closing and
+// removing any that have fallen too far behind to receive it.
+func (g *Game) broadcast(event *GameEvent) {
+	subscribers := g.subscribers[:0]
 	for _, s := range g.subscribers {
-		s <- event
+		select {
+		case s <- event:
+			subscribers = append(subscribers, s)
+		default:
+			close(s)
+		}
 	}
+	g.subscribers = subscribers
 }
 
 func (g *Game) PickUpStock(playerId int32) (deck.Card, error) {
@@ -180,10 +478,29 @@ func (g *Game) PickUpStock(playerId int32) (deck.Card, error) {
 
 	p := g.players[playerId]
 	// Out of cards in the stock, shuffle the discard pile.
-	if len(g.stock) == 0 {
+	// The top card starts a new discard pile, unless that
+	// would leave the stock empty.
+	if len(g.stock) == 0 && len(g.discard) > 0 {
 		g.stock = deck.Deck(g.discard)
 		g.stock.Shuffle()
-		g.discard = []deck.Card{g.stock.Pop()}
+		g.discard = nil
+		if len(g.stock) > 1 {
+			g.discard = []deck.Card{g.stock.Pop()}
+		}
+		g.publish(&GameEvent{
+			PlayerId: playerId,
+			Type:     GameEvent_STOCK_RESHUFFLED,
+			Cards:    protoSlice(g.discard),
+		})
+	}
+
+	if len(g.stock) == 0 {
+		// Every card is in a hand or has been played,
+		// so there is nothing left to pick up.
+		g.endGame(&GameEvent{
+			Type: GameEvent_GAME_OVER,
+		})
+		return deck.Card{}, fmt.Errorf("no cards left in the stock, game over")
 	}
 
 	card := g.stock.Pop()
@@ -210,6 +527,10 @@ func (g *Game) PickUpDiscard(playerId int32, nCards int) ([]deck.Card, error) {
 		return nil, fmt.Errorf("can't pick up %v > %v cards in discard pile", nCards, len(g.discard))
 	}
 
+	if nCards > 1 && g.options.RulesVariant == GameOptions_SINGLE_DISCARD {
+		return nil, fmt.Errorf("only the top card of the discard pile may be picked up")
+	}
+
 	p := g.players[playerId]
 	lastCard := len(g.discard) - nCards
 	cards := g.discard[lastCard:]
@@ -331,10 +652,14 @@ func (g *Game) PlayCards(playerId int32, cards []deck.Card) (int, error) {
 		}
 	}
 
+	var meldId int32
 	if isMeld {
+		meldId = g.nextMeldId()
 		p.melds = append(p.melds, possibleMeld)
+		p.meldIds = append(p.meldIds, meldId)
 	} else if canRummy {
 		p.rummies = append(p.rummies, cards...)
+		meldId = g.meldIdOf(cards[0])
 	}
 	score := possibleMeld.Value()
 	g.publish(&GameEvent{
@@ -342,6 +667,7 @@ func (g *Game) PlayCards(playerId int32, cards []deck.Card) (int, error) {
 		Type:     GameEvent_PLAY_CARDS,
 		Cards:    protoSlice(cards),
 		Score:    int32(score),
+		MeldId:   meldId,
 	})
 	g.currentPlayerTurnState = GameState_PLAYED_CARDS
 
@@ -390,7 +716,9 @@ func (g *Game) DiscardCard(playerId int32, card deck.Card) error {
 
 	// If that was the last card in the player's hand, then the game is over.
 	if len(p.hand) == 0 {
-		g.endGame()
+		g.endGame(&GameEvent{
+			Type: GameEvent_GAME_OVER,
+		})
 	} else {
 		g.nextPlayer()
 	}
@@ -400,29 +728,162 @@ func (g *Game) DiscardCard(playerId int32, card deck.Card) error {
 
 // Move Game forward to next player.
 func (g *Game) nextPlayer() {
+	g.chargeTimeBank(g.now())
 	g.turn++
-	g.currentPlayer = (g.currentPlayer + 1) % int32(len(g.players))
+	// Skip players who have left. There is always at least one
+	// remaining, since the game ends when only one is left.
+	for {
+		g.currentPlayer = (g.currentPlayer + 1) % int32(len(g.players))
+		if !g.players[g.currentPlayer].forfeited {
+			break
+		}
+	}
 	g.currentPlayerTurnState = GameState_TURN_START
+	g.turnStartedAt = g.now()
+	g.turnTimedOut = false
 	g.publish(&GameEvent{
 		PlayerId: g.currentPlayer,
 		Type:     GameEvent_TURN_START,
 	})
 }
 
-func (g *Game) endGame() {
+// endGame ends the game, publishing the given GAME_OVER event.
+func (g *Game) endGame(event *GameEvent) {
 	g.isOver = true
 	g.currentPlayer = -1
+	g.publish(event)
+	g.closeSubscribers()
+}
+
+// Forfeit removes a player from the game. If the game has not started,
+// their name and seat are freed for another player to join. If the game
+// is in progress, the cards in their hand remain there and count against
+// their score, and they are skipped in the turn order; if it is currently
+// their turn, the turn passes to the next player. Once only one player
+// remains, the game is over.
+func (g *Game) Forfeit(playerId int32) error {
+	status := g.Status()
+	if status != GameState_LOBBY && status != GameState_IN_PROGRESS {
+		return fmt.Errorf("game is over")
+	} else if playerId < 0 || playerId >= int32(len(g.players)) {
+		return fmt.Errorf("no such player: %v", playerId)
+	} else if g.players[playerId].forfeited {
+		return fmt.Errorf("player %v has already left the game", playerId)
+	}
+
+	if status == GameState_LOBBY {
+		delete(g.name2id, g.players[playerId].name)
+		g.players[playerId] = &player{forfeited: true}
+	} else {
+		g.players[playerId].forfeited = true
+	}
+	g.publish(&GameEvent{
+		PlayerId: playerId,
+		Type:     GameEvent_PLAYER_LEFT,
+	})
+	if status == GameState_LOBBY {
+		return nil
+	}
+
+	remaining := g.remainingPlayers()
+	if len(remaining) == 1 {
+		g.endGame(&GameEvent{
+			PlayerId: remaining[0],
+			Type:     GameEvent_GAME_OVER,
+			Forfeit:  true,
+		})
+	} else if g.currentPlayer == playerId {
+		g.mustPlayCard = nil
+		g.nextPlayer()
+	}
+
+	return nil
+}
+
+// NumPlayers returns the number of players who have not left the game.
+func (g *Game) NumPlayers() int {
+	return len(g.remainingPlayers())
+}
+
+// remainingPlayers returns the ids of the players who have not left the game.
+func (g *Game) remainingPlayers() []int32 {
+	var remaining []int32
+	for id, p := range g.players {
+		if !p.forfeited {
+			remaining = append(remaining, int32(id))
+		}
+	}
+	return remaining
+}
+
+// Chat publishes a chat message from the given player to everyone
+// observing the game, and returns the sequence number of its event.
+// Messages may be sent until the game is over.
+func (g *Game) Chat(playerId int32, message string) (int64, error) {
+	if g.isOver || g.isExpired {
+		return 0, fmt.Errorf("game is over")
+	} else if playerId < 0 || playerId >= int32(len(g.players)) {
+		return 0, fmt.Errorf("no such player: %v", playerId)
+	} else if strings.TrimSpace(message) == "" {
+		return 0, fmt.Errorf("message is empty")
+	}
+
+	event := &GameEvent{
+		PlayerId: playerId,
+		Type:     GameEvent_CHAT,
+		Message:  message,
+	}
+	g.publish(event)
+	return event.Sequence, nil
+}
+
+// Expire aborts a game that has not completed, e.g. because it has
+// been abandoned. Subscribers are sent a final GAME_EXPIRED event
+// and then their channels are closed. Expire has no effect on a game
+// that is already over.
+func (g *Game) Expire() {
+	if g.isOver || g.isExpired {
+		return
+	}
+
+	g.isExpired = true
+	g.currentPlayer = -1
 	g.publish(&GameEvent{
-		Type: GameEvent_GAME_OVER,
+		Type: GameEvent_GAME_EXPIRED,
+	})
+	g.closeSubscribers()
+}
+
+// Shutdown sends a SERVER_SHUTDOWN event to all subscribers and
+// closes their channels, without changing the state of the game.
+// The game may continue to be played (or be snapshotted and restored),
+// but anyone who wishes to observe it must subscribe again.
+// The SERVER_SHUTDOWN event is not recorded in the game's history.
+func (g *Game) Shutdown() {
+	g.broadcast(&GameEvent{
+		Type:      GameEvent_SERVER_SHUTDOWN,
+		Timestamp: g.now().UnixNano() / int64(time.Millisecond),
 	})
+	g.closeSubscribers()
+}
+
+// closeSubscribers closes and removes all current subscribers.
+func (g *Game) closeSubscribers() {
 	for _, s := range g.subscribers {
 		close(s)
 	}
+	g.subscribers = nil
 }
 
 func (g *Game) CallRummy(playerId int32, cards []deck.Card) error {
-	if playerId >= int32(len(g.players)) {
+	if playerId < 0 || playerId >= int32(len(g.players)) {
 		return fmt.Errorf("no such player: %v", playerId)
+	} else if g.players[playerId].forfeited {
+		return fmt.Errorf("player %v has left the game", playerId)
+	}
+
+	if g.options.RulesVariant == GameOptions_NO_RUMMY {
+		return fmt.Errorf("calling rummy is not allowed in this game")
 	}
 
 	// Verify that cards are in the discard pile, and that they are
@@ -455,6 +916,13 @@ func (g *Game) CallRummy(playerId int32, cards []deck.Card) error {
 	g.discard = remainingDiscard
 	p := g.players[playerId]
 	p.rummies = append(p.rummies, cards...)
+	g.publish(&GameEvent{
+		PlayerId: playerId,
+		Type:     GameEvent_RUMMY_CALLED,
+		Cards:    protoSlice(cards),
+		Score:    int32(meld.Meld(cards).Value()),
+		MeldId:   g.meldIdOf(cards[0]),
+	})
 	return nil
 }
 
@@ -475,13 +943,43 @@ func (g *Game) canPlay(cards []deck.Card) bool {
 	return true
 }
 
+// nextMeldId returns the id of the next meld to be played.
+func (g *Game) nextMeldId() int32 {
+	var n int32
+	for _, p := range g.players {
+		n += int32(len(p.melds))
+	}
+	return n + 1
+}
+
+// meldIdOf returns the id of the extended meld containing the given
+// card, or 0 if it is not in any meld.
+func (g *Game) meldIdOf(card deck.Card) int32 {
+	melds, ids := g.aggregatedMeldsWithIds()
+	for i, m := range melds {
+		for _, c := range m {
+			if c == card {
+				return ids[i]
+			}
+		}
+	}
+	return 0
+}
+
+func (g *Game) aggregatedMelds() []meld.Meld {
+	melds, _ := g.aggregatedMeldsWithIds()
+	return melds
+}
+
 // Get all of the extended melds in this Game, formed by taking the
 // melds of each player and extending them with any rummies that have been
-// played off of them.
-func (g *Game) aggregatedMelds() []meld.Meld {
+// played off of them, along with the id of each meld.
+func (g *Game) aggregatedMeldsWithIds() ([]meld.Meld, []int32) {
 	melds := make([]meld.Meld, 0)
+	ids := make([]int32, 0)
 	rummies := make(map[deck.Card]struct{}, 0)
 	for _, p := range g.players {
+		ids = append(ids, p.meldIds...)
 		for _, m := range p.melds {
 			// Copy melds because we are going to add rummied cards to them
 			// below and don't want to modify the meld attached to the player.
@@ -529,7 +1027,7 @@ func (g *Game) aggregatedMelds() []meld.Meld {
 		}
 	}
 
-	return melds
+	return melds, ids
 }
 
 // Takes the given set of rummy cards and splits them into contiguous
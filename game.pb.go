@@ -12,16 +12,46 @@ It has these top-level messages:
 	Meld
 	PlayerState
 	GameState
+	GameOptions
 	GameEvent
+	PlayerView
+	GameSnapshot
 	CreateGameRequest
 	CreateGameResponse
 	JoinGameRequest
 	JoinGameResponse
+	FindMatchRequest
+	FindMatchResponse
+	JoinSpectatorRequest
+	JoinSpectatorResponse
 	StartGameRequest
 	StartGameResponse
 	GetGameStateRequest
 	GetHandCardsRequest
 	GetHandCardsResponse
+	GetPlayerViewRequest
+	ListGamesRequest
+	ListGamesResponse
+	UpdateInviteCodeRequest
+	UpdateInviteCodeResponse
+	TakeOverSeatRequest
+	TakeOverSeatResponse
+	ReclaimSeatRequest
+	ReclaimSeatResponse
+	StrategyParam
+	StrategyInfo
+	ListStrategiesRequest
+	ListStrategiesResponse
+	ListBotsRequest
+	ListBotsResponse
+	PauseBotRequest
+	PauseBotResponse
+	RemoveBotRequest
+	RemoveBotResponse
+	SendChatRequest
+	SendChatResponse
+	LeaveGameRequest
+	LeaveGameResponse
 	SubscribeGameRequest
 	PickUpStockRequest
 	PickUpStockResponse
@@ -33,6 +63,21 @@ It has these top-level messages:
 	DiscardCardResponse
 	CallRummyRequest
 	CallRummyResponse
+	PlayRequest
+	ActionResult
+	PlayResponse
+	Tournament
+	TournamentEntrant
+	CreateTournamentRequest
+	CreateTournamentResponse
+	GetTournamentRequest
+	SubscribeTournamentRequest
+	CreateAccountRequest
+	CreateAccountResponse
+	PlayerStats
+	GetLeaderboardRequest
+	GetLeaderboardResponse
+	GetPlayerStatsRequest
 */
 package rummy
 
@@ -76,6 +121,111 @@ func (x GameState_TurnState) String() string {
 }
 func (GameState_TurnState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
 
+// The lifecycle of a game: games are created in the LOBBY,
+// move to IN_PROGRESS once they are dealt, and are COMPLETED
+// when one player goes out. Games that are removed by the server
+// before completing (e.g. because they were idle) are EXPIRED.
+type GameState_Status int32
+
+const (
+	GameState_LOBBY       GameState_Status = 0
+	GameState_IN_PROGRESS GameState_Status = 1
+	GameState_COMPLETED   GameState_Status = 2
+	GameState_EXPIRED     GameState_Status = 3
+)
+
+var GameState_Status_name = map[int32]string{
+	0: "LOBBY",
+	1: "IN_PROGRESS",
+	2: "COMPLETED",
+	3: "EXPIRED",
+}
+var GameState_Status_value = map[string]int32{
+	"LOBBY":       0,
+	"IN_PROGRESS": 1,
+	"COMPLETED":   2,
+	"EXPIRED":     3,
+}
+
+func (x GameState_Status) String() string {
+	return proto.EnumName(GameState_Status_name, int32(x))
+}
+func (GameState_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 1} }
+
+type GameOptions_RulesVariant int32
+
+const (
+	// Any number of cards may be picked up from the discard pile,
+	// and players may call rummy.
+	GameOptions_STANDARD GameOptions_RulesVariant = 0
+	// Only the top card of the discard pile may be picked up.
+	GameOptions_SINGLE_DISCARD GameOptions_RulesVariant = 1
+	// Players may not call rummy on cards that are discarded.
+	GameOptions_NO_RUMMY GameOptions_RulesVariant = 2
+)
+
+var GameOptions_RulesVariant_name = map[int32]string{
+	0: "STANDARD",
+	1: "SINGLE_DISCARD",
+	2: "NO_RUMMY",
+}
+var GameOptions_RulesVariant_value = map[string]int32{
+	"STANDARD":       0,
+	"SINGLE_DISCARD": 1,
+	"NO_RUMMY":       2,
+}
+
+func (x GameOptions_RulesVariant) String() string {
+	return proto.EnumName(GameOptions_RulesVariant_name, int32(x))
+}
+func (GameOptions_RulesVariant) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+
+type GameOptions_Visibility int32
+
+const (
+	GameOptions_PUBLIC  GameOptions_Visibility = 0
+	GameOptions_PRIVATE GameOptions_Visibility = 1
+)
+
+var GameOptions_Visibility_name = map[int32]string{
+	0: "PUBLIC",
+	1: "PRIVATE",
+}
+var GameOptions_Visibility_value = map[string]int32{
+	"PUBLIC":  0,
+	"PRIVATE": 1,
+}
+
+func (x GameOptions_Visibility) String() string {
+	return proto.EnumName(GameOptions_Visibility_name, int32(x))
+}
+func (GameOptions_Visibility) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 1} }
+
+// Spectators join a game to observe it without playing. Anyone may
+// also observe public games that allow spectators without joining.
+type GameOptions_SpectatorPolicy int32
+
+const (
+	GameOptions_SPECTATORS_ALLOWED GameOptions_SpectatorPolicy = 0
+	GameOptions_NO_SPECTATORS      GameOptions_SpectatorPolicy = 1
+)
+
+var GameOptions_SpectatorPolicy_name = map[int32]string{
+	0: "SPECTATORS_ALLOWED",
+	1: "NO_SPECTATORS",
+}
+var GameOptions_SpectatorPolicy_value = map[string]int32{
+	"SPECTATORS_ALLOWED": 0,
+	"NO_SPECTATORS":      1,
+}
+
+func (x GameOptions_SpectatorPolicy) String() string {
+	return proto.EnumName(GameOptions_SpectatorPolicy_name, int32(x))
+}
+func (GameOptions_SpectatorPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 2}
+}
+
 type GameEvent_Type int32
 
 const (
@@ -86,34 +236,134 @@ const (
 	GameEvent_PLAY_CARDS      GameEvent_Type = 4
 	GameEvent_DISCARD         GameEvent_Type = 5
 	GameEvent_GAME_OVER       GameEvent_Type = 6
+	// The game was removed by the server before it completed.
+	// No further events will be published.
+	GameEvent_GAME_EXPIRED GameEvent_Type = 7
+	// The server is shutting down and the stream will be closed.
+	// The game will resume when the server restarts.
+	// This event is not part of the game's history and has
+	// no sequence number.
+	GameEvent_SERVER_SHUTDOWN GameEvent_Type = 8
+	// The player did not complete their turn in time, and the
+	// rest of their turn will be played automatically.
+	GameEvent_TURN_TIMEOUT GameEvent_Type = 9
+	// The player's connection to the game was lost, and they did
+	// not reconnect within the grace period.
+	GameEvent_PLAYER_AWAY GameEvent_Type = 10
+	// A player who was away has reconnected.
+	GameEvent_PLAYER_RETURNED GameEvent_Type = 11
+	// Published by earlier versions of the server when a player
+	// left the game, and still found in the history of games
+	// restored from their snapshots. See PLAYER_LEFT.
+	GameEvent_PLAYER_FORFEIT GameEvent_Type = 12
+	// A chat message sent by the player to everyone observing
+	// the game. See GameEvent.message.
+	GameEvent_CHAT GameEvent_Type = 13
+	// The player joined the game before it started.
+	// See GameEvent.player_name.
+	GameEvent_PLAYER_JOINED GameEvent_Type = 14
+	// The game was dealt. The cards are the first card of the
+	// discard pile, and seat_order gives the order of play, starting
+	// with the player who goes first (who is also the player_id).
+	GameEvent_GAME_STARTED GameEvent_Type = 15
+	// The stock ran out and the discard pile was shuffled to form a
+	// new stock. The cards are the new discard pile: its top card is
+	// turned over, unless that would leave the stock empty.
+	GameEvent_STOCK_RESHUFFLED GameEvent_Type = 16
+	// The player called rummy, taking the cards from the discard
+	// pile and playing them off of the meld with meld_id.
+	GameEvent_RUMMY_CALLED GameEvent_Type = 17
+	// The player left the game. If it had not started, their seat
+	// is free for another player. Otherwise the cards in their hand
+	// count against their score, and they are skipped in the turn order.
+	GameEvent_PLAYER_LEFT GameEvent_Type = 18
 )
 
 var GameEvent_Type_name = map[int32]string{
-	0: "UNKNOWN_TYPE",
-	1: "TURN_START",
-	2: "PICK_UP_STOCK",
-	3: "PICK_UP_DISCARD",
-	4: "PLAY_CARDS",
-	5: "DISCARD",
-	6: "GAME_OVER",
+	0:  "UNKNOWN_TYPE",
+	1:  "TURN_START",
+	2:  "PICK_UP_STOCK",
+	3:  "PICK_UP_DISCARD",
+	4:  "PLAY_CARDS",
+	5:  "DISCARD",
+	6:  "GAME_OVER",
+	7:  "GAME_EXPIRED",
+	8:  "SERVER_SHUTDOWN",
+	9:  "TURN_TIMEOUT",
+	10: "PLAYER_AWAY",
+	11: "PLAYER_RETURNED",
+	12: "PLAYER_FORFEIT",
+	13: "CHAT",
+	14: "PLAYER_JOINED",
+	15: "GAME_STARTED",
+	16: "STOCK_RESHUFFLED",
+	17: "RUMMY_CALLED",
+	18: "PLAYER_LEFT",
 }
 var GameEvent_Type_value = map[string]int32{
-	"UNKNOWN_TYPE":    0,
-	"TURN_START":      1,
-	"PICK_UP_STOCK":   2,
-	"PICK_UP_DISCARD": 3,
-	"PLAY_CARDS":      4,
-	"DISCARD":         5,
-	"GAME_OVER":       6,
+	"UNKNOWN_TYPE":     0,
+	"TURN_START":       1,
+	"PICK_UP_STOCK":    2,
+	"PICK_UP_DISCARD":  3,
+	"PLAY_CARDS":       4,
+	"DISCARD":          5,
+	"GAME_OVER":        6,
+	"GAME_EXPIRED":     7,
+	"SERVER_SHUTDOWN":  8,
+	"TURN_TIMEOUT":     9,
+	"PLAYER_AWAY":      10,
+	"PLAYER_RETURNED":  11,
+	"PLAYER_FORFEIT":   12,
+	"CHAT":             13,
+	"PLAYER_JOINED":    14,
+	"GAME_STARTED":     15,
+	"STOCK_RESHUFFLED": 16,
+	"RUMMY_CALLED":     17,
+	"PLAYER_LEFT":      18,
 }
 
 func (x GameEvent_Type) String() string {
 	return proto.EnumName(GameEvent_Type_name, int32(x))
 }
-func (GameEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+func (GameEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4, 0} }
+
+type PlayerView_Action int32
+
+const (
+	PlayerView_UNKNOWN_ACTION  PlayerView_Action = 0
+	PlayerView_PICK_UP_STOCK   PlayerView_Action = 1
+	PlayerView_PICK_UP_DISCARD PlayerView_Action = 2
+	PlayerView_PLAY_CARDS      PlayerView_Action = 3
+	PlayerView_DISCARD         PlayerView_Action = 4
+	PlayerView_CALL_RUMMY      PlayerView_Action = 5
+)
+
+var PlayerView_Action_name = map[int32]string{
+	0: "UNKNOWN_ACTION",
+	1: "PICK_UP_STOCK",
+	2: "PICK_UP_DISCARD",
+	3: "PLAY_CARDS",
+	4: "DISCARD",
+	5: "CALL_RUMMY",
+}
+var PlayerView_Action_value = map[string]int32{
+	"UNKNOWN_ACTION":  0,
+	"PICK_UP_STOCK":   1,
+	"PICK_UP_DISCARD": 2,
+	"PLAY_CARDS":      3,
+	"DISCARD":         4,
+	"CALL_RUMMY":      5,
+}
+
+func (x PlayerView_Action) String() string {
+	return proto.EnumName(PlayerView_Action_name, int32(x))
+}
+func (PlayerView_Action) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
 
 type Meld struct {
 	Cards []*deck.Card `protobuf:"bytes,1,rep,name=cards" json:"cards,omitempty"`
+	// Melds are numbered in the order that they are played, from 1.
+	Id int32 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
 }
 
 func (m *Meld) Reset()                    { *m = Meld{} }
@@ -128,6 +378,13 @@ func (m *Meld) GetCards() []*deck.Card {
 	return nil
 }
 
+func (m *Meld) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
 type PlayerState struct {
 	Id             int32        `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 	Name           string       `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -135,6 +392,18 @@ type PlayerState struct {
 	Rummies        []*deck.Card `protobuf:"bytes,4,rep,name=rummies" json:"rummies,omitempty"`
 	NumCardsInHand int32        `protobuf:"varint,5,opt,name=num_cards_in_hand,json=numCardsInHand" json:"num_cards_in_hand,omitempty"`
 	CurrentScore   int32        `protobuf:"varint,6,opt,name=current_score,json=currentScore" json:"current_score,omitempty"`
+	// Time remaining in the player's time bank, in milliseconds.
+	TimeBankRemainingMs int64 `protobuf:"varint,7,opt,name=time_bank_remaining_ms,json=timeBankRemainingMs" json:"time_bank_remaining_ms,omitempty"`
+	// True if the player has lost their connection to the game.
+	Away bool `protobuf:"varint,8,opt,name=away" json:"away,omitempty"`
+	// True if the player has left the game. Seats left before the
+	// game started have no name, until another player joins.
+	Forfeited bool `protobuf:"varint,9,opt,name=forfeited" json:"forfeited,omitempty"`
+	// The cards in the player's hand. Only revealed once the game is
+	// over, or to spectators after the game's spectator hand delay.
+	Hand []*deck.Card `protobuf:"bytes,10,rep,name=hand" json:"hand,omitempty"`
+	// The hand dealt to the player. Only revealed once the game is over.
+	InitialHand []*deck.Card `protobuf:"bytes,11,rep,name=initial_hand,json=initialHand" json:"initial_hand,omitempty"`
 }
 
 func (m *PlayerState) Reset()                    { *m = PlayerState{} }
@@ -184,6 +453,41 @@ func (m *PlayerState) GetCurrentScore() int32 {
 	return 0
 }
 
+func (m *PlayerState) GetTimeBankRemainingMs() int64 {
+	if m != nil {
+		return m.TimeBankRemainingMs
+	}
+	return 0
+}
+
+func (m *PlayerState) GetAway() bool {
+	if m != nil {
+		return m.Away
+	}
+	return false
+}
+
+func (m *PlayerState) GetForfeited() bool {
+	if m != nil {
+		return m.Forfeited
+	}
+	return false
+}
+
+func (m *PlayerState) GetHand() []*deck.Card {
+	if m != nil {
+		return m.Hand
+	}
+	return nil
+}
+
+func (m *PlayerState) GetInitialHand() []*deck.Card {
+	if m != nil {
+		return m.InitialHand
+	}
+	return nil
+}
+
 type GameState struct {
 	NumCardsInStock   int32               `protobuf:"varint,1,opt,name=num_cards_in_stock,json=numCardsInStock" json:"num_cards_in_stock,omitempty"`
 	DiscardPile       []*deck.Card        `protobuf:"bytes,2,rep,name=discard_pile,json=discardPile" json:"discard_pile,omitempty"`
@@ -193,6 +497,23 @@ type GameState struct {
 	CurrentPlayerTurn int32               `protobuf:"varint,7,opt,name=current_player_turn,json=currentPlayerTurn" json:"current_player_turn,omitempty"`
 	TurnState         GameState_TurnState `protobuf:"varint,8,opt,name=turn_state,json=turnState,enum=rummy.GameState_TurnState" json:"turn_state,omitempty"`
 	GameOver          bool                `protobuf:"varint,9,opt,name=game_over,json=gameOver" json:"game_over,omitempty"`
+	Status            GameState_Status    `protobuf:"varint,10,opt,name=status,enum=rummy.GameState_Status" json:"status,omitempty"`
+	// The sequence number of the most recent GameEvent, so that clients
+	// can subscribe to the events that follow this state.
+	LastSequence int64 `protobuf:"varint,11,opt,name=last_sequence,json=lastSequence" json:"last_sequence,omitempty"`
+	// The options the game was created with.
+	Options *GameOptions `protobuf:"bytes,12,opt,name=options" json:"options,omitempty"`
+	// Time left for the current player to complete their turn,
+	// including their time bank, in milliseconds. Zero if the game
+	// has no turn time limit.
+	TurnTimeRemainingMs int64 `protobuf:"varint,13,opt,name=turn_time_remaining_ms,json=turnTimeRemainingMs" json:"turn_time_remaining_ms,omitempty"`
+	// The stock and discard pile as they were after dealing, with the
+	// top of the stock last. Only revealed once the game is over.
+	InitialStock   []*deck.Card `protobuf:"bytes,14,rep,name=initial_stock,json=initialStock" json:"initial_stock,omitempty"`
+	InitialDiscard *deck.Card   `protobuf:"bytes,15,opt,name=initial_discard,json=initialDiscard" json:"initial_discard,omitempty"`
+	// If players' hands are revealed to spectators after a delay, the
+	// sequence number of the last event reflected in the hands.
+	HandsRevealedSequence int64 `protobuf:"varint,16,opt,name=hands_revealed_sequence,json=handsRevealedSequence" json:"hands_revealed_sequence,omitempty"`
 }
 
 func (m *GameState) Reset()                    { *m = GameState{} }
@@ -256,17 +577,197 @@ func (m *GameState) GetGameOver() bool {
 	return false
 }
 
+func (m *GameState) GetStatus() GameState_Status {
+	if m != nil {
+		return m.Status
+	}
+	return GameState_LOBBY
+}
+
+func (m *GameState) GetLastSequence() int64 {
+	if m != nil {
+		return m.LastSequence
+	}
+	return 0
+}
+
+func (m *GameState) GetOptions() *GameOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *GameState) GetTurnTimeRemainingMs() int64 {
+	if m != nil {
+		return m.TurnTimeRemainingMs
+	}
+	return 0
+}
+
+func (m *GameState) GetInitialStock() []*deck.Card {
+	if m != nil {
+		return m.InitialStock
+	}
+	return nil
+}
+
+func (m *GameState) GetInitialDiscard() *deck.Card {
+	if m != nil {
+		return m.InitialDiscard
+	}
+	return nil
+}
+
+func (m *GameState) GetHandsRevealedSequence() int64 {
+	if m != nil {
+		return m.HandsRevealedSequence
+	}
+	return 0
+}
+
+// Options that may be chosen when a game is created.
+type GameOptions struct {
+	// The number of players that must join before the game can be
+	// started. If zero, defaults to 1.
+	MinPlayers int32 `protobuf:"varint,1,opt,name=min_players,json=minPlayers" json:"min_players,omitempty"`
+	// The maximum number of players that may join the game.
+	// If zero, defaults to the most players that can be dealt
+	// a hand from a single deck.
+	MaxPlayers   int32                    `protobuf:"varint,2,opt,name=max_players,json=maxPlayers" json:"max_players,omitempty"`
+	RulesVariant GameOptions_RulesVariant `protobuf:"varint,3,opt,name=rules_variant,json=rulesVariant,enum=rummy.GameOptions_RulesVariant" json:"rules_variant,omitempty"`
+	// The score at which a match of several games is meant to end,
+	// or zero if the game is not part of a match. This is informational
+	// only: the server plays a single hand and does not enforce it,
+	// so clients that play matches must keep score across games.
+	TargetScore int32 `protobuf:"varint,4,opt,name=target_score,json=targetScore" json:"target_score,omitempty"`
+	// The time each player has to complete their turn,
+	// or zero for no limit. If a player does not complete their
+	// turn in time, it is played for them automatically.
+	TurnTimeLimitSeconds int32                  `protobuf:"varint,5,opt,name=turn_time_limit_seconds,json=turnTimeLimitSeconds" json:"turn_time_limit_seconds,omitempty"`
+	Visibility           GameOptions_Visibility `protobuf:"varint,6,opt,name=visibility,enum=rummy.GameOptions_Visibility" json:"visibility,omitempty"`
+	// If true, computer players may not join the game, and players
+	// who are away are not replaced by them.
+	DisallowBots    bool                        `protobuf:"varint,7,opt,name=disallow_bots,json=disallowBots" json:"disallow_bots,omitempty"`
+	SpectatorPolicy GameOptions_SpectatorPolicy `protobuf:"varint,8,opt,name=spectator_policy,json=spectatorPolicy,enum=rummy.GameOptions_SpectatorPolicy" json:"spectator_policy,omitempty"`
+	// Additional time each player may draw on over the course of the
+	// game once they exceed the turn time limit.
+	TimeBankSeconds int32 `protobuf:"varint,9,opt,name=time_bank_seconds,json=timeBankSeconds" json:"time_bank_seconds,omitempty"`
+	// If non-zero, spectators who have joined the game are shown all
+	// players' hands as they were this long ago, e.g. for streamed
+	// coverage. Otherwise hands are hidden until the game is over.
+	// Must be at least 300 seconds, since players may also spectate
+	// their own game.
+	SpectatorHandDelaySeconds int32 `protobuf:"varint,10,opt,name=spectator_hand_delay_seconds,json=spectatorHandDelaySeconds" json:"spectator_hand_delay_seconds,omitempty"`
+}
+
+func (m *GameOptions) Reset()                    { *m = GameOptions{} }
+func (m *GameOptions) String() string            { return proto.CompactTextString(m) }
+func (*GameOptions) ProtoMessage()               {}
+func (*GameOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GameOptions) GetMinPlayers() int32 {
+	if m != nil {
+		return m.MinPlayers
+	}
+	return 0
+}
+
+func (m *GameOptions) GetMaxPlayers() int32 {
+	if m != nil {
+		return m.MaxPlayers
+	}
+	return 0
+}
+
+func (m *GameOptions) GetRulesVariant() GameOptions_RulesVariant {
+	if m != nil {
+		return m.RulesVariant
+	}
+	return GameOptions_STANDARD
+}
+
+func (m *GameOptions) GetTargetScore() int32 {
+	if m != nil {
+		return m.TargetScore
+	}
+	return 0
+}
+
+func (m *GameOptions) GetTurnTimeLimitSeconds() int32 {
+	if m != nil {
+		return m.TurnTimeLimitSeconds
+	}
+	return 0
+}
+
+func (m *GameOptions) GetVisibility() GameOptions_Visibility {
+	if m != nil {
+		return m.Visibility
+	}
+	return GameOptions_PUBLIC
+}
+
+func (m *GameOptions) GetDisallowBots() bool {
+	if m != nil {
+		return m.DisallowBots
+	}
+	return false
+}
+
+func (m *GameOptions) GetSpectatorPolicy() GameOptions_SpectatorPolicy {
+	if m != nil {
+		return m.SpectatorPolicy
+	}
+	return GameOptions_SPECTATORS_ALLOWED
+}
+
+func (m *GameOptions) GetTimeBankSeconds() int32 {
+	if m != nil {
+		return m.TimeBankSeconds
+	}
+	return 0
+}
+
+func (m *GameOptions) GetSpectatorHandDelaySeconds() int32 {
+	if m != nil {
+		return m.SpectatorHandDelaySeconds
+	}
+	return 0
+}
+
 type GameEvent struct {
 	PlayerId int32          `protobuf:"varint,1,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
 	Type     GameEvent_Type `protobuf:"varint,2,opt,name=type,enum=rummy.GameEvent_Type" json:"type,omitempty"`
 	Cards    []*deck.Card   `protobuf:"bytes,3,rep,name=cards" json:"cards,omitempty"`
 	Score    int32          `protobuf:"varint,4,opt,name=score" json:"score,omitempty"`
+	// Events in a game are numbered sequentially, starting from 1.
+	Sequence int64 `protobuf:"varint,5,opt,name=sequence" json:"sequence,omitempty"`
+	// Server time at which the event occurred, in milliseconds
+	// since the Unix epoch.
+	Timestamp int64 `protobuf:"varint,6,opt,name=timestamp" json:"timestamp,omitempty"`
+	// For GAME_OVER events, true if the game ended because all other
+	// players forfeited. The player_id is that of the remaining player.
+	Forfeit bool `protobuf:"varint,7,opt,name=forfeit" json:"forfeit,omitempty"`
+	// For CHAT events, the text of the message.
+	Message string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
+	// The turn of the game (see GameState.turn) when the event occurred.
+	Turn int32 `protobuf:"varint,9,opt,name=turn" json:"turn,omitempty"`
+	// For PLAY_CARDS events, the id of the new meld, or of the meld
+	// the cards were played off of. For RUMMY_CALLED events, the id of
+	// the meld the cards were played off of.
+	MeldId int32 `protobuf:"varint,10,opt,name=meld_id,json=meldId" json:"meld_id,omitempty"`
+	// For PLAYER_JOINED events, the name of the player.
+	PlayerName string `protobuf:"bytes,11,opt,name=player_name,json=playerName" json:"player_name,omitempty"`
+	// For GAME_STARTED events, the ids of the players in the order
+	// that they take turns.
+	SeatOrder []int32 `protobuf:"varint,12,rep,name=seat_order,json=seatOrder,packed" json:"seat_order,omitempty"`
 }
 
 func (m *GameEvent) Reset()                    { *m = GameEvent{} }
 func (m *GameEvent) String() string            { return proto.CompactTextString(m) }
 func (*GameEvent) ProtoMessage()               {}
-func (*GameEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*GameEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *GameEvent) GetPlayerId() int32 {
 	if m != nil {
@@ -296,55 +797,439 @@ func (m *GameEvent) GetScore() int32 {
 	return 0
 }
 
+func (m *GameEvent) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *GameEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *GameEvent) GetForfeit() bool {
+	if m != nil {
+		return m.Forfeit
+	}
+	return false
+}
+
+func (m *GameEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *GameEvent) GetTurn() int32 {
+	if m != nil {
+		return m.Turn
+	}
+	return 0
+}
+
+func (m *GameEvent) GetMeldId() int32 {
+	if m != nil {
+		return m.MeldId
+	}
+	return 0
+}
+
+func (m *GameEvent) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
+	}
+	return ""
+}
+
+func (m *GameEvent) GetSeatOrder() []int32 {
+	if m != nil {
+		return m.SeatOrder
+	}
+	return nil
+}
+
+// A player's view of the game: the public game state along with
+// their private hand and the actions they may currently take.
+type PlayerView struct {
+	GameState *GameState `protobuf:"bytes,1,opt,name=game_state,json=gameState" json:"game_state,omitempty"`
+	PlayerId  int32      `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	// The cards in the player's hand, sorted by suit and rank.
+	Hand []*deck.Card `protobuf:"bytes,3,rep,name=hand" json:"hand,omitempty"`
+	// If set, this card was picked up from the discard pile this turn
+	// and must be played before the player may discard.
+	MustPlayCard *deck.Card          `protobuf:"bytes,4,opt,name=must_play_card,json=mustPlayCard" json:"must_play_card,omitempty"`
+	IsMyTurn     bool                `protobuf:"varint,5,opt,name=is_my_turn,json=isMyTurn" json:"is_my_turn,omitempty"`
+	TurnState    GameState_TurnState `protobuf:"varint,6,opt,name=turn_state,json=turnState,enum=rummy.GameState_TurnState" json:"turn_state,omitempty"`
+	// The kinds of action that the player may currently take.
+	LegalActions []PlayerView_Action `protobuf:"varint,7,rep,name=legal_actions,json=legalActions,enum=rummy.PlayerView_Action,packed" json:"legal_actions,omitempty"`
+}
+
+func (m *PlayerView) Reset()                    { *m = PlayerView{} }
+func (m *PlayerView) String() string            { return proto.CompactTextString(m) }
+func (*PlayerView) ProtoMessage()               {}
+func (*PlayerView) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PlayerView) GetGameState() *GameState {
+	if m != nil {
+		return m.GameState
+	}
+	return nil
+}
+
+func (m *PlayerView) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PlayerView) GetHand() []*deck.Card {
+	if m != nil {
+		return m.Hand
+	}
+	return nil
+}
+
+func (m *PlayerView) GetMustPlayCard() *deck.Card {
+	if m != nil {
+		return m.MustPlayCard
+	}
+	return nil
+}
+
+func (m *PlayerView) GetIsMyTurn() bool {
+	if m != nil {
+		return m.IsMyTurn
+	}
+	return false
+}
+
+func (m *PlayerView) GetTurnState() GameState_TurnState {
+	if m != nil {
+		return m.TurnState
+	}
+	return GameState_TURN_START
+}
+
+func (m *PlayerView) GetLegalActions() []PlayerView_Action {
+	if m != nil {
+		return m.LegalActions
+	}
+	return nil
+}
+
+// The complete state of a game, including private information
+// such as the cards in each player's hand and the order of the stock.
+// Used to persist games across server restarts.
+type GameSnapshot struct {
+	Stock          []*deck.Card           `protobuf:"bytes,1,rep,name=stock" json:"stock,omitempty"`
+	DiscardPile    []*deck.Card           `protobuf:"bytes,2,rep,name=discard_pile,json=discardPile" json:"discard_pile,omitempty"`
+	Players        []*GameSnapshot_Player `protobuf:"bytes,3,rep,name=players" json:"players,omitempty"`
+	Turn           int32                  `protobuf:"varint,4,opt,name=turn" json:"turn,omitempty"`
+	CurrentPlayer  int32                  `protobuf:"varint,5,opt,name=current_player,json=currentPlayer" json:"current_player,omitempty"`
+	TurnState      GameState_TurnState    `protobuf:"varint,6,opt,name=turn_state,json=turnState,enum=rummy.GameState_TurnState" json:"turn_state,omitempty"`
+	MustPlayCard   *deck.Card             `protobuf:"bytes,7,opt,name=must_play_card,json=mustPlayCard" json:"must_play_card,omitempty"`
+	GameOver       bool                   `protobuf:"varint,8,opt,name=game_over,json=gameOver" json:"game_over,omitempty"`
+	History        []*GameEvent           `protobuf:"bytes,9,rep,name=history" json:"history,omitempty"`
+	Options        *GameOptions           `protobuf:"bytes,10,opt,name=options" json:"options,omitempty"`
+	InitialStock   []*deck.Card           `protobuf:"bytes,11,rep,name=initial_stock,json=initialStock" json:"initial_stock,omitempty"`
+	InitialDiscard *deck.Card             `protobuf:"bytes,12,opt,name=initial_discard,json=initialDiscard" json:"initial_discard,omitempty"`
+}
+
+func (m *GameSnapshot) Reset()                    { *m = GameSnapshot{} }
+func (m *GameSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*GameSnapshot) ProtoMessage()               {}
+func (*GameSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *GameSnapshot) GetStock() []*deck.Card {
+	if m != nil {
+		return m.Stock
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetDiscardPile() []*deck.Card {
+	if m != nil {
+		return m.DiscardPile
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetPlayers() []*GameSnapshot_Player {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetTurn() int32 {
+	if m != nil {
+		return m.Turn
+	}
+	return 0
+}
+
+func (m *GameSnapshot) GetCurrentPlayer() int32 {
+	if m != nil {
+		return m.CurrentPlayer
+	}
+	return 0
+}
+
+func (m *GameSnapshot) GetTurnState() GameState_TurnState {
+	if m != nil {
+		return m.TurnState
+	}
+	return GameState_TURN_START
+}
+
+func (m *GameSnapshot) GetMustPlayCard() *deck.Card {
+	if m != nil {
+		return m.MustPlayCard
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetGameOver() bool {
+	if m != nil {
+		return m.GameOver
+	}
+	return false
+}
+
+func (m *GameSnapshot) GetHistory() []*GameEvent {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetOptions() *GameOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetInitialStock() []*deck.Card {
+	if m != nil {
+		return m.InitialStock
+	}
+	return nil
+}
+
+func (m *GameSnapshot) GetInitialDiscard() *deck.Card {
+	if m != nil {
+		return m.InitialDiscard
+	}
+	return nil
+}
+
+type GameSnapshot_Player struct {
+	Name        string       `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Hand        []*deck.Card `protobuf:"bytes,2,rep,name=hand" json:"hand,omitempty"`
+	Melds       []*Meld      `protobuf:"bytes,3,rep,name=melds" json:"melds,omitempty"`
+	Rummies     []*deck.Card `protobuf:"bytes,4,rep,name=rummies" json:"rummies,omitempty"`
+	TimeBankMs  int64        `protobuf:"varint,5,opt,name=time_bank_ms,json=timeBankMs" json:"time_bank_ms,omitempty"`
+	Away        bool         `protobuf:"varint,6,opt,name=away" json:"away,omitempty"`
+	Forfeited   bool         `protobuf:"varint,7,opt,name=forfeited" json:"forfeited,omitempty"`
+	InitialHand []*deck.Card `protobuf:"bytes,8,rep,name=initial_hand,json=initialHand" json:"initial_hand,omitempty"`
+}
+
+func (m *GameSnapshot_Player) Reset()                    { *m = GameSnapshot_Player{} }
+func (m *GameSnapshot_Player) String() string            { return proto.CompactTextString(m) }
+func (*GameSnapshot_Player) ProtoMessage()               {}
+func (*GameSnapshot_Player) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6, 0} }
+
+func (m *GameSnapshot_Player) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GameSnapshot_Player) GetHand() []*deck.Card {
+	if m != nil {
+		return m.Hand
+	}
+	return nil
+}
+
+func (m *GameSnapshot_Player) GetMelds() []*Meld {
+	if m != nil {
+		return m.Melds
+	}
+	return nil
+}
+
+func (m *GameSnapshot_Player) GetRummies() []*deck.Card {
+	if m != nil {
+		return m.Rummies
+	}
+	return nil
+}
+
+func (m *GameSnapshot_Player) GetTimeBankMs() int64 {
+	if m != nil {
+		return m.TimeBankMs
+	}
+	return 0
+}
+
+func (m *GameSnapshot_Player) GetAway() bool {
+	if m != nil {
+		return m.Away
+	}
+	return false
+}
+
+func (m *GameSnapshot_Player) GetForfeited() bool {
+	if m != nil {
+		return m.Forfeited
+	}
+	return false
+}
+
+func (m *GameSnapshot_Player) GetInitialHand() []*deck.Card {
+	if m != nil {
+		return m.InitialHand
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Meld)(nil), "rummy.Meld")
 	proto.RegisterType((*PlayerState)(nil), "rummy.PlayerState")
 	proto.RegisterType((*GameState)(nil), "rummy.GameState")
+	proto.RegisterType((*GameOptions)(nil), "rummy.GameOptions")
 	proto.RegisterType((*GameEvent)(nil), "rummy.GameEvent")
+	proto.RegisterType((*PlayerView)(nil), "rummy.PlayerView")
+	proto.RegisterType((*GameSnapshot)(nil), "rummy.GameSnapshot")
+	proto.RegisterType((*GameSnapshot_Player)(nil), "rummy.GameSnapshot.Player")
 	proto.RegisterEnum("rummy.GameState_TurnState", GameState_TurnState_name, GameState_TurnState_value)
+	proto.RegisterEnum("rummy.GameState_Status", GameState_Status_name, GameState_Status_value)
+	proto.RegisterEnum("rummy.GameOptions_RulesVariant", GameOptions_RulesVariant_name, GameOptions_RulesVariant_value)
+	proto.RegisterEnum("rummy.GameOptions_Visibility", GameOptions_Visibility_name, GameOptions_Visibility_value)
+	proto.RegisterEnum("rummy.GameOptions_SpectatorPolicy", GameOptions_SpectatorPolicy_name, GameOptions_SpectatorPolicy_value)
 	proto.RegisterEnum("rummy.GameEvent_Type", GameEvent_Type_name, GameEvent_Type_value)
+	proto.RegisterEnum("rummy.PlayerView_Action", PlayerView_Action_name, PlayerView_Action_value)
 }
 
 func init() { proto.RegisterFile("game.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 596 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xdf, 0x4e, 0xdb, 0x3e,
-	0x14, 0xc7, 0x49, 0x9b, 0xd0, 0xe6, 0x14, 0x4a, 0x30, 0xbf, 0x9f, 0x14, 0xb1, 0x9b, 0xae, 0xdb,
-	0x45, 0x11, 0x5b, 0x90, 0x98, 0x34, 0x69, 0x97, 0x5d, 0x5b, 0xb1, 0x8a, 0xd1, 0x56, 0x49, 0xd8,
-	0xc4, 0x95, 0x65, 0x62, 0xab, 0x44, 0xe4, 0x9f, 0x1c, 0x07, 0xa9, 0xd2, 0x5e, 0x60, 0x0f, 0xb3,
-	0x17, 0xd9, 0x53, 0x4d, 0xb6, 0x13, 0xca, 0x98, 0xb8, 0xa9, 0xdc, 0xef, 0xf9, 0xf8, 0x9c, 0xf3,
-	0x3d, 0x3e, 0x01, 0x58, 0x93, 0x94, 0x79, 0x05, 0xcf, 0x45, 0x8e, 0x2c, 0x5e, 0xa5, 0xe9, 0xe6,
-	0xf8, 0x74, 0x1d, 0x8b, 0xbb, 0xea, 0xd6, 0x8b, 0xf2, 0xf4, 0x4c, 0xc4, 0x69, 0x41, 0x92, 0x82,
-	0x64, 0xe2, 0x4c, 0x05, 0xcf, 0x28, 0x8b, 0xee, 0xd5, 0x8f, 0xbe, 0x33, 0x1c, 0x81, 0x79, 0xc5,
-	0x12, 0x8a, 0x06, 0x60, 0x45, 0x84, 0xd3, 0xd2, 0x35, 0x06, 0xed, 0x51, 0xef, 0x1c, 0x3c, 0xc5,
-	0x4c, 0x08, 0xa7, 0xbe, 0x0e, 0x0c, 0x7f, 0x1b, 0xd0, 0x5b, 0x25, 0x64, 0xc3, 0x78, 0x20, 0x88,
-	0x60, 0xa8, 0x0f, 0xad, 0x98, 0xba, 0xc6, 0xc0, 0x18, 0x59, 0x7e, 0x2b, 0xa6, 0x08, 0x81, 0x99,
-	0x91, 0x94, 0xb9, 0xad, 0x81, 0x31, 0xb2, 0x7d, 0x75, 0x46, 0xaf, 0xc1, 0x4a, 0x59, 0x42, 0x4b,
-	0xb7, 0xad, 0xb2, 0xf6, 0x3c, 0xd5, 0x84, 0x27, 0x2b, 0xfa, 0x3a, 0x82, 0xde, 0x42, 0x47, 0x8a,
-	0x31, 0x2b, 0x5d, 0xf3, 0x9f, 0xd2, 0x4d, 0x08, 0x9d, 0xc0, 0x61, 0x56, 0xa5, 0x58, 0x75, 0x82,
-	0xe3, 0x0c, 0xdf, 0x91, 0x8c, 0xba, 0x96, 0xaa, 0xdd, 0xcf, 0xaa, 0x54, 0xc2, 0xe5, 0x3c, 0xfb,
-	0x42, 0x32, 0x8a, 0xde, 0xc0, 0x7e, 0x54, 0x71, 0xce, 0x32, 0x81, 0xcb, 0x28, 0xe7, 0xcc, 0xdd,
-	0x55, 0xd8, 0x5e, 0x2d, 0x06, 0x52, 0x1b, 0xfe, 0x6a, 0x83, 0x7d, 0x41, 0x52, 0xa6, 0xad, 0x9c,
-	0x02, 0xfa, 0x2b, 0x7b, 0x29, 0xf2, 0xe8, 0xbe, 0xb6, 0x76, 0xb0, 0x4d, 0x1f, 0x48, 0x19, 0xbd,
-	0x87, 0x3d, 0x1a, 0x97, 0x92, 0xc5, 0x45, 0x9c, 0x48, 0xbf, 0xcf, 0xbb, 0xee, 0xd5, 0xf1, 0x55,
-	0x9c, 0x30, 0xf4, 0x11, 0x1c, 0xb2, 0x5e, 0x73, 0xb6, 0x26, 0x82, 0x51, 0xfc, 0xe2, 0x34, 0x0e,
-	0xb6, 0xd0, 0x95, 0x9a, 0xcb, 0x3b, 0xe8, 0x14, 0x6a, 0xda, 0xcd, 0x5c, 0x50, 0x8d, 0x3f, 0x79,
-	0x03, 0xbf, 0x41, 0xe4, 0xf0, 0x45, 0xc5, 0xb3, 0xda, 0xab, 0x3a, 0x23, 0x0f, 0x8e, 0x9a, 0x41,
-	0x68, 0x0c, 0x2b, 0xa4, 0xa3, 0x90, 0xc3, 0x3a, 0xa4, 0xb3, 0x85, 0x92, 0xff, 0x04, 0x20, 0x01,
-	0x5c, 0xca, 0xd4, 0x6e, 0x77, 0x60, 0x8c, 0xfa, 0xe7, 0xc7, 0x75, 0xd1, 0xc7, 0x59, 0x79, 0x12,
-	0xd5, 0xc5, 0x6d, 0xd1, 0x1c, 0xd1, 0x2b, 0xb0, 0xe5, 0x1e, 0xe2, 0xfc, 0x81, 0x71, 0xd7, 0x1e,
-	0x18, 0xa3, 0xae, 0xdf, 0x95, 0xc2, 0xf2, 0x81, 0xf1, 0xe1, 0x67, 0xb0, 0x1f, 0x2f, 0xa1, 0x3e,
-	0x40, 0x78, 0xed, 0x2f, 0x70, 0x10, 0x8e, 0xfd, 0xd0, 0xd9, 0x41, 0x47, 0x70, 0xb0, 0x9a, 0x4f,
-	0x2e, 0x67, 0x53, 0x7c, 0xbd, 0xc2, 0x93, 0xb1, 0x3f, 0x0d, 0x1c, 0x03, 0x39, 0xb0, 0xb7, 0xfa,
-	0x3a, 0xbe, 0x99, 0x4d, 0x6b, 0xa5, 0x35, 0xfc, 0xd9, 0xd2, 0xef, 0x35, 0x7b, 0x60, 0x99, 0x90,
-	0xe5, 0x6a, 0x47, 0x8f, 0x1b, 0xd8, 0xd5, 0xc2, 0x9c, 0xa2, 0x13, 0x30, 0xc5, 0xa6, 0xd0, 0x7b,
-	0xd8, 0x3f, 0xff, 0xff, 0x89, 0x01, 0x75, 0xd9, 0x0b, 0x37, 0x05, 0xf3, 0x15, 0xb2, 0x5d, 0xfa,
-	0xf6, 0x0b, 0x4b, 0x8f, 0xfe, 0x03, 0x4b, 0x2f, 0x91, 0xa9, 0xaa, 0xe8, 0x3f, 0xc3, 0x1f, 0x60,
-	0xca, 0x2c, 0xb2, 0xcf, 0xeb, 0xc5, 0xe5, 0x62, 0xf9, 0x7d, 0x81, 0xc3, 0x9b, 0xd5, 0xcc, 0xd9,
-	0x79, 0x66, 0xcf, 0x40, 0x87, 0xb0, 0x2f, 0xed, 0x49, 0x73, 0x41, 0xb8, 0x9c, 0x5c, 0x3a, 0xad,
-	0xc6, 0xb1, 0x94, 0xa6, 0xf3, 0x40, 0x1a, 0x74, 0xda, 0xf2, 0x9e, 0x74, 0x5c, 0xfb, 0x35, 0x51,
-	0x0f, 0x3a, 0x4d, 0xd0, 0x42, 0xfb, 0x60, 0x5f, 0x8c, 0xaf, 0x66, 0x78, 0xf9, 0x6d, 0xe6, 0x3b,
-	0xbb, 0xb7, 0xbb, 0xea, 0xcb, 0xfd, 0xf0, 0x27, 0x00, 0x00, 0xff, 0xff, 0x54, 0xc5, 0x18, 0xdb,
-	0xfb, 0x03, 0x00, 0x00,
+	// 1724 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x92, 0xdb, 0xc6,
+	0xd1, 0x5e, 0x9e, 0xc9, 0xe6, 0x09, 0x3b, 0x92, 0x2d, 0xfc, 0xfa, 0xe5, 0x98, 0x66, 0xe2, 0xaa,
+	0xb5, 0x9d, 0x70, 0x53, 0xab, 0xc4, 0x95, 0x54, 0xc5, 0x49, 0xb8, 0x24, 0x56, 0x62, 0x44, 0x12,
+	0xac, 0x01, 0x76, 0x95, 0xbd, 0x9a, 0xc2, 0x12, 0x63, 0x0a, 0x25, 0x1c, 0x68, 0x00, 0x5c, 0x99,
+	0x77, 0xb9, 0xcc, 0x8b, 0xe4, 0x0d, 0x52, 0x95, 0xdb, 0x5c, 0xe5, 0x15, 0xf2, 0x3a, 0xa9, 0x9e,
+	0xc1, 0xf0, 0xa8, 0x8d, 0xca, 0x49, 0x6e, 0x50, 0x98, 0xee, 0x6f, 0x4e, 0xdd, 0xdf, 0xf4, 0x7c,
+	0x03, 0xb0, 0x70, 0x02, 0xde, 0x5b, 0xc6, 0x51, 0x1a, 0x91, 0x52, 0xbc, 0x0a, 0x82, 0xf5, 0xd3,
+	0xaf, 0x16, 0x5e, 0xfa, 0x66, 0x75, 0xd7, 0x9b, 0x47, 0xc1, 0x79, 0xea, 0x05, 0x4b, 0xc7, 0x5f,
+	0x3a, 0x61, 0x7a, 0x2e, 0x9c, 0xe7, 0x2e, 0x9f, 0xbf, 0x15, 0x1f, 0xd9, 0xa7, 0xfb, 0x2b, 0x28,
+	0x4e, 0xb8, 0xef, 0x92, 0x0e, 0x94, 0xe6, 0x4e, 0xec, 0x26, 0x7a, 0xae, 0x53, 0x38, 0xab, 0x5f,
+	0x40, 0x4f, 0x60, 0x06, 0x4e, 0xec, 0x52, 0xe9, 0x20, 0x2d, 0xc8, 0x7b, 0xae, 0x9e, 0xef, 0xe4,
+	0xce, 0x4a, 0x34, 0xef, 0xb9, 0xdd, 0x3f, 0x15, 0xa0, 0x3e, 0xf3, 0x9d, 0x35, 0x8f, 0xad, 0xd4,
+	0x49, 0x79, 0xe6, 0xcf, 0x29, 0x3f, 0x21, 0x50, 0x0c, 0x9d, 0x80, 0x8b, 0x1e, 0x35, 0x2a, 0xfe,
+	0xc9, 0x67, 0x50, 0x0a, 0xb8, 0xef, 0x26, 0x7a, 0x41, 0xcc, 0x52, 0xef, 0x89, 0x45, 0xf5, 0x70,
+	0x05, 0x54, 0x7a, 0xc8, 0x4f, 0xa0, 0x82, 0x46, 0x8f, 0x27, 0x7a, 0xf1, 0x68, 0x29, 0xca, 0x45,
+	0xbe, 0x80, 0xd3, 0x70, 0x15, 0x30, 0xb1, 0x32, 0xe6, 0x85, 0xec, 0x8d, 0x13, 0xba, 0x7a, 0x49,
+	0xcc, 0xdd, 0x0a, 0x57, 0x01, 0x82, 0x93, 0x51, 0xf8, 0xd2, 0x09, 0x5d, 0xf2, 0x63, 0x68, 0xce,
+	0x57, 0x71, 0xcc, 0xc3, 0x94, 0x25, 0xf3, 0x28, 0xe6, 0x7a, 0x59, 0xc0, 0x1a, 0x99, 0xd1, 0x42,
+	0x1b, 0x79, 0x0e, 0x1f, 0xa7, 0x5e, 0xc0, 0xd9, 0x9d, 0x13, 0xbe, 0x65, 0x31, 0x0f, 0x1c, 0x2f,
+	0xf4, 0xc2, 0x05, 0x0b, 0x12, 0xbd, 0xd2, 0xc9, 0x9d, 0x15, 0xe8, 0x23, 0xf4, 0x5e, 0x3a, 0xe1,
+	0x5b, 0xaa, 0x7c, 0x93, 0x04, 0x77, 0xe8, 0xbc, 0x73, 0xd6, 0x7a, 0xb5, 0x93, 0x3b, 0xab, 0x52,
+	0xf1, 0x4f, 0x9e, 0x41, 0xed, 0xdb, 0x28, 0xfe, 0x96, 0x7b, 0x29, 0x77, 0xf5, 0x9a, 0x70, 0x6c,
+	0x0d, 0xe4, 0x47, 0x50, 0x14, 0x2b, 0x85, 0xa3, 0x9d, 0x09, 0x3b, 0xf9, 0x19, 0x34, 0xbc, 0xd0,
+	0x4b, 0x3d, 0xc7, 0x97, 0x3b, 0xaa, 0x1f, 0xe1, 0xea, 0x99, 0x1f, 0xb7, 0xd6, 0xfd, 0x47, 0x19,
+	0x6a, 0x2f, 0x9c, 0x80, 0xcb, 0x04, 0x7c, 0x05, 0x64, 0x2f, 0x26, 0x49, 0x1a, 0xcd, 0xdf, 0x66,
+	0x09, 0x69, 0x6f, 0x83, 0x62, 0xa1, 0x19, 0x67, 0x72, 0xbd, 0x04, 0xb1, 0x6c, 0xe9, 0xf9, 0x98,
+	0xa5, 0xa3, 0x99, 0x32, 0xff, 0xcc, 0xf3, 0x39, 0xf9, 0x1a, 0x34, 0x67, 0xb1, 0x88, 0xf9, 0xc2,
+	0x49, 0xb9, 0xcb, 0x1e, 0xcc, 0x61, 0x7b, 0x0b, 0x9a, 0x88, 0x6c, 0xfe, 0x14, 0x2a, 0x4b, 0xc1,
+	0x11, 0x95, 0x4d, 0x92, 0xc1, 0x77, 0x98, 0x43, 0x15, 0x04, 0x03, 0x9a, 0xae, 0xe2, 0x30, 0xcb,
+	0x90, 0xf8, 0x27, 0x3d, 0x78, 0xa4, 0xd2, 0x27, 0x61, 0x4c, 0x40, 0x2a, 0x02, 0x72, 0x9a, 0xb9,
+	0xe4, 0x68, 0x36, 0xe2, 0x7f, 0x0d, 0x80, 0x00, 0x96, 0xe0, 0xd0, 0x22, 0x35, 0xad, 0x8b, 0xa7,
+	0xd9, 0xa4, 0x9b, 0x58, 0xf5, 0x10, 0x2a, 0x27, 0xaf, 0xa5, 0xea, 0x97, 0xfc, 0x3f, 0xd4, 0xf0,
+	0x34, 0xb1, 0xe8, 0x9e, 0xc7, 0x59, 0xee, 0xaa, 0x68, 0x30, 0xef, 0x79, 0x4c, 0xce, 0xa1, 0x8c,
+	0x43, 0xae, 0x12, 0x1d, 0xc4, 0x98, 0x4f, 0x8e, 0xc6, 0xb4, 0x84, 0x9b, 0x66, 0x30, 0xe4, 0x9d,
+	0xef, 0x24, 0x29, 0x4b, 0xf8, 0x77, 0x2b, 0x1e, 0xce, 0xb9, 0x5e, 0x17, 0x4c, 0x6a, 0xa0, 0xd1,
+	0xca, 0x6c, 0x18, 0x9f, 0x68, 0x99, 0x7a, 0x51, 0x98, 0xe8, 0x8d, 0x4e, 0x6e, 0x27, 0x3e, 0x38,
+	0xac, 0x29, 0x3d, 0x54, 0x41, 0x04, 0x4b, 0x71, 0x6f, 0x82, 0xaa, 0x7b, 0x2c, 0x6d, 0x66, 0x2c,
+	0x5d, 0xc5, 0xa1, 0xed, 0x05, 0x7c, 0x97, 0xa5, 0xe7, 0xd0, 0x54, 0x9c, 0x92, 0x8c, 0x68, 0x1d,
+	0xa5, 0x5a, 0x91, 0x4e, 0x52, 0xe3, 0x39, 0xb4, 0x55, 0x87, 0x8c, 0x02, 0x7a, 0xbb, 0x93, 0x3b,
+	0xe8, 0xd2, 0xca, 0x20, 0x43, 0x89, 0x20, 0x5f, 0xc3, 0x13, 0x64, 0x6c, 0xc2, 0x62, 0x7e, 0xcf,
+	0x1d, 0x9f, 0xbb, 0xdb, 0x7d, 0x6b, 0x62, 0x6d, 0x1f, 0x09, 0x37, 0xcd, 0xbc, 0x2a, 0x00, 0xdd,
+	0x4b, 0xa8, 0x6d, 0x72, 0x41, 0x5a, 0x00, 0xf6, 0x35, 0x9d, 0x32, 0xcb, 0xee, 0x53, 0x5b, 0x3b,
+	0x21, 0x8f, 0xa0, 0x3d, 0x1b, 0x0d, 0x5e, 0x19, 0x43, 0x76, 0x3d, 0x63, 0x83, 0x3e, 0x1d, 0x5a,
+	0x5a, 0x8e, 0x68, 0xd0, 0x98, 0x8d, 0xfb, 0xb7, 0xc6, 0x30, 0xb3, 0xe4, 0xbb, 0xbf, 0x87, 0xb2,
+	0x8c, 0x3d, 0xa9, 0x41, 0x69, 0x6c, 0x5e, 0x5e, 0xde, 0x6a, 0x27, 0xa4, 0x0d, 0xf5, 0xd1, 0x94,
+	0xcd, 0xa8, 0xf9, 0x82, 0x1a, 0x16, 0xf6, 0x6b, 0x42, 0x6d, 0x60, 0x4e, 0x66, 0x63, 0xc3, 0x36,
+	0x86, 0x5a, 0x9e, 0xd4, 0xa1, 0x62, 0xfc, 0x71, 0x36, 0xa2, 0xc6, 0x50, 0x2b, 0x74, 0xff, 0x5a,
+	0x82, 0xfa, 0x4e, 0xc4, 0xc9, 0xa7, 0x50, 0x0f, 0xbc, 0x90, 0x29, 0xea, 0xca, 0x33, 0x04, 0x81,
+	0x17, 0x4a, 0xa2, 0x49, 0x80, 0xf3, 0xfd, 0x06, 0x90, 0xcf, 0x00, 0xce, 0xf7, 0x0a, 0x30, 0x84,
+	0x66, 0xbc, 0xf2, 0x79, 0xc2, 0xee, 0x9d, 0xd8, 0x73, 0xc2, 0x54, 0x2f, 0x08, 0xd6, 0x7c, 0x7a,
+	0x9c, 0xde, 0x1e, 0x45, 0xdc, 0x8d, 0x84, 0xd1, 0x46, 0xbc, 0xd3, 0x22, 0x9f, 0x41, 0x23, 0x75,
+	0xe2, 0x05, 0x57, 0xa5, 0xab, 0x28, 0xe6, 0xa9, 0x4b, 0x9b, 0xac, 0x5c, 0xbf, 0x84, 0x27, 0x5b,
+	0x4e, 0xf8, 0x5e, 0xe0, 0x21, 0xe3, 0xe6, 0x51, 0xe8, 0x26, 0x59, 0x3d, 0x7c, 0xac, 0x48, 0x31,
+	0x46, 0xa7, 0x25, 0x7d, 0xe4, 0x1b, 0x80, 0x7b, 0x2f, 0xf1, 0xee, 0x3c, 0xdf, 0x4b, 0xd7, 0xe2,
+	0xc0, 0xb5, 0x2e, 0x3e, 0x79, 0xcf, 0xe2, 0x6e, 0x36, 0x20, 0xba, 0xd3, 0x01, 0xc9, 0xed, 0x7a,
+	0x89, 0xe3, 0xfb, 0xd1, 0x3b, 0x76, 0x17, 0xa5, 0xb2, 0x4c, 0x56, 0x69, 0x43, 0x19, 0x2f, 0xa3,
+	0x34, 0x21, 0x13, 0xd0, 0x92, 0x25, 0x9f, 0xa7, 0x4e, 0x1a, 0xc5, 0x6c, 0x19, 0xf9, 0xde, 0x7c,
+	0x9d, 0x1d, 0xc8, 0xee, 0x7b, 0x66, 0xb2, 0x14, 0x74, 0x26, 0x90, 0xb4, 0x9d, 0xec, 0x1b, 0xc8,
+	0x97, 0x70, 0xba, 0xad, 0xd1, 0x6a, 0x8f, 0x35, 0x59, 0xde, 0x54, 0x79, 0x56, 0xdb, 0xfb, 0x1d,
+	0x3c, 0xdb, 0x4e, 0x8d, 0xcc, 0x63, 0x2e, 0xf7, 0x9d, 0xf5, 0xa6, 0x1b, 0x88, 0x6e, 0xff, 0xb7,
+	0xc1, 0x60, 0x39, 0x1d, 0x22, 0x22, 0x1b, 0xa0, 0xfb, 0x5b, 0x68, 0xec, 0xe6, 0x85, 0x34, 0xa0,
+	0x6a, 0xd9, 0xfd, 0xe9, 0xb0, 0x4f, 0x87, 0xda, 0x09, 0x21, 0xd0, 0xb2, 0x46, 0xd3, 0x17, 0x63,
+	0x83, 0x0d, 0x47, 0x16, 0xd2, 0x50, 0xcb, 0x21, 0x62, 0x6a, 0x32, 0x7a, 0x3d, 0x99, 0xdc, 0x6a,
+	0xf9, 0xee, 0xe7, 0x00, 0xdb, 0xd0, 0x11, 0x80, 0xf2, 0xec, 0xfa, 0x72, 0x3c, 0x1a, 0x68, 0x27,
+	0x48, 0xbc, 0x19, 0x1d, 0xdd, 0xf4, 0x6d, 0x43, 0xcb, 0x75, 0x7f, 0x03, 0xed, 0x83, 0x7d, 0x93,
+	0x8f, 0x81, 0x58, 0x33, 0x63, 0x60, 0xf7, 0x6d, 0x93, 0x5a, 0xac, 0x3f, 0x1e, 0x9b, 0xaf, 0x0d,
+	0x9c, 0xf3, 0x14, 0x9a, 0x53, 0x93, 0x6d, 0x5d, 0x5a, 0xae, 0xfb, 0xf7, 0x92, 0xac, 0xff, 0xc6,
+	0x3d, 0x0f, 0x53, 0x2c, 0x5f, 0x59, 0x85, 0xdc, 0xdc, 0xc3, 0x55, 0x69, 0x18, 0xb9, 0xe4, 0x0b,
+	0x28, 0xa6, 0xeb, 0xa5, 0xbc, 0x8d, 0x5b, 0x17, 0x1f, 0xed, 0xc4, 0x5f, 0x74, 0xee, 0xd9, 0xeb,
+	0x25, 0xa7, 0x02, 0xb2, 0x95, 0x02, 0x85, 0x87, 0xa4, 0xc0, 0x63, 0x28, 0xed, 0xf2, 0x51, 0x36,
+	0xc8, 0x53, 0xa8, 0x6e, 0xce, 0x7c, 0x49, 0x9c, 0xf9, 0x4d, 0x1b, 0xaf, 0x45, 0x4c, 0x51, 0x92,
+	0x3a, 0xc1, 0x52, 0xb0, 0xad, 0x40, 0xb7, 0x06, 0xa2, 0x43, 0x25, 0xbb, 0x23, 0x33, 0x1e, 0xa9,
+	0x26, 0x7a, 0x02, 0x9e, 0x24, 0xce, 0x42, 0x96, 0xf2, 0x1a, 0x55, 0xcd, 0xcd, 0x5d, 0x51, 0xdb,
+	0xb9, 0x2b, 0x9e, 0x20, 0xda, 0x77, 0x71, 0xff, 0x32, 0xc1, 0x65, 0x6c, 0x8e, 0x5c, 0x3c, 0xae,
+	0x59, 0x68, 0x84, 0x24, 0xa9, 0x8b, 0xa1, 0x40, 0x9a, 0xa6, 0x28, 0x4c, 0x3e, 0x01, 0x48, 0xb8,
+	0x93, 0xb2, 0x28, 0x76, 0x79, 0xac, 0x37, 0x3a, 0x85, 0xb3, 0x12, 0xad, 0xa1, 0xc5, 0x44, 0x43,
+	0xf7, 0x9f, 0x79, 0x28, 0x62, 0x84, 0xb0, 0xf8, 0x5c, 0x4f, 0x5f, 0x4d, 0xcd, 0xd7, 0x53, 0x66,
+	0xdf, 0xce, 0x0c, 0xed, 0xe4, 0xa0, 0x66, 0xe5, 0x30, 0x4d, 0x58, 0xb3, 0xb0, 0x62, 0x59, 0xb6,
+	0x39, 0x78, 0xa5, 0xe5, 0x55, 0x19, 0x43, 0x93, 0xa2, 0x4b, 0x01, 0xfb, 0x61, 0x19, 0xcb, 0x8a,
+	0x58, 0x11, 0x69, 0xa1, 0x9c, 0x25, 0xac, 0x55, 0x2f, 0xfa, 0x13, 0x83, 0x99, 0x37, 0x06, 0xd5,
+	0xca, 0x38, 0xab, 0x68, 0xaa, 0x82, 0x55, 0xc1, 0x21, 0x2d, 0x83, 0xde, 0x18, 0x94, 0x59, 0x2f,
+	0xaf, 0xed, 0xa1, 0xf9, 0x7a, 0xaa, 0x55, 0x11, 0x26, 0x96, 0x62, 0x8f, 0x26, 0x86, 0x79, 0x6d,
+	0x6b, 0x35, 0x2c, 0x82, 0xa2, 0x56, 0x52, 0xd6, 0x7f, 0xdd, 0xbf, 0xd5, 0x40, 0x2c, 0x45, 0x1a,
+	0xa8, 0x81, 0x58, 0x63, 0xa8, 0xd5, 0x91, 0xcd, 0x99, 0xf1, 0xca, 0xa4, 0x57, 0xc6, 0xc8, 0xd6,
+	0x1a, 0xa4, 0x0a, 0xc5, 0xc1, 0xcb, 0xbe, 0xad, 0x35, 0xc5, 0x86, 0xa4, 0xf7, 0x0f, 0xe6, 0x08,
+	0x3b, 0xb4, 0x36, 0xeb, 0x11, 0x7b, 0x36, 0x86, 0x5a, 0x9b, 0x3c, 0x06, 0x4d, 0xec, 0x96, 0x51,
+	0xc3, 0x7a, 0x79, 0x7d, 0x75, 0x35, 0x36, 0x86, 0x9a, 0x86, 0x38, 0x71, 0x1e, 0xd8, 0xa0, 0x3f,
+	0x46, 0xcb, 0xe9, 0xce, 0x82, 0xc6, 0xc6, 0x95, 0xad, 0x91, 0xee, 0xdf, 0x0a, 0x00, 0xb2, 0x66,
+	0xde, 0x78, 0xfc, 0x1d, 0x39, 0x97, 0x82, 0x36, 0xbb, 0xbd, 0x73, 0xe2, 0xda, 0xd1, 0x0e, 0x6f,
+	0x5a, 0x5a, 0x5b, 0xa8, 0xdf, 0x7d, 0xd2, 0xe7, 0x0f, 0x48, 0xaf, 0xe4, 0x56, 0xe1, 0x01, 0xb9,
+	0xf5, 0x73, 0x68, 0x05, 0xab, 0x44, 0x0a, 0x0b, 0xa1, 0x9b, 0x04, 0xa1, 0x0f, 0xee, 0x46, 0x44,
+	0xe0, 0x0a, 0xb1, 0x45, 0x9e, 0x01, 0x78, 0x09, 0x0b, 0xd6, 0x52, 0x84, 0x94, 0xa4, 0x46, 0xf0,
+	0x92, 0xc9, 0xfa, 0x3d, 0xda, 0xa3, 0xfc, 0x43, 0xb4, 0xc7, 0x37, 0xd0, 0xf4, 0xf9, 0xc2, 0xf1,
+	0x99, 0x33, 0x97, 0x72, 0xa0, 0xd2, 0x29, 0x9c, 0xb5, 0x2e, 0xf4, 0x3d, 0xb9, 0x84, 0x21, 0xea,
+	0xf5, 0x05, 0x80, 0x36, 0x04, 0x5c, 0x36, 0x92, 0xee, 0x77, 0x50, 0x96, 0xbf, 0x98, 0x4c, 0xc5,
+	0xd0, 0xfe, 0xc0, 0x1e, 0x99, 0x53, 0x59, 0x3a, 0xf6, 0x39, 0x99, 0x7b, 0x1f, 0x27, 0xf3, 0x07,
+	0x9c, 0x2c, 0xec, 0x72, 0xb2, 0x88, 0x4e, 0x4c, 0x63, 0x56, 0xe1, 0x4a, 0xdd, 0xbf, 0x94, 0xa1,
+	0x21, 0x36, 0x15, 0x3a, 0xcb, 0xe4, 0x4d, 0x94, 0x62, 0xdd, 0x50, 0x92, 0xf3, 0xa8, 0x6e, 0x24,
+	0xff, 0x89, 0xe8, 0xfc, 0xc5, 0x56, 0x3c, 0xca, 0x0c, 0xee, 0xc5, 0x32, 0x9b, 0x36, 0x0b, 0xcd,
+	0xb1, 0x88, 0x2c, 0xee, 0x14, 0x86, 0xcf, 0xa1, 0xb5, 0x2f, 0x22, 0xb3, 0xbb, 0xb1, 0xb9, 0xa7,
+	0x1f, 0xff, 0x9b, 0xfc, 0x1d, 0x53, 0xa9, 0xf2, 0x01, 0x2a, 0xed, 0xa9, 0xcd, 0xea, 0x81, 0xda,
+	0xfc, 0x12, 0x2a, 0x6f, 0xbc, 0x24, 0x8d, 0xe2, 0xb5, 0x5e, 0xeb, 0x14, 0x0e, 0x0e, 0x81, 0xa8,
+	0xd8, 0x54, 0x01, 0x76, 0x35, 0x24, 0x7c, 0x58, 0x43, 0x1e, 0xc9, 0xc1, 0xfa, 0x0f, 0x97, 0x83,
+	0x8d, 0x0f, 0xc9, 0xc1, 0xa7, 0x7f, 0xce, 0x43, 0x39, 0x0b, 0xaa, 0x7a, 0x07, 0xe6, 0x76, 0xde,
+	0x81, 0xea, 0x60, 0xe6, 0x1f, 0x38, 0x98, 0xff, 0xb3, 0x77, 0x62, 0x07, 0x1a, 0x5b, 0xcd, 0x10,
+	0x24, 0xd9, 0xbd, 0x04, 0x4a, 0x2e, 0xec, 0x3c, 0xe2, 0xca, 0x0f, 0x3d, 0xe2, 0x2a, 0x87, 0x8f,
+	0xb8, 0xc3, 0x47, 0x5a, 0xf5, 0xdf, 0x3e, 0xd2, 0xee, 0xca, 0xe2, 0xa1, 0xfd, 0xfc, 0x5f, 0x01,
+	0x00, 0x00, 0xff, 0xff, 0x9c, 0xa2, 0xe3, 0xc3, 0xaa, 0x0f, 0x00, 0x00,
 }
@@ -0,0 +1,157 @@
+package rummy
+
+import (
+	"testing"
+
+	"github.com/timpalpant/rummy/scoring"
+)
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	g := NewGame()
+	fast := make(chan *GameEvent, 10)
+	slow := make(chan *GameEvent, 1)
+	g.Subscribe(fast)
+	g.Subscribe(slow)
+
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := g.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(fast) != 3 {
+		t.Errorf("fast subscriber received %v events, expected 3", len(fast))
+	}
+	if e := <-slow; e.Sequence != 1 {
+		t.Errorf("slow subscriber received event %v, expected 1", e.Sequence)
+	}
+	if _, ok := <-slow; ok {
+		t.Error("slow subscriber was not closed")
+	}
+
+	// The dropped subscriber picks up where it left off.
+	resumed := make(chan *GameEvent, 10)
+	history := g.SubscribeFrom(resumed, 2)
+	if len(history) != 2 || history[0].Sequence != 2 {
+		t.Errorf("resumed with %v events, expected 2 starting at 2", len(history))
+	}
+	g.Unsubscribe(slow) // Has no effect.
+}
+
+func handValue(g *Game, playerId int32) int {
+	total := 0
+	for card := range g.players[playerId].hand {
+		total += scoring.Value(card)
+	}
+	return total
+}
+
+func TestForfeit(t *testing.T) {
+	g := NewGame()
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := g.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Deal(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The current player leaves, and the turn passes to the next player.
+	first := g.currentPlayer
+	second := (first + 1) % 3
+	third := (first + 2) % 3
+	if err := g.Forfeit(first); err != nil {
+		t.Fatal(err)
+	}
+	if g.currentPlayer != second || g.currentPlayerTurnState != GameState_TURN_START {
+		t.Errorf("after the current player left, player %v is in state %v, expected player %v to start",
+			g.currentPlayer, g.currentPlayerTurnState, second)
+	}
+	if err := g.Forfeit(first); err == nil {
+		t.Error("player left the game twice")
+	}
+
+	// The player who left is skipped in the turn order.
+	playStockTurn(t, g)
+	if g.currentPlayer != third {
+		t.Errorf("turn passed to player %v, expected %v", g.currentPlayer, third)
+	}
+	playStockTurn(t, g)
+	if g.currentPlayer != second {
+		t.Errorf("turn passed to player %v, expected %v", g.currentPlayer, second)
+	}
+
+	// Once one player remains, the game is over.
+	if err := g.Forfeit(third); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status() != GameState_COMPLETED {
+		t.Fatalf("game is %v after all but one player left, expected it to be completed", g.Status())
+	}
+	e := g.history[len(g.history)-1]
+	if e.Type != GameEvent_GAME_OVER || !e.Forfeit || e.PlayerId != second {
+		t.Errorf("last event %v, expected player %v to win by forfeit", e, second)
+	}
+	if err := g.Forfeit(second); err == nil {
+		t.Error("player left a game that is over")
+	}
+
+	// The hands of players who left count against their score.
+	gs := g.GameState()
+	for _, id := range []int32{first, third} {
+		if !gs.Players[id].Forfeited {
+			t.Errorf("player %v is not shown as having left", id)
+		}
+		if score := gs.Players[id].CurrentScore; score != int32(-handValue(g, id)) {
+			t.Errorf("player %v scored %v, expected %v", id, score, -handValue(g, id))
+		}
+	}
+}
+
+func TestForfeitBeforeDeal(t *testing.T) {
+	g, err := NewGameWithOptions(&GameOptions{MaxPlayers: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := g.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Forfeit(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The seat and the name are free for another player.
+	if id, err := g.AddPlayer("b"); err != nil || id != 1 {
+		t.Errorf("rejoining returned seat %v, %v, expected seat 1", id, err)
+	}
+	if _, err := g.AddPlayer("d"); err == nil {
+		t.Error("joined a full game")
+	}
+
+	// Seats that are still empty once the game starts are skipped.
+	if err := g.Forfeit(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.players[0].hand) != 0 {
+		t.Errorf("empty seat was dealt %v", g.players[0].hand)
+	}
+	for i := 0; i < 4; i++ {
+		if g.currentPlayer == 0 {
+			t.Fatal("empty seat was given a turn")
+		}
+		playStockTurn(t, g)
+	}
+	gs := g.GameState()
+	if p := gs.Players[0]; p.Name != "" || !p.Forfeited {
+		t.Errorf("empty seat is shown as %v", p)
+	}
+	if n := g.NumPlayers(); n != 2 {
+		t.Errorf("game has %v players, expected 2", n)
+	}
+}
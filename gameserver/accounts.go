@@ -0,0 +1,277 @@
+package gameserver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/scoring"
+)
+
+// Ratings are Elo-style: each game is scored as a set of head-to-head
+// results between every pair of players, and the change in rating is
+// averaged over the player's opponents.
+const (
+	initialRating = 1500
+	ratingK       = 32
+)
+
+// account is a player's identity, which persists across games.
+type account struct {
+	token  string
+	rating float64
+	// Finished games, in the order that they were played.
+	history []*rummy.PlayerStats_Game
+}
+
+// stats summarizes the account's finished games.
+func (a *account) stats(name string, withHistory bool) *rummy.PlayerStats {
+	stats := &rummy.PlayerStats{
+		AccountName: name,
+		Rating:      a.rating,
+		GamesPlayed: int32(len(a.history)),
+	}
+
+	var points, deadwood int
+	for _, g := range a.history {
+		if g.Won {
+			stats.Wins++
+		}
+		points += int(g.Score)
+		deadwood += int(g.Deadwood)
+	}
+	if n := float64(len(a.history)); n > 0 {
+		stats.WinRate = float64(stats.Wins) / n
+		stats.AveragePoints = float64(points) / n
+		stats.AverageDeadwood = float64(deadwood) / n
+	}
+
+	if withHistory {
+		for i := len(a.history) - 1; i >= 0; i-- {
+			stats.History = append(stats.History, a.history[i])
+		}
+	}
+	return stats
+}
+
+func (s *RummyServer) CreateAccount(ctx context.Context, req *rummy.CreateAccountRequest) (*rummy.CreateAccountResponse, error) {
+	glog.V(1).Infof("CreateAccount: %v", req)
+	name := strings.TrimSpace(req.AccountName)
+	if name == "" {
+		return nil, fmt.Errorf("account name must not be empty")
+	}
+
+	token, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	if _, ok := s.accounts[name]; ok {
+		return nil, fmt.Errorf("account %v already exists", name)
+	}
+
+	glog.Infof("Created account %v", name)
+	s.accounts[name] = &account{
+		token:  token,
+		rating: initialRating,
+	}
+	return &rummy.CreateAccountResponse{
+		AccountToken: token,
+	}, nil
+}
+
+func (s *RummyServer) GetLeaderboard(ctx context.Context, req *rummy.GetLeaderboardRequest) (*rummy.GetLeaderboardResponse, error) {
+	glog.V(1).Infof("GetLeaderboard: %v", req)
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	resp := &rummy.GetLeaderboardResponse{}
+	for name, a := range s.accounts {
+		if len(a.history) > 0 {
+			resp.Players = append(resp.Players, a.stats(name, false))
+		}
+	}
+
+	sort.Slice(resp.Players, func(i, j int) bool {
+		a, b := resp.Players[i], resp.Players[j]
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.AccountName < b.AccountName
+	})
+	if req.Limit > 0 && int(req.Limit) < len(resp.Players) {
+		resp.Players = resp.Players[:req.Limit]
+	}
+	return resp, nil
+}
+
+func (s *RummyServer) GetPlayerStats(ctx context.Context, req *rummy.GetPlayerStatsRequest) (*rummy.PlayerStats, error) {
+	glog.V(1).Infof("GetPlayerStats: %v", req)
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	a, ok := s.accounts[req.AccountName]
+	if !ok {
+		return nil, fmt.Errorf("no such account: %v", req.AccountName)
+	}
+
+	return a.stats(req.AccountName, true), nil
+}
+
+// authenticateAccount verifies that token is the one returned
+// when the account was created.
+func (s *RummyServer) authenticateAccount(name, token string) error {
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	a, ok := s.accounts[name]
+	if !ok {
+		return fmt.Errorf("no such account: %v", name)
+	} else if !secretsEqual(a.token, token) {
+		return fmt.Errorf("invalid token for account %v", name)
+	}
+	return nil
+}
+
+// watchGame subscribes to a game, and once the game is over records
+// its result.
+// Must be called while holding sg.mu.
+func (s *RummyServer) watchGame(name string, sg *serverGame) {
+	if sg.watched {
+		return
+	}
+
+	sg.watched = true
+	events := make(chan *rummy.GameEvent, eventsBufferSize)
+	sg.game.Subscribe(events)
+	go func() {
+		for {
+			for range events {
+			}
+
+			// The stream is closed once the game is over, but also if
+			// it expires, the server shuts down, or we fell behind.
+			sg.mu.Lock()
+			status := sg.game.Status()
+			s.gamesMu.Lock()
+			shuttingDown := s.shuttingDown
+			s.gamesMu.Unlock()
+			if shuttingDown || (status != rummy.GameState_LOBBY &&
+				status != rummy.GameState_IN_PROGRESS) {
+				s.recordCompletedGame(name, sg)
+				sg.mu.Unlock()
+				return
+			}
+
+			events = make(chan *rummy.GameEvent, eventsBufferSize)
+			sg.game.Subscribe(events)
+			sg.mu.Unlock()
+		}
+	}()
+}
+
+// recordCompletedGame counts a game that is over as completed and
+// records its result for the players who joined as accounts, unless
+// it has already been recorded.
+// Must be called while holding sg.mu.
+func (s *RummyServer) recordCompletedGame(name string, sg *serverGame) {
+	if sg.recorded || sg.game.Status() != rummy.GameState_COMPLETED {
+		return
+	}
+
+	sg.recorded = true
+	s.metrics.gamesCompleted.Inc()
+	if len(sg.accounts) > 0 {
+		s.recordGame(name, sg.game.GameState(), sg.accounts, time.Now())
+	}
+}
+
+// recordGame adds the final state of a game to the history of each
+// account that played in it, and updates their ratings. Players who
+// did not join as an account, including computer players, count
+// as opponents with the initial rating.
+func (s *RummyServer) recordGame(name string, gs *rummy.GameState, accounts map[int32]string, now time.Time) {
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	players := gs.Players
+	ratings := make([]float64, len(players))
+	for i := range players {
+		ratings[i] = initialRating
+		if a, ok := s.accounts[accounts[int32(i)]]; ok {
+			ratings[i] = a.rating
+		}
+	}
+
+	winningScore := int32(math.MinInt32)
+	for _, p := range players {
+		if !p.Forfeited && p.CurrentScore > winningScore {
+			winningScore = p.CurrentScore
+		}
+	}
+
+	for id, accountName := range accounts {
+		a, ok := s.accounts[accountName]
+		if !ok {
+			continue
+		}
+
+		p := players[id]
+		result := &rummy.PlayerStats_Game{
+			GameName:     name,
+			FinishedAtMs: now.UnixNano() / int64(time.Millisecond),
+			PlayerId:     id,
+			Score:        p.CurrentScore,
+			Won:          !p.Forfeited && p.CurrentScore == winningScore,
+			Forfeited:    p.Forfeited,
+		}
+		for _, card := range p.Hand {
+			result.Deadwood += int32(scoring.Value(*card))
+		}
+
+		var change float64
+		for j, opponent := range players {
+			if int32(j) == id {
+				continue
+			}
+			result.Opponents = append(result.Opponents, opponent.Name)
+			expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[id])/400))
+			change += outcome(p, opponent) - expected
+		}
+		if len(players) > 1 {
+			change *= ratingK / float64(len(players)-1)
+		}
+
+		result.RatingChange = change
+		a.rating += change
+		a.history = append(a.history, result)
+		glog.Infof("Recorded game %v for account %v: score %v, rating %.1f (%+.1f)",
+			name, accountName, p.CurrentScore, a.rating, change)
+	}
+}
+
+// outcome returns the result of a head-to-head comparison between
+// two players' final states: 1 for a win, 0.5 for a draw and 0 for a
+// loss. Players who left the game lose to everyone who did not.
+func outcome(p, opponent *rummy.PlayerState) float64 {
+	switch {
+	case p.Forfeited != opponent.Forfeited:
+		if opponent.Forfeited {
+			return 1
+		}
+		return 0
+	case p.CurrentScore > opponent.CurrentScore:
+		return 1
+	case p.CurrentScore < opponent.CurrentScore:
+		return 0
+	}
+	return 0.5
+}
@@ -0,0 +1,119 @@
+package gameserver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *rummy.PlayerState
+		opponent *rummy.PlayerState
+		expected float64
+	}{
+		{"higher score", &rummy.PlayerState{CurrentScore: 50}, &rummy.PlayerState{CurrentScore: 20}, 1},
+		{"lower score", &rummy.PlayerState{CurrentScore: -10}, &rummy.PlayerState{CurrentScore: 20}, 0},
+		{"draw", &rummy.PlayerState{CurrentScore: 20}, &rummy.PlayerState{CurrentScore: 20}, 0.5},
+		{"opponent left", &rummy.PlayerState{CurrentScore: -10},
+			&rummy.PlayerState{CurrentScore: 20, Forfeited: true}, 1},
+		{"left", &rummy.PlayerState{CurrentScore: 50, Forfeited: true},
+			&rummy.PlayerState{CurrentScore: 20}, 0},
+		{"both left", &rummy.PlayerState{CurrentScore: 50, Forfeited: true},
+			&rummy.PlayerState{CurrentScore: 20, Forfeited: true}, 1},
+	}
+
+	for _, tc := range tests {
+		if result := outcome(tc.p, tc.opponent); result != tc.expected {
+			t.Errorf("%v: outcome = %v, expected %v", tc.name, result, tc.expected)
+		}
+		// The opponent's outcome is the complement.
+		if result := outcome(tc.opponent, tc.p); result != 1-tc.expected {
+			t.Errorf("%v: opponent's outcome = %v, expected %v", tc.name, result, 1-tc.expected)
+		}
+	}
+}
+
+func TestGameFinishedDuringShutdownIsRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions
+	opts.Store = store
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+
+	account, err := client.CreateAccount(ctx, &rummy.CreateAccountRequest{AccountName: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+		GameName:     "game",
+		PlayerName:   "P0",
+		AccountName:  "alice",
+		AccountToken: account.AccountToken,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	opponent, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: "P1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The game ends while in-flight requests drain.
+	s.Shutdown()
+	if _, err := client.LeaveGame(ctx, &rummy.LeaveGameRequest{
+		GameName:     "game",
+		PlayerId:     opponent.PlayerId,
+		PlayerSecret: opponent.PlayerSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkStats := func(step string, s *RummyServer) {
+		stats, err := s.GetPlayerStats(ctx, &rummy.GetPlayerStatsRequest{AccountName: "alice"})
+		if err != nil {
+			t.Fatalf("%v: %v", step, err)
+		}
+		if stats.GamesPlayed != 1 || stats.Wins != 1 {
+			t.Errorf("%v: %v games played and %v won, expected 1 won",
+				step, stats.GamesPlayed, stats.Wins)
+		}
+	}
+	checkStats("after shutdown", s)
+
+	// The restored game is not recorded again.
+	restarted := NewRummyServer(opts)
+	defer restarted.Stop()
+	if err := restarted.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	checkStats("after restart", restarted)
+}
@@ -0,0 +1,89 @@
+package gameserver
+
+import (
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of recent actions remembered for each player. Clients only
+// retry their most recent few requests, so this need not be large.
+// Recent actions are not saved with the game, so a request retried
+// after the server restarts is applied again.
+const recentActionsPerPlayer = 16
+
+// actionResult is the outcome of a successful action with a request id,
+// which is returned again if the request is retried.
+type actionResult struct {
+	requestId string
+	method    string
+	resp      proto.Message
+}
+
+// actionPreconditions are the optional fields common to
+// all action requests.
+type actionPreconditions struct {
+	requestId        string
+	expectedSequence int64
+	checkTurn        bool
+	expectedTurn     int32
+}
+
+// act takes an action for the given player, who has already been
+// authenticated. If the request has already succeeded, the result
+// of that attempt is returned without acting again. Otherwise the
+// action is aborted if the game is not in the state the client expects.
+// Failed requests are not remembered, since they did not change the
+// game, so that they may be retried once the game is as expected.
+// Must be called while holding sg.mu.
+func (sg *serverGame) act(playerId int32, method string, pre actionPreconditions, action func() (proto.Message, error)) (proto.Message, error) {
+	if pre.requestId != "" {
+		for _, r := range sg.recentActions[playerId] {
+			if r.requestId != pre.requestId {
+				continue
+			} else if r.method != method {
+				return nil, status.Errorf(codes.InvalidArgument,
+					"request id %v was already used for %v", pre.requestId, r.method)
+			}
+			glog.V(1).Infof("Replaying result of %v request %v for player %v",
+				method, pre.requestId, playerId)
+			return r.resp, nil
+		}
+	}
+
+	var resp proto.Message
+	err := sg.checkPreconditions(pre)
+	if err == nil {
+		resp, err = action()
+	}
+
+	if pre.requestId != "" && err == nil {
+		recent := append(sg.recentActions[playerId], &actionResult{
+			requestId: pre.requestId,
+			method:    method,
+			resp:      resp,
+		})
+		if len(recent) > recentActionsPerPlayer {
+			recent = recent[1:]
+		}
+		sg.recentActions[playerId] = recent
+	}
+	return resp, err
+}
+
+// checkPreconditions returns an Aborted error if the game is not in the
+// state that the client expected when it made the request.
+// Must be called while holding sg.mu.
+func (sg *serverGame) checkPreconditions(pre actionPreconditions) error {
+	g := sg.game
+	if pre.expectedSequence != 0 && g.LastSequence() != pre.expectedSequence {
+		return status.Errorf(codes.Aborted,
+			"game is at sequence %v, not the expected %v", g.LastSequence(), pre.expectedSequence)
+	}
+	if pre.checkTurn && int32(g.Turn()) != pre.expectedTurn {
+		return status.Errorf(codes.Aborted,
+			"game is at turn %v, not the expected %v", g.Turn(), pre.expectedTurn)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package gameserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestActDeduplicatesRequests(t *testing.T) {
+	sg := newServerGame(rummy.NewGame())
+	for _, name := range []string{"P0", "P1"} {
+		if _, err := sg.game.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sg.game.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	seq := sg.game.LastSequence()
+
+	// Each step is taken in order, against the same game.
+	tests := []struct {
+		name     string
+		playerId int32
+		method   string
+		pre      actionPreconditions
+		acted    bool
+		code     codes.Code
+	}{
+		{"first attempt", 0, "PickUpStock", actionPreconditions{requestId: "a"}, true, codes.OK},
+		{"retry", 0, "PickUpStock", actionPreconditions{requestId: "a"}, false, codes.OK},
+		{"reused for another method", 0, "DiscardCard", actionPreconditions{requestId: "a"}, false, codes.InvalidArgument},
+		{"another player", 1, "PickUpStock", actionPreconditions{requestId: "a"}, true, codes.OK},
+		{"no request id", 0, "PickUpStock", actionPreconditions{}, true, codes.OK},
+		{"no request id again", 0, "PickUpStock", actionPreconditions{}, true, codes.OK},
+		{"wrong sequence", 0, "PickUpStock", actionPreconditions{requestId: "b", expectedSequence: seq + 1}, false, codes.Aborted},
+		// The rejection is not remembered, so the retry succeeds.
+		{"retry of rejected request", 0, "PickUpStock", actionPreconditions{requestId: "b", expectedSequence: seq}, true, codes.OK},
+		{"retry of successful retry", 0, "PickUpStock", actionPreconditions{requestId: "b", expectedSequence: seq}, false, codes.OK},
+		{"expected sequence", 0, "PickUpStock", actionPreconditions{requestId: "c", expectedSequence: seq}, true, codes.OK},
+		{"wrong turn", 0, "PickUpStock", actionPreconditions{requestId: "d", checkTurn: true, expectedTurn: 1}, false, codes.Aborted},
+		{"expected turn", 0, "PickUpStock", actionPreconditions{requestId: "e", checkTurn: true}, true, codes.OK},
+	}
+
+	actions := 0
+	for _, tc := range tests {
+		before := actions
+		resp, err := sg.act(tc.playerId, tc.method, tc.pre, func() (proto.Message, error) {
+			actions++
+			return &rummy.PickUpStockResponse{}, nil
+		})
+		if acted := actions > before; acted != tc.acted {
+			t.Errorf("%v: acted = %v, expected %v", tc.name, acted, tc.acted)
+		}
+		if code := status.Code(err); code != tc.code {
+			t.Errorf("%v: returned %v, expected %v", tc.name, err, tc.code)
+		} else if err == nil && resp == nil {
+			t.Errorf("%v: returned no response", tc.name)
+		}
+	}
+}
+
+func TestActForgetsOldRequests(t *testing.T) {
+	sg := newServerGame(rummy.NewGame())
+	actions := 0
+	act := func(requestId string) {
+		sg.act(0, "PickUpStock", actionPreconditions{requestId: requestId}, func() (proto.Message, error) {
+			actions++
+			return &rummy.PickUpStockResponse{}, nil
+		})
+	}
+
+	for i := 0; i <= recentActionsPerPlayer; i++ {
+		act(fmt.Sprintf("request-%d", i))
+	}
+	// The most recent requests are remembered, but not the first.
+	act(fmt.Sprintf("request-%d", recentActionsPerPlayer))
+	if actions != recentActionsPerPlayer+1 {
+		t.Errorf("took %v actions, expected %v", actions, recentActionsPerPlayer+1)
+	}
+	act("request-0")
+	if actions != recentActionsPerPlayer+2 {
+		t.Errorf("took %v actions, expected %v", actions, recentActionsPerPlayer+2)
+	}
+}
+
+func TestActRetriesFailedRequests(t *testing.T) {
+	sg := newServerGame(rummy.NewGame())
+	fail := true
+	actions := 0
+	act := func() error {
+		_, err := sg.act(0, "DiscardCard", actionPreconditions{requestId: "a"}, func() (proto.Message, error) {
+			actions++
+			if fail {
+				return nil, fmt.Errorf("not your turn")
+			}
+			return &rummy.DiscardCardResponse{}, nil
+		})
+		return err
+	}
+
+	if err := act(); err == nil {
+		t.Fatal("failed action returned no error")
+	}
+	fail = false
+	if err := act(); err != nil || actions != 2 {
+		t.Errorf("retry of failed action returned %v after %v actions, expected it to act again", err, actions)
+	}
+	if err := act(); err != nil || actions != 2 {
+		t.Errorf("retry of successful action returned %v after %v actions, expected it to be replayed", err, actions)
+	}
+}
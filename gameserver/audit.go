@@ -0,0 +1,399 @@
+package gameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuditEntry records a request made in a game and its outcome.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Game   string    `json:"game"`
+	Method string    `json:"method"`
+	// Who made the request: a "player", one of the server's computer
+	// players ("bot"), the "host" of the game, a "spectator", or the
+	// "server" itself.
+	Role string `json:"role"`
+	// The player who made the request, if it was made by a player.
+	PlayerId   *int32 `json:"player_id,omitempty"`
+	PlayerName string `json:"player_name,omitempty"`
+	// The strategy of the computer player that made the request.
+	Strategy string `json:"strategy,omitempty"`
+	// The address the request came from, preceded by the addresses
+	// of any clients that it was forwarded for.
+	Peer string `json:"peer,omitempty"`
+	// The request as JSON, with secrets and invite codes removed.
+	Request json.RawMessage `json:"request,omitempty"`
+	// The status code of the response, and the error
+	// if the request was rejected.
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+	// The sequence number of the game's last event once
+	// the request was handled.
+	Sequence int64 `json:"sequence"`
+}
+
+// AuditLog records the requests made in each game, including those
+// that are rejected, so that disputes about what happened can be settled.
+// Record and CloseGame are called while holding the game's lock,
+// so they should not block on I/O.
+type AuditLog interface {
+	// Record appends an entry to the log of its game.
+	Record(entry *AuditEntry) error
+	// CloseGame releases any resources held for the named game.
+	// The game's log may still be appended to afterwards.
+	CloseGame(game string) error
+	// Close releases all resources held by the log.
+	Close() error
+}
+
+// Fields of requests that are removed before they are recorded.
+var redactedFields = []string{
+	"player_secret",
+	"host_secret",
+	"invite_code",
+	"spectator_token",
+	"account_token",
+}
+
+const noPlayer = -1
+
+// auditCaller identifies who made a request.
+type auditCaller struct {
+	role string
+	// The player who made the request, or noPlayer.
+	playerId int32
+}
+
+func playerCaller(playerId int32) auditCaller {
+	return auditCaller{role: "player", playerId: playerId}
+}
+
+var (
+	hostCaller      = auditCaller{role: "host", playerId: noPlayer}
+	spectatorCaller = auditCaller{role: "spectator", playerId: noPlayer}
+)
+
+// audit records a request made in the named game and its outcome, *errp,
+// in the audit log, if one is configured. Requests made by the server's
+// computer players are attributed to them rather than to the player
+// whose seat they are playing.
+// Must be called while holding sg.mu, or before the game has been
+// made visible to other requests.
+func (s *RummyServer) audit(ctx context.Context, game string, sg *serverGame, method string, req proto.Message, caller auditCaller, errp *error) {
+	if s.opts.AuditLog == nil {
+		return
+	}
+
+	entry := &AuditEntry{
+		Time:     time.Now().UTC(),
+		Game:     game,
+		Method:   method,
+		Role:     caller.role,
+		Code:     status.Code(*errp).String(),
+		Sequence: sg.game.LastSequence(),
+	}
+	if *errp != nil {
+		entry.Error = (*errp).Error()
+	}
+	if caller.playerId != noPlayer {
+		id := caller.playerId
+		entry.PlayerId = &id
+		entry.PlayerName, _ = sg.game.PlayerName(id)
+	}
+	if isBotRequest(ctx) {
+		entry.Role = "bot"
+		entry.Strategy = sg.strategies[caller.playerId]
+	} else {
+		entry.Peer = peerAddr(ctx)
+	}
+	if req != nil {
+		var err error
+		entry.Request, err = redactedJSON(req)
+		if err != nil {
+			glog.Errorf("Error encoding %v request for audit log: %v", method, err)
+		}
+	}
+
+	if err := s.opts.AuditLog.Record(entry); err != nil {
+		glog.Errorf("Error writing audit log for game %v: %v", game, err)
+	}
+}
+
+// peerAddr returns the address of the client that made the request,
+// preceded by any addresses that the JSON proxy forwarded it for.
+func peerAddr(ctx context.Context) string {
+	var addrs []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		addrs = append(addrs, md.Get("x-forwarded-for")...)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addrs = append(addrs, p.Addr.String())
+	}
+	return strings.Join(addrs, ", ")
+}
+
+// redactedJSON encodes req as JSON, in the same form as the
+// JSON proxy, without the fields that hold secrets.
+func redactedJSON(req proto.Message) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	m := jsonpb.Marshaler{OrigName: true}
+	if err := m.Marshal(&buf, req); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range redactedFields {
+		delete(fields, name)
+	}
+	return json.Marshal(fields)
+}
+
+// FileAuditLog is an AuditLog that appends entries, one JSON object
+// per line, to a file for each game in a directory. Once a game's
+// file reaches the maximum size it is rotated: the current file is
+// renamed with the suffix .1, the previous .1 file to .2, and so on,
+// and the oldest files beyond the maximum number are removed.
+//
+// Entries are queued in memory and written to their files in the
+// background, in the order they were recorded, so that requests are
+// never blocked on the disk. Errors writing them are logged.
+type FileAuditLog struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	// mu protects queue and closed.
+	mu sync.Mutex
+	// Operations that have not yet been taken by the writer, in order.
+	queue  []auditOp
+	closed bool
+	// Signalled when operations are added to the queue.
+	wake chan struct{}
+	// Closed once the writer has finished, after which closeErr is set.
+	done     chan struct{}
+	closeErr error
+
+	// Open log files, by game name.
+	// Only accessed by the writer.
+	files map[string]*auditFile
+}
+
+// auditOp is an operation on a game's log file,
+// to be carried out by the writer.
+type auditOp struct {
+	game string
+	// The line to append, or nil to close the game's file.
+	line []byte
+}
+
+type auditFile struct {
+	f    *os.File
+	size int64
+}
+
+// NewFileAuditLog creates a FileAuditLog in the given directory,
+// creating the directory if it does not exist. Each game's file is
+// rotated once it exceeds maxSize bytes, and up to maxFiles rotated
+// files are kept for each game. If maxSize is zero, files are
+// never rotated.
+func NewFileAuditLog(dir string, maxSize int64, maxFiles int) (*FileAuditLog, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	l := &FileAuditLog{
+		dir:      dir,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		files:    make(map[string]*auditFile),
+	}
+	go l.run()
+	return l, nil
+}
+
+// Path returns the path of the current log file of the named game.
+// Game names are escaped so that each game has its own file
+// within the log's directory.
+func (l *FileAuditLog) Path(game string) string {
+	return filepath.Join(l.dir, url.PathEscape(game)+".jsonl")
+}
+
+func (l *FileAuditLog) Record(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return l.enqueue(auditOp{entry.Game, append(line, '\n')})
+}
+
+func (l *FileAuditLog) CloseGame(game string) error {
+	return l.enqueue(auditOp{game: game})
+}
+
+// enqueue adds an operation to the writer's queue.
+func (l *FileAuditLog) enqueue(op auditOp) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return fmt.Errorf("audit log is closed")
+	}
+	l.queue = append(l.queue, op)
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close writes all queued entries, and then closes all files.
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+
+	<-l.done
+	return l.closeErr
+}
+
+// run carries out queued operations until the log is closed.
+func (l *FileAuditLog) run() {
+	defer close(l.done)
+	for {
+		l.mu.Lock()
+		ops, closed := l.queue, l.closed
+		l.queue = nil
+		l.mu.Unlock()
+
+		for _, op := range ops {
+			var err error
+			if op.line == nil {
+				err = l.closeFile(op.game)
+			} else {
+				err = l.write(op.game, op.line)
+			}
+			if err != nil {
+				glog.Errorf("Error writing audit log for game %v: %v", op.game, err)
+			}
+		}
+
+		if closed {
+			l.closeErr = l.closeAll()
+			return
+		}
+		<-l.wake
+	}
+}
+
+// write appends a line to the named game's log file,
+// rotating it first if it would exceed the maximum size.
+func (l *FileAuditLog) write(game string, line []byte) error {
+	af, err := l.open(game)
+	if err != nil {
+		return err
+	}
+	if l.maxSize > 0 && af.size > 0 && af.size+int64(len(line)) > l.maxSize {
+		if af, err = l.rotate(game); err != nil {
+			return err
+		}
+	}
+
+	n, err := af.f.Write(line)
+	af.size += int64(n)
+	return err
+}
+
+// open returns the current log file of the named game,
+// opening it if necessary.
+func (l *FileAuditLog) open(game string) (*auditFile, error) {
+	if af, ok := l.files[game]; ok {
+		return af, nil
+	}
+
+	f, err := os.OpenFile(l.Path(game), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	af := &auditFile{f: f, size: info.Size()}
+	l.files[game] = af
+	return af, nil
+}
+
+// rotate moves the named game's current log file aside,
+// and opens a new one.
+func (l *FileAuditLog) rotate(game string) (*auditFile, error) {
+	if err := l.closeFile(game); err != nil {
+		return nil, err
+	}
+
+	path := l.Path(game)
+	if l.maxFiles <= 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+		return l.open(game)
+	}
+
+	for i := l.maxFiles - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%v.%d", path, i), fmt.Sprintf("%v.%d", path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	if err := os.Rename(path, path+".1"); err != nil {
+		return nil, err
+	}
+	return l.open(game)
+}
+
+// closeFile closes the named game's log file, if it is open.
+func (l *FileAuditLog) closeFile(game string) error {
+	af, ok := l.files[game]
+	if !ok {
+		return nil
+	}
+	delete(l.files, game)
+	return af.f.Close()
+}
+
+// closeAll closes all open log files, returning the first error.
+func (l *FileAuditLog) closeAll() error {
+	var firstErr error
+	for game := range l.files {
+		if err := l.closeFile(game); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
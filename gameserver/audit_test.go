@@ -0,0 +1,126 @@
+package gameserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestRedactedJSON(t *testing.T) {
+	tests := []struct {
+		req      proto.Message
+		expected string
+	}{
+		{
+			&rummy.JoinGameRequest{
+				GameName:     "game",
+				PlayerName:   "P0",
+				PlayerSecret: "secret",
+				InviteCode:   "invite",
+				AccountName:  "account",
+				AccountToken: "token",
+			},
+			`{"account_name":"account","game_name":"game","player_name":"P0"}`,
+		},
+		{
+			&rummy.UpdateInviteCodeRequest{GameName: "game", HostSecret: "secret", InviteCode: "invite"},
+			`{"game_name":"game"}`,
+		},
+		{
+			&rummy.GetGameStateRequest{GameName: "game", SpectatorToken: "token"},
+			`{"game_name":"game"}`,
+		},
+		{
+			&rummy.ListGamesRequest{},
+			`{}`,
+		},
+	}
+
+	for _, tc := range tests {
+		result, err := redactedJSON(tc.req)
+		if err != nil {
+			t.Errorf("redactedJSON(%v) returned error: %v", tc.req, err)
+		} else if string(result) != tc.expected {
+			t.Errorf("redactedJSON(%v) = %s, expected %s", tc.req, result, tc.expected)
+		}
+	}
+}
+
+// countLines returns the number of lines in a file,
+// or -1 if it does not exist.
+func countLines(t *testing.T, path string) int {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return -1
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		n++
+	}
+	return n
+}
+
+func TestFileAuditLogRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Find the size of an entry, so that each file holds exactly two.
+	entry := func(seq int64) *AuditEntry {
+		return &AuditEntry{Game: "a/game", Method: "PickUpStock", Sequence: seq}
+	}
+	line, err := json.Marshal(entry(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewFileAuditLog(dir, int64(2*(len(line)+1)), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 7; i++ {
+		if err := l.Record(entry(int64(i))); err != nil {
+			t.Fatal(err)
+		}
+		if i == 2 {
+			// Entries may still be appended once the game is closed.
+			if err := l.CloseGame("a/game"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record(entry(0)); err == nil {
+		t.Error("recorded an entry after the log was closed")
+	}
+
+	// The 7 entries fill three files and start a fourth,
+	// and the oldest file is removed.
+	path := l.Path("a/game")
+	expected := map[string]int{
+		path:        1,
+		path + ".1": 2,
+		path + ".2": 2,
+		path + ".3": -1,
+	}
+	for p, n := range expected {
+		if got := countLines(t, p); got != n {
+			t.Errorf("%v has %v lines, expected %v", p, got, n)
+		}
+	}
+}
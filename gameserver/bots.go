@@ -0,0 +1,289 @@
+package gameserver
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
+)
+
+// Size of the buffer of the in-process connection
+// used by computer players.
+const botConnBufferSize = 1 << 20
+
+// serverBot is a computer player running in a game.
+type serverBot struct {
+	// The computer player acts with this token rather than the seat's
+	// player secret. It is revoked when the computer player is stopped,
+	// so that any requests it still has in flight are rejected.
+	token  string
+	cancel context.CancelFunc
+}
+
+// isBot returns true if secret is the token of the computer player
+// currently running in the given seat.
+// Must be called while holding sg.mu.
+func (sg *serverGame) isBot(playerId int32, secret string) bool {
+	bot, ok := sg.bots[playerId]
+	return ok && secretsEqual(bot.token, secret)
+}
+
+type botRequestKey struct{}
+
+// isBotRequest returns true if ctx is that of a request made by
+// one of the server's own computer players.
+func isBotRequest(ctx context.Context) bool {
+	return ctx.Value(botRequestKey{}) != nil
+}
+
+// botServerStream marks the context of streams opened
+// by computer players.
+type botServerStream struct {
+	grpc.ServerStream
+}
+
+func (ss *botServerStream) Context() context.Context {
+	return context.WithValue(ss.ServerStream.Context(), botRequestKey{}, true)
+}
+
+// startBotServer serves the RummyService over an in-process connection,
+// through which the server's computer players play their games.
+// Their requests are recorded in the server's metrics along with
+// everyone else's.
+func (s *RummyServer) startBotServer() {
+	lis := bufconn.Listen(botConnBufferSize)
+	s.botServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.UnaryInterceptor(),
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(context.WithValue(ctx, botRequestKey{}, true), req)
+			}),
+		grpc.ChainStreamInterceptor(s.StreamInterceptor(),
+			func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &botServerStream{ss})
+			}),
+	)
+	rummy.RegisterRummyServiceServer(s.botServer, s)
+	go func() {
+		if err := s.botServer.Serve(lis); err != nil {
+			glog.Errorf("Error serving computer players: %v", err)
+		}
+	}()
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		glog.Fatalf("Error connecting computer players to server: %v", err)
+	}
+	s.botConn = conn
+	s.botClient = rummy.NewRummyServiceClient(conn)
+}
+
+// stopped returns true once Stop has been called.
+func (s *RummyServer) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopBotServer closes the in-process connection, stopping
+// all computer players.
+func (s *RummyServer) stopBotServer() {
+	s.botConn.Close()
+	s.botServer.Stop()
+}
+
+// computerPlayer is a computer player that is ready to take a seat.
+type computerPlayer struct {
+	strategyName string
+	params       map[string]string
+	strat        strategy.Strategy
+	token        string
+}
+
+// newComputerPlayer prepares a computer player with the given strategy
+// and parameters, so that it can be checked before it is given a seat.
+func newComputerPlayer(strategyName string, params map[string]string) (*computerPlayer, error) {
+	strat, err := strategy.ForNameWithParams(strategyName, params)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &computerPlayer{strategyName, params, strat, token}, nil
+}
+
+// startComputerPlayer starts a goroutine playing as the given player
+// in the game with the given strategy and parameters.
+// Must be called while holding sg.mu.
+func (s *RummyServer) startComputerPlayer(gameName string, sg *serverGame, id int32, strategyName string, params map[string]string) error {
+	cp, err := newComputerPlayer(strategyName, params)
+	if err != nil {
+		return err
+	}
+
+	s.runComputerPlayer(gameName, sg, id, cp)
+	return nil
+}
+
+// runComputerPlayer starts a goroutine playing as the given player
+// in the game with a prepared computer player.
+// Must be called while holding sg.mu.
+func (s *RummyServer) runComputerPlayer(gameName string, sg *serverGame, id int32, cp *computerPlayer) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sg.strategies[id] = cp.strategyName
+	if len(cp.params) > 0 {
+		sg.strategyParams[id] = cp.params
+	}
+	sg.bots[id] = &serverBot{cp.token, cancel}
+	delete(sg.paused, id)
+	go func() {
+		err := ai.PlayRemoteGame(ctx, s.botClient, gameName, id, cp.token, cp.strat)
+		if err != nil && ctx.Err() == nil && !s.stopped() {
+			glog.Errorf("Error in computer player %v in game %v: %v",
+				id, gameName, err)
+		}
+	}()
+}
+
+// pauseComputerPlayer stops the computer player in the given seat,
+// but leaves the seat to the computer player so that it may be resumed.
+// Must be called while holding sg.mu.
+func pauseComputerPlayer(sg *serverGame, id int32) {
+	if bot, ok := sg.bots[id]; ok {
+		bot.cancel()
+	}
+	delete(sg.bots, id)
+	sg.paused[id] = true
+}
+
+// stopComputerPlayer stops the computer player in the given seat, if any.
+// Must be called while holding sg.mu, so that the computer player's
+// token is revoked before it takes any further actions.
+func stopComputerPlayer(sg *serverGame, id int32) {
+	if bot, ok := sg.bots[id]; ok {
+		bot.cancel()
+	}
+	delete(sg.bots, id)
+	delete(sg.strategies, id)
+	delete(sg.strategyParams, id)
+	delete(sg.takenOver, id)
+	delete(sg.paused, id)
+}
+
+func (s *RummyServer) ListStrategies(ctx context.Context, req *rummy.ListStrategiesRequest) (*rummy.ListStrategiesResponse, error) {
+	glog.V(1).Infof("ListStrategies: %v", req)
+	return &rummy.ListStrategiesResponse{
+		Strategies: strategy.List(),
+	}, nil
+}
+
+func (s *RummyServer) ListBots(ctx context.Context, req *rummy.ListBotsRequest) (*rummy.ListBotsResponse, error) {
+	glog.V(1).Infof("ListBots: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+
+	if err := sg.authenticateHost(req.HostSecret); err != nil {
+		return nil, err
+	}
+
+	players := sg.game.GameState().Players
+	resp := &rummy.ListBotsResponse{}
+	for id, strategyName := range sg.strategies {
+		resp.Bots = append(resp.Bots, &rummy.ListBotsResponse_Bot{
+			PlayerId:       id,
+			PlayerName:     players[id].Name,
+			Strategy:       strategyName,
+			Paused:         sg.paused[id],
+			TakenOver:      sg.takenOver[id],
+			StrategyParams: copyStringMap(sg.strategyParams[id]),
+		})
+	}
+
+	sort.Slice(resp.Bots, func(i, j int) bool {
+		return resp.Bots[i].PlayerId < resp.Bots[j].PlayerId
+	})
+	return resp, nil
+}
+
+func (s *RummyServer) PauseBot(ctx context.Context, req *rummy.PauseBotRequest) (_ *rummy.PauseBotResponse, err error) {
+	glog.V(1).Infof("PauseBot: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "PauseBot", req, hostCaller, &err)
+
+	if err := sg.authenticateHost(req.HostSecret); err != nil {
+		return nil, err
+	}
+
+	strategyName, ok := sg.strategies[req.PlayerId]
+	if !ok {
+		return nil, fmt.Errorf("player %v is not a computer player", req.PlayerId)
+	} else if req.Paused == sg.paused[req.PlayerId] {
+		return &rummy.PauseBotResponse{}, nil
+	}
+
+	if req.Paused {
+		glog.Infof("Pausing computer player %v in game %v", req.PlayerId, req.GameName)
+		pauseComputerPlayer(sg, req.PlayerId)
+		return &rummy.PauseBotResponse{}, nil
+	}
+
+	glog.Infof("Resuming computer player %v in game %v", req.PlayerId, req.GameName)
+	return &rummy.PauseBotResponse{}, s.startComputerPlayer(req.GameName, sg, req.PlayerId,
+		strategyName, sg.strategyParams[req.PlayerId])
+}
+
+func (s *RummyServer) RemoveBot(ctx context.Context, req *rummy.RemoveBotRequest) (_ *rummy.RemoveBotResponse, err error) {
+	glog.V(1).Infof("RemoveBot: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "RemoveBot", req, hostCaller, &err)
+
+	if err := sg.authenticateHost(req.HostSecret); err != nil {
+		return nil, err
+	} else if _, ok := sg.strategies[req.PlayerId]; !ok {
+		return nil, fmt.Errorf("player %v is not a computer player", req.PlayerId)
+	}
+
+	if !sg.takenOver[req.PlayerId] {
+		switch sg.game.Status() {
+		case rummy.GameState_LOBBY:
+			return nil, fmt.Errorf("computer players cannot be removed before the game starts")
+		case rummy.GameState_IN_PROGRESS:
+			if err := sg.game.Forfeit(req.PlayerId); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("game %v is over", req.GameName)
+		}
+	}
+
+	glog.Infof("Removed computer player %v from game %v", req.PlayerId, req.GameName)
+	stopComputerPlayer(sg, req.PlayerId)
+	return &rummy.RemoveBotResponse{}, nil
+}
@@ -0,0 +1,129 @@
+package gameserver
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestComputerPlayers(t *testing.T) {
+	s, client := startTestServer(t, DefaultOptions)
+	ctx := context.Background()
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	human, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: "P0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A computer player that cannot be started does not take a seat.
+	if _, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+		GameName:   "game",
+		PlayerName: "CP",
+		Strategy:   "no such strategy",
+	}); err == nil {
+		t.Error("computer player joined with an unknown strategy")
+	}
+	cp, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+		GameName:   "game",
+		PlayerName: "CP",
+		Strategy:   "greedy",
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if cp.PlayerId != 1 {
+		t.Errorf("computer player joined seat %v, expected 1", cp.PlayerId)
+	}
+
+	checkBots := func(step string, expected ...*rummy.ListBotsResponse_Bot) {
+		resp, err := client.ListBots(ctx, &rummy.ListBotsRequest{
+			GameName:   "game",
+			HostSecret: host.HostSecret,
+		})
+		if err != nil {
+			t.Fatalf("%v: %v", step, err)
+		}
+		if !proto.Equal(resp, &rummy.ListBotsResponse{Bots: expected}) {
+			t.Errorf("%v: bots %v, expected %v", step, resp.Bots, expected)
+		}
+	}
+	bot := &rummy.ListBotsResponse_Bot{PlayerId: cp.PlayerId, PlayerName: "CP", Strategy: "greedy"}
+	checkBots("joined", bot)
+	if _, err := client.ListBots(ctx, &rummy.ListBotsRequest{GameName: "game"}); err == nil {
+		t.Error("bots were listed without the host secret")
+	}
+
+	remove := &rummy.RemoveBotRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+		PlayerId:   cp.PlayerId,
+	}
+	if _, err := client.RemoveBot(ctx, remove); err == nil {
+		t.Error("computer player was removed before the game started")
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	pause := func(playerId int32, paused bool) error {
+		_, err := client.PauseBot(ctx, &rummy.PauseBotRequest{
+			GameName:   "game",
+			HostSecret: host.HostSecret,
+			PlayerId:   playerId,
+			Paused:     paused,
+		})
+		return err
+	}
+	if err := pause(human.PlayerId, true); err == nil {
+		t.Error("human player was paused")
+	}
+	if err := pause(cp.PlayerId, true); err != nil {
+		t.Fatal(err)
+	}
+	checkBots("paused", &rummy.ListBotsResponse_Bot{
+		PlayerId:   cp.PlayerId,
+		PlayerName: "CP",
+		Strategy:   "greedy",
+		Paused:     true,
+	})
+	s.gamesMu.Lock()
+	sg := s.games["game"]
+	s.gamesMu.Unlock()
+	sg.mu.Lock()
+	if _, ok := sg.bots[cp.PlayerId]; ok {
+		t.Error("paused computer player is still running")
+	}
+	sg.mu.Unlock()
+
+	if err := pause(cp.PlayerId, false); err != nil {
+		t.Fatal(err)
+	}
+	checkBots("resumed", bot)
+	sg.mu.Lock()
+	if _, ok := sg.bots[cp.PlayerId]; !ok {
+		t.Error("resumed computer player is not running")
+	}
+	sg.mu.Unlock()
+
+	// A computer player removed from a game in progress forfeits.
+	if _, err := client.RemoveBot(ctx, remove); err != nil {
+		t.Fatal(err)
+	}
+	checkBots("removed")
+	state, err := client.GetGameState(ctx, &rummy.GetGameStateRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	} else if !state.Players[cp.PlayerId].Forfeited {
+		t.Errorf("removed computer player did not forfeit: %v", state.Players[cp.PlayerId])
+	}
+	if _, err := client.RemoveBot(ctx, remove); err == nil {
+		t.Error("computer player was removed twice")
+	}
+}
@@ -0,0 +1,118 @@
+package gameserver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChatFilter is called with each chat message before it is sent.
+// It returns the message to send, which may be modified (e.g. to mask
+// profanity), or an error if the message should be rejected.
+type ChatFilter func(message string) (string, error)
+
+// NewWordFilter returns a ChatFilter that masks each of the given words
+// wherever they appear as a whole word in a message, ignoring case.
+func NewWordFilter(words []string) ChatFilter {
+	var trimmed []string
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			trimmed = append(trimmed, w)
+		}
+	}
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	// Longer words are tried first, so that a word is not hidden by a
+	// shorter one that it begins with. Word boundaries are checked
+	// separately, since \b only recognizes ASCII letters.
+	sort.Slice(trimmed, func(i, j int) bool {
+		return len(trimmed[i]) > len(trimmed[j])
+	})
+	quoted := make([]string, len(trimmed))
+	for i, w := range trimmed {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	re := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+
+	return func(message string) (string, error) {
+		var result strings.Builder
+		last := 0
+		for start := 0; start < len(message); {
+			loc := re.FindStringIndex(message[start:])
+			if loc == nil {
+				break
+			}
+
+			i, j := start+loc[0], start+loc[1]
+			if !isWordBoundary(message, i) || !isWordBoundary(message, j) {
+				_, size := utf8.DecodeRuneInString(message[i:])
+				start = i + size
+				continue
+			}
+			result.WriteString(message[last:i])
+			result.WriteString(strings.Repeat("*", utf8.RuneCountInString(message[i:j])))
+			last, start = j, j
+		}
+		result.WriteString(message[last:])
+		return result.String(), nil
+	}
+}
+
+// isWordBoundary returns true unless position i in s is
+// between two letters, digits or underscores.
+func isWordBoundary(s string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	return !isWordRune(before) || !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// chatLimiter limits the number of chat messages each player may
+// send within a sliding window.
+type chatLimiter struct {
+	limit    int
+	interval time.Duration
+	// Times at which each player sent their recent messages, by player id.
+	sent map[int32][]time.Time
+}
+
+func newChatLimiter(limit int, interval time.Duration) *chatLimiter {
+	return &chatLimiter{
+		limit:    limit,
+		interval: interval,
+		sent:     make(map[int32][]time.Time),
+	}
+}
+
+// allow records a message sent by the given player at now, or returns
+// an error if they have already sent too many messages recently.
+// If limit is zero, all messages are allowed.
+func (cl *chatLimiter) allow(playerId int32, now time.Time) error {
+	if cl.limit <= 0 {
+		return nil
+	}
+
+	cutoff := now.Add(-cl.interval)
+	recent := cl.sent[playerId]
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+
+	if len(recent) >= cl.limit {
+		cl.sent[playerId] = recent
+		return fmt.Errorf("too many chat messages, please wait %v",
+			recent[0].Sub(cutoff).Round(time.Second))
+	}
+
+	cl.sent[playerId] = append(recent, now)
+	return nil
+}
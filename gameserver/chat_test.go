@@ -0,0 +1,75 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWordFilter(t *testing.T) {
+	tests := []struct {
+		words    []string
+		message  string
+		expected string
+	}{
+		{[]string{"darn"}, "darn it", "**** it"},
+		{[]string{"darn"}, "Darn, DARN!", "****, ****!"},
+		{[]string{"darn"}, "darned darnit", "darned darnit"},
+		{[]string{"darn", " heck \r"}, "darn heck", "**** ****"},
+		{[]string{"a.b"}, "a.b axb", "*** axb"},
+		{[]string{"café"}, "le café", "le ****"},
+		{[]string{"café"}, "cafés", "cafés"},
+		{[]string{"darn"}, "darné", "darné"},
+		{[]string{"darn", "darned"}, "darned darn", "****** ****"},
+		{[]string{"darn"}, "", ""},
+	}
+
+	for _, tc := range tests {
+		filter := NewWordFilter(tc.words)
+		result, err := filter(tc.message)
+		if err != nil {
+			t.Errorf("filter(%q) returned error: %v", tc.message, err)
+		} else if result != tc.expected {
+			t.Errorf("filter(%q) with words %q = %q, expected %q",
+				tc.message, tc.words, result, tc.expected)
+		}
+	}
+
+	if NewWordFilter([]string{"", " "}) != nil {
+		t.Error("NewWordFilter returned a filter without any words")
+	}
+}
+
+func TestChatLimiter(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		playerId int32
+		after    time.Duration
+		ok       bool
+	}{
+		{0, 0, true},
+		{0, time.Second, true},
+		{0, 2 * time.Second, false},
+		// Limits are per player.
+		{1, 2 * time.Second, true},
+		// The first message leaves the window.
+		{0, 10 * time.Second, true},
+		{0, 10 * time.Second, false},
+		{0, 11 * time.Second, true},
+	}
+
+	cl := newChatLimiter(2, 10*time.Second)
+	for i, tc := range tests {
+		err := cl.allow(tc.playerId, start.Add(tc.after))
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: allow(%v) at +%v returned %v, expected ok = %v",
+				i, tc.playerId, tc.after, err, tc.ok)
+		}
+	}
+
+	unlimited := newChatLimiter(0, time.Second)
+	for i := 0; i < 10; i++ {
+		if err := unlimited.allow(0, start); err != nil {
+			t.Errorf("unlimited limiter returned %v", err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// The name under which the health service reports
+// the status of the RummyService.
+const rummyServiceName = "rummy.RummyService"
+
+// How long the health handler waits for the server to respond.
+const healthCheckTimeout = 5 * time.Second
+
+// healthHandler reports the status of the server from its gRPC health
+// service, responding with 200 OK if it is serving and 503 otherwise,
+// so that the server's readiness can be checked without a gRPC client.
+func healthHandler(client healthpb.HealthClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		ctx, cancel := context.WithTimeout(req.Context(), healthCheckTimeout)
+		defer cancel()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{
+			Service: rummyServiceName,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, err)
+			return
+		}
+
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		fmt.Fprintln(w, resp.Status)
+	})
+}
+
+// startupGate rejects requests to the RummyService until it is opened,
+// so that the server can report its health while its games are being
+// restored without clients seeing it before they have been.
+type startupGate struct {
+	// Set to 1 once the gate is opened.
+	open int32
+}
+
+// Open lets requests through to the RummyService.
+func (g *startupGate) Open() {
+	atomic.StoreInt32(&g.open, 1)
+}
+
+func (g *startupGate) check(fullMethod string) error {
+	if atomic.LoadInt32(&g.open) == 0 && strings.HasPrefix(fullMethod, "/"+rummyServiceName+"/") {
+		return status.Errorf(codes.Unavailable, "server is starting up")
+	}
+	return nil
+}
+
+// UnaryInterceptor returns a gRPC interceptor that rejects unary
+// RPCs to the RummyService until the gate is opened.
+func (g *startupGate) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := g.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that rejects streaming
+// RPCs to the RummyService until the gate is opened.
+func (g *startupGate) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := g.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStartupGate(t *testing.T) {
+	tests := []struct {
+		method string
+		open   bool
+		code   codes.Code
+	}{
+		{"/rummy.RummyService/ListGames", false, codes.Unavailable},
+		{"/rummy.RummyService/ListGames", true, codes.OK},
+		{"/grpc.health.v1.Health/Check", false, codes.OK},
+		{"/grpc.health.v1.Health/Check", true, codes.OK},
+	}
+
+	for _, tc := range tests {
+		var gate startupGate
+		if tc.open {
+			gate.Open()
+		}
+		if code := status.Code(gate.check(tc.method)); code != tc.code {
+			t.Errorf("check(%v) with open = %v returned %v, expected %v",
+				tc.method, tc.open, code, tc.code)
+		}
+	}
+}
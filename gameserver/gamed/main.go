@@ -4,41 +4,141 @@
 package main
 
 import (
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
 	"github.com/timpalpant/rummy/gameserver"
+	"github.com/timpalpant/rummy/tlsutil"
 )
 
-func runProxy(ctx context.Context, grpcEndpoint string, port int) error {
+// runProxy starts the JSON proxy for the gRPC server at grpcEndpoint.
+// If tlsConfig is non-nil, the proxy serves HTTPS with it, and connects
+// to the gRPC server over TLS.
+func runProxy(ctx context.Context, grpcEndpoint string, port int, metrics http.Handler, tlsConfig *tls.Config) (*http.Server, error) {
 	mux := runtime.NewServeMux()
 	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if tlsConfig != nil {
+		opts = []grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(proxyClientConfig(tlsConfig))),
+		}
+	}
 	err := rummy.RegisterRummyServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Browsers play over a WebSocket, which is served alongside
+	// the JSON API through its own connection to the server.
+	conn, err := grpc.Dial(grpcEndpoint, opts...)
+	if err != nil {
+		return nil, err
 	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/v1/play", websocketHandler(ctx, rummy.NewRummyServiceClient(conn)))
+	httpMux.Handle("/healthz", healthHandler(healthpb.NewHealthClient(conn)))
+	httpMux.Handle("/metrics", metrics)
+	httpMux.Handle("/", webHandler(mux))
 
 	jsonEndpoint := fmt.Sprintf(":%d", port)
-	http.ListenAndServe(jsonEndpoint, mux)
-	return nil
+	srv := &http.Server{Addr: jsonEndpoint, Handler: httpMux, TLSConfig: tlsConfig}
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
+			glog.Fatalf("JSON proxy failed: %v", err)
+		}
+	}()
+	return srv, nil
+}
+
+// gracefulStop stops the gRPC server, waiting up to timeout
+// for in-flight RPCs to finish before forcibly closing them.
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		glog.Warning("Timed out waiting for RPCs to finish")
+		s.Stop()
+	}
 }
 
 func main() {
 	port := flag.Int("port", 8081, "Port to run gRPC service on")
 	proxyPort := flag.Int("proxyport", 8082, "Port to run JSON proxy on")
 	seed := flag.Int64("seed", 1, "Seed for random shuffling")
+	lobbyTTL := flag.Duration("lobby_ttl", gameserver.DefaultOptions.LobbyIdleTTL,
+		"Remove games that have not started after this long without activity (0 = never)")
+	idleTTL := flag.Duration("idle_ttl", gameserver.DefaultOptions.InProgressIdleTTL,
+		"Remove games in progress after this long without activity (0 = never)")
+	completedTTL := flag.Duration("completed_ttl", gameserver.DefaultOptions.CompletedTTL,
+		"Remove completed games after this long (0 = never)")
+	timeoutStrategy := flag.String("timeout_strategy", gameserver.DefaultOptions.TimeoutStrategy,
+		"Strategy used to finish the turns of players who run out of time")
+	awayGrace := flag.Duration("away_grace", gameserver.DefaultOptions.AwayGracePeriod,
+		"Mark players away after they have been disconnected for this long")
+	awayStrategy := flag.String("away_strategy", "",
+		"If set, computer players with this strategy take over the seats of away players")
+	chatRateLimit := flag.Int("chat_rate_limit", gameserver.DefaultOptions.ChatRateLimit,
+		"Maximum number of chat messages each player may send per -chat_rate_interval (0 = unlimited)")
+	chatRateInterval := flag.Duration("chat_rate_interval", gameserver.DefaultOptions.ChatRateInterval,
+		"Interval over which chat messages are rate limited")
+	maxChatLength := flag.Int("max_chat_length", gameserver.DefaultOptions.MaxChatLength,
+		"Maximum length of a chat message, in characters (0 = unlimited)")
+	chatBlocklist := flag.String("chat_blocklist", "",
+		"File of words, one per line, to mask in chat messages")
+	matchTimeout := flag.Duration("match_timeout", gameserver.DefaultOptions.MatchTimeout,
+		"Fill empty seats with computer players after players wait this long for a match (0 = never)")
+	matchStrategy := flag.String("match_strategy", gameserver.DefaultOptions.MatchStrategy,
+		"Strategy of the computer players that fill empty seats in matches")
+	storeDir := flag.String("store_dir", "", "Directory in which to save games across restarts")
+	auditDir := flag.String("audit_dir", "", "Directory in which to record the requests made in each game")
+	auditMaxSize := flag.Int64("audit_max_size", 10<<20,
+		"Rotate each game's audit log once it reaches this many bytes (0 = never)")
+	auditMaxFiles := flag.Int("audit_max_files", 5, "Number of rotated audit logs to keep for each game")
+	certFile := flag.String("cert", "", "Certificate with which to serve TLS on both ports (PEM)")
+	keyFile := flag.String("key", "", "Key of -cert (PEM)")
+	clientCA := flag.String("client_ca", "",
+		"If set, clients must present a certificate signed by one of the CAs in this file (PEM)")
+	shutdownTimeout := flag.Duration("shutdown_timeout", 30*time.Second,
+		"How long to wait for requests to finish when shutting down")
 	flag.Parse()
 
 	rand.Seed(*seed)
@@ -49,8 +149,86 @@ func main() {
 	if err != nil {
 		glog.Fatalf("failed to listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
-	rummyServer := gameserver.NewRummyServer()
+	opts := gameserver.DefaultOptions
+	opts.LobbyIdleTTL = *lobbyTTL
+	opts.InProgressIdleTTL = *idleTTL
+	opts.CompletedTTL = *completedTTL
+	if _, err := strategy.ForName(*timeoutStrategy); err != nil {
+		glog.Fatal(err)
+	}
+	opts.TimeoutStrategy = *timeoutStrategy
+	if *awayStrategy != "" {
+		if _, err := strategy.ForName(*awayStrategy); err != nil {
+			glog.Fatal(err)
+		}
+	}
+	opts.AwayGracePeriod = *awayGrace
+	opts.AwayStrategy = *awayStrategy
+	if *matchStrategy != "" {
+		if _, err := strategy.ForName(*matchStrategy); err != nil {
+			glog.Fatal(err)
+		}
+	}
+	opts.MatchTimeout = *matchTimeout
+	opts.MatchStrategy = *matchStrategy
+	opts.ChatRateLimit = *chatRateLimit
+	opts.ChatRateInterval = *chatRateInterval
+	opts.MaxChatLength = *maxChatLength
+	if *chatBlocklist != "" {
+		words, err := ioutil.ReadFile(*chatBlocklist)
+		if err != nil {
+			glog.Fatalf("failed to read chat blocklist: %v", err)
+		}
+		opts.ChatFilter = gameserver.NewWordFilter(strings.Split(string(words), "\n"))
+	}
+	if *storeDir != "" {
+		opts.Store, err = gameserver.NewFileStore(*storeDir)
+		if err != nil {
+			glog.Fatalf("failed to open store: %v", err)
+		}
+	}
+	var auditLog *gameserver.FileAuditLog
+	if *auditDir != "" {
+		auditLog, err = gameserver.NewFileAuditLog(*auditDir, *auditMaxSize, *auditMaxFiles)
+		if err != nil {
+			glog.Fatalf("failed to open audit log: %v", err)
+		}
+		opts.AuditLog = auditLog
+	}
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	opts.Registerer = registry
+	rummyServer := gameserver.NewRummyServer(opts)
+	var gate startupGate
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(rummyServer.UnaryInterceptor(), gate.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(rummyServer.StreamInterceptor(), gate.StreamInterceptor()),
+	}
+	var tlsConfig *tls.Config
+	if *certFile != "" {
+		tlsConfig, err = tlsutil.ServerConfig(*certFile, *keyFile, *clientCA)
+		if err != nil {
+			glog.Fatalf("failed to load TLS certificates: %v", err)
+		}
+		grpcConfig := tlsConfig
+		if *clientCA != "" {
+			grpcConfig = acceptOwnCertificate(tlsConfig)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(grpcConfig)))
+	} else if *clientCA != "" {
+		glog.Fatal("-client_ca requires -cert and -key")
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+	// The server reports that it is serving once its games have
+	// been restored, until it begins shutting down. Until then, requests
+	// to the RummyService are rejected, but the health service (and
+	// /healthz) can be reached.
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(rummyServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	rummy.RegisterRummyServiceServer(grpcServer, rummyServer)
 	go grpcServer.Serve(lis)
 
@@ -58,13 +236,49 @@ func main() {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	go runProxy(ctx, endpoint, *proxyPort)
+	proxy, err := runProxy(ctx, endpoint, *proxyPort,
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), tlsConfig)
+	if err != nil {
+		glog.Fatalf("failed to start JSON proxy: %v", err)
+	}
+
+	if err := rummyServer.Restore(); err != nil {
+		glog.Fatalf("failed to restore games: %v", err)
+	}
+	gate.Open()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(rummyServiceName, healthpb.HealthCheckResponse_SERVING)
 
 	// Wait for SIGINT and SIGTERM (HIT CTRL-C)
-	ch := make(chan os.Signal)
+	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	glog.Info(<-ch)
 	glog.Info("Shutting down")
 
-	grpcServer.Stop()
+	// Stop accepting new games and close all event streams,
+	// then wait for outstanding requests on both listeners.
+	healthServer.Shutdown()
+	rummyServer.Shutdown()
+	shutdownCtx, cancelShutdown := context.WithTimeout(ctx, *shutdownTimeout)
+	defer cancelShutdown()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := proxy.Shutdown(shutdownCtx); err != nil {
+			glog.Warningf("Error shutting down JSON proxy: %v", err)
+		}
+	}()
+	gracefulStop(grpcServer, *shutdownTimeout)
+	wg.Wait()
+
+	if err := rummyServer.Flush(); err != nil {
+		glog.Errorf("Error saving games: %v", err)
+	}
+	if auditLog != nil {
+		if err := auditLog.Close(); err != nil {
+			glog.Errorf("Error closing audit log: %v", err)
+		}
+	}
+	glog.Flush()
 }
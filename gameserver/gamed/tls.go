@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// acceptOwnCertificate returns a copy of the gRPC server's config that
+// also accepts clients presenting the server's own certificate, so that
+// the JSON proxy can connect when client certificates are required even
+// if the server's certificate is not signed by the client CA. Other
+// clients must present a certificate signed by the client CA as usual.
+func acceptOwnCertificate(config *tls.Config) *tls.Config {
+	own := config.Certificates[0].Certificate[0]
+	clientCAs := config.ClientCAs
+	config = config.Clone()
+	config.ClientAuth = tls.RequireAnyClientCert
+	config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if bytes.Equal(rawCerts[0], own) {
+			return nil
+		}
+
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			certs[i] = cert
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         clientCAs,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		return err
+	}
+	return config
+}
+
+// proxyClientConfig returns the config with which the JSON proxy
+// connects to the gRPC server. The proxy presents the server's own
+// certificate, and accepts only that certificate from the server,
+// so that it does not matter which names the certificate was
+// issued for or who signed it.
+func proxyClientConfig(config *tls.Config) *tls.Config {
+	cert := config.Certificates[0]
+	return &tls.Config{
+		// The certificate is presented even though it may not be signed
+		// by any of the CAs the server asks for client certificates from.
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &cert, nil
+		},
+		// The server's certificate is verified below instead.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], cert.Certificate[0]) {
+				return fmt.Errorf("gRPC server did not present its own certificate")
+			}
+			return nil
+		},
+	}
+}
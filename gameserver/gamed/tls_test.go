@@ -0,0 +1,132 @@
+package main
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/gameserver"
+	"github.com/timpalpant/rummy/tlsutil"
+)
+
+// writeCert generates a certificate signed by the given CA, writes it
+// and its key to files in dir, and returns their paths.
+func writeCert(t *testing.T, dir, name string, caCert, caKey []byte, hosts []string) (certFile, keyFile string) {
+	certPEM, keyPEM, err := tlsutil.GenerateCert(caCert, caKey, name, hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// writeCA generates a CA, writes its certificate to a file in dir,
+// and returns the path and the PEM-encoded certificate and key.
+func writeCA(t *testing.T, dir, name string) (caFile string, caCert, caKey []byte) {
+	caCert, caKey, err := tlsutil.GenerateCA(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile = filepath.Join(dir, name+".pem")
+	if err := ioutil.WriteFile(caFile, caCert, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return caFile, caCert, caKey
+}
+
+// TestTLS serves the RummyService as gamed does with -cert, -key and
+// -client_ca, and checks which clients can call it. The server's own
+// certificate is signed by a different CA than client certificates,
+// so the JSON proxy can only connect by presenting it.
+func TestTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gamed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	serverCAFile, serverCACert, serverCAKey := writeCA(t, dir, "server-ca")
+	clientCAFile, clientCACert, clientCAKey := writeCA(t, dir, "client-ca")
+	serverCert, serverKey := writeCert(t, dir, "server", serverCACert, serverCAKey,
+		[]string{"localhost", "127.0.0.1"})
+	clientCert, clientKey := writeCert(t, dir, "client", clientCACert, clientCAKey, nil)
+	// Signed by a CA that the server does not accept client certificates from.
+	otherCert, otherKey := writeCert(t, dir, "other", serverCACert, serverCAKey, nil)
+
+	tlsConfig, err := tlsutil.ServerConfig(serverCert, serverKey, clientCAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(acceptOwnCertificate(tlsConfig))))
+	rummyServer := gameserver.NewRummyServer(gameserver.DefaultOptions)
+	rummy.RegisterRummyServiceServer(grpcServer, rummyServer)
+	go grpcServer.Serve(lis)
+	defer rummyServer.Stop()
+	defer grpcServer.Stop()
+
+	clientConfig := func(certFile, keyFile string) *tls.Config {
+		config, err := tlsutil.ClientConfig(serverCAFile, "localhost", certFile, keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	otherConfig, err := tlsutil.ServerConfig(otherCert, otherKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opt  grpc.DialOption
+		ok   bool
+	}{
+		{"plaintext", grpc.WithInsecure(), false},
+		{"no client certificate",
+			grpc.WithTransportCredentials(credentials.NewTLS(clientConfig("", ""))), false},
+		{"client certificate",
+			grpc.WithTransportCredentials(credentials.NewTLS(clientConfig(clientCert, clientKey))), true},
+		{"client certificate from another CA",
+			grpc.WithTransportCredentials(credentials.NewTLS(clientConfig(otherCert, otherKey))), false},
+		{"JSON proxy",
+			grpc.WithTransportCredentials(credentials.NewTLS(proxyClientConfig(tlsConfig))), true},
+		// The proxy only accepts the certificate it presents.
+		{"JSON proxy of another server",
+			grpc.WithTransportCredentials(credentials.NewTLS(proxyClientConfig(otherConfig))), false},
+	}
+
+	for _, tc := range tests {
+		conn, err := grpc.Dial(lis.Addr().String(), tc.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		client := rummy.NewRummyServiceClient(conn)
+		_, err = client.ListGames(ctx, &rummy.ListGamesRequest{})
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: ListGames returned %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+		cancel()
+		conn.Close()
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// The browser client, a single page that plays through the JSON API
+// and the WebSocket served alongside it.
+//
+//go:embed web
+var webFiles embed.FS
+
+// webHandler serves the browser client under /ui/, and sends requests
+// for the root to it. All other requests are passed to api.
+func webHandler(api http.Handler) http.Handler {
+	files, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/ui/", http.StripPrefix("/ui/", http.FileServer(http.FS(files))))
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/" {
+			http.Redirect(w, req, "/ui/", http.StatusFound)
+			return
+		}
+		api.ServeHTTP(w, req)
+	})
+	return mux
+}
@@ -0,0 +1,633 @@
+// Browser client for the rummy server. Games are created, joined and
+// played through the JSON API, and game events arrive over the /v1/play
+// WebSocket, which is opened without sending any actions on it.
+'use strict';
+
+const RANKS = {
+  ACE: 'A', TWO: '2', THREE: '3', FOUR: '4', FIVE: '5', SIX: '6', SEVEN: '7',
+  EIGHT: '8', NINE: '9', TEN: '10', JACK: 'J', QUEEN: 'Q', KING: 'K',
+};
+const SUITS = {HEARTS: '♥', DIAMONDS: '♦', CLUBS: '♣', SPADES: '♠'};
+const SESSION_KEY = 'rummy.session';
+const RECONNECT_DELAY_MS = 2000;
+
+// The game the player is seated at, saved so that reloading the
+// page returns to it: {game, playerId, secret, inviteCode}.
+let session = JSON.parse(localStorage.getItem(SESSION_KEY) || 'null');
+let view = null;
+let socket = null;
+let lastSequence = 0;
+// Cards selected in the hand and the discard pile, by key, and the
+// cards moved from the hand into a new meld.
+let selectedHand = new Set();
+let selectedDiscard = new Set();
+let staged = [];
+
+const $ = (id) => document.getElementById(id);
+
+function saveSession(s) {
+  session = s;
+  if (s) {
+    localStorage.setItem(SESSION_KEY, JSON.stringify(s));
+  } else {
+    localStorage.removeItem(SESSION_KEY);
+  }
+}
+
+function showError(err) {
+  const el = $('error');
+  el.textContent = err ? String(err.message || err) : '';
+  el.hidden = !err;
+}
+
+async function api(method, path, body) {
+  const resp = await fetch(path, {
+    method: method,
+    headers: body ? {'Content-Type': 'application/json'} : {},
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  const result = await resp.json().catch(() => ({}));
+  if (!resp.ok) {
+    throw new Error(result.message || result.error || resp.statusText);
+  }
+  return result;
+}
+
+// Sends an action for the current player, reporting any error.
+async function act(path, body) {
+  showError(null);
+  try {
+    await api('POST', path, Object.assign({
+      game_name: session.game,
+      player_id: session.playerId,
+      player_secret: session.secret,
+      // Lets the server ignore the request if it is sent twice.
+      request_id: Math.random().toString(36).slice(2),
+    }, body));
+  } catch (err) {
+    showError(err);
+  }
+  await refreshView();
+}
+
+// Cards in JSON omit fields with default values.
+function cardKey(c) {
+  return (c.suit || '') + '-' + (c.rank || '');
+}
+
+function cardElement(c, draggable) {
+  const el = document.createElement('div');
+  el.className = 'card';
+  if (c.suit === 'HEARTS' || c.suit === 'DIAMONDS') {
+    el.classList.add('red');
+  }
+  el.textContent = (RANKS[c.rank] || '?') + (SUITS[c.suit] || '?');
+  if (draggable) {
+    el.draggable = true;
+    el.addEventListener('dragstart', (e) => {
+      e.dataTransfer.setData('text/plain', cardKey(c));
+    });
+  }
+  return el;
+}
+
+function sameCard(a, b) {
+  return cardKey(a) === cardKey(b);
+}
+
+// Cards dragged from the hand or the new meld are identified by key.
+function handCard(key) {
+  return (view.hand || []).find((c) => cardKey(c) === key);
+}
+
+function dropTarget(el, onDrop) {
+  el.addEventListener('dragover', (e) => {
+    e.preventDefault();
+    el.classList.add('over');
+  });
+  el.addEventListener('dragleave', () => el.classList.remove('over'));
+  el.addEventListener('drop', (e) => {
+    e.preventDefault();
+    el.classList.remove('over');
+    const card = handCard(e.dataTransfer.getData('text/plain'));
+    if (card) {
+      onDrop(card);
+    }
+  });
+}
+
+// Lobby.
+
+async function loadGames() {
+  try {
+    const resp = await api('GET', '/v1/games');
+    const tbody = $('games').querySelector('tbody');
+    tbody.textContent = '';
+    const games = resp.games || [];
+    $('no-games').hidden = games.length > 0;
+    for (const g of games) {
+      const opts = g.options || {};
+      const status = g.status || 'LOBBY';
+      const tr = document.createElement('tr');
+      for (const text of [g.game_name, status,
+        (g.num_players || 0) + ' / ' + (opts.max_players || 0),
+        opts.rules_variant || 'STANDARD']) {
+        const td = document.createElement('td');
+        td.textContent = text;
+        tr.appendChild(td);
+      }
+      const td = document.createElement('td');
+      if (status === 'LOBBY') {
+        const button = document.createElement('button');
+        button.textContent = 'Join';
+        button.addEventListener('click', () => joinGame(g.game_name, ''));
+        td.appendChild(button);
+      }
+      tr.appendChild(td);
+      tbody.appendChild(tr);
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function playerName() {
+  const name = $('player-name').value.trim();
+  if (!name) {
+    throw new Error('Enter your name first');
+  }
+  localStorage.setItem('rummy.name', name);
+  return name;
+}
+
+async function joinGame(gameName, inviteCode) {
+  showError(null);
+  try {
+    const resp = await api('POST', '/v1/join_game', {
+      game_name: gameName,
+      player_name: playerName(),
+      invite_code: inviteCode,
+    });
+    saveSession({
+      game: gameName,
+      playerId: resp.player_id || 0,
+      secret: resp.player_secret,
+      inviteCode: inviteCode,
+    });
+    enterTable();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function createGame(e) {
+  e.preventDefault();
+  showError(null);
+  try {
+    const gameName = $('create-name').value.trim();
+    playerName();
+    const resp = await api('POST', '/v1/create_game', {
+      game_name: gameName,
+      options: {
+        min_players: Number($('create-min').value),
+        max_players: Number($('create-max').value),
+        rules_variant: $('create-rules').value,
+        target_score: Number($('create-target').value),
+        visibility: $('create-private').checked ? 'PRIVATE' : 'PUBLIC',
+        disallow_bots: !$('create-bots').checked,
+      },
+    });
+    await joinGame(gameName, resp.invite_code || '');
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function showLobby() {
+  $('lobby').hidden = false;
+  $('table').hidden = true;
+  $('status').textContent = '';
+  loadGames();
+}
+
+// Table.
+
+function enterTable() {
+  $('lobby').hidden = true;
+  $('table').hidden = false;
+  $('events').textContent = '';
+  lastSequence = 0;
+  view = null;
+  staged = [];
+  selectedHand.clear();
+  selectedDiscard.clear();
+  loadStrategies();
+  connect();
+  refreshView();
+}
+
+function connect() {
+  const protocol = location.protocol === 'https:' ? 'wss:' : 'ws:';
+  const ws = new WebSocket(protocol + '//' + location.host + '/v1/play');
+  socket = ws;
+  ws.onopen = () => {
+    ws.send(JSON.stringify({
+      game_name: session.game,
+      player_id: session.playerId,
+      player_secret: session.secret,
+      from_sequence: lastSequence + 1,
+    }));
+  };
+  ws.onmessage = (msg) => {
+    const resp = JSON.parse(msg.data);
+    if (resp.error) {
+      showError(resp.error);
+    } else if (resp.event) {
+      onEvent(resp.event);
+    } else if (resp.result && resp.result.view) {
+      render(resp.result.view);
+    }
+  };
+  ws.onclose = () => {
+    if (socket !== ws || !session) {
+      return;
+    }
+    const status = view && view.game_state.status;
+    if (status !== 'COMPLETED' && status !== 'EXPIRED') {
+      setTimeout(() => {
+        if (socket === ws && session) {
+          connect();
+        }
+      }, RECONNECT_DELAY_MS);
+    }
+  };
+}
+
+function disconnect() {
+  const ws = socket;
+  socket = null;
+  if (ws) {
+    ws.close();
+  }
+}
+
+let refreshing = null;
+
+// Fetches the player's view, coalescing requests made while one
+// is already in flight.
+function refreshView() {
+  if (!refreshing) {
+    refreshing = api('POST', '/v1/view', {
+      game_name: session.game,
+      player_id: session.playerId,
+      player_secret: session.secret,
+    }).then(render, showError).finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+}
+
+function name(id) {
+  const players = (view && view.game_state.players) || [];
+  const p = players[id || 0];
+  return p ? p.name : 'Player ' + (id || 0);
+}
+
+function describe(e) {
+  const who = name(e.player_id);
+  const cards = (e.cards || []).map((c) => (RANKS[c.rank] || '?') + (SUITS[c.suit] || '?')).join(' ');
+  switch (e.type) {
+    case 'PLAYER_JOINED': return e.player_name + ' joined';
+    case 'GAME_STARTED': return 'The game started, ' + who + ' goes first. Discard: ' + cards;
+    case 'TURN_START': return who + "'s turn";
+    case 'PICK_UP_STOCK': return who + ' drew from the stock';
+    case 'PICK_UP_DISCARD': return who + ' picked up ' + cards;
+    case 'PLAY_CARDS': return who + ' played ' + cards + ' for ' + (e.score || 0);
+    case 'DISCARD': return who + ' discarded ' + cards;
+    case 'STOCK_RESHUFFLED': return 'The discard pile was shuffled into the stock';
+    case 'RUMMY_CALLED': return who + ' called rummy with ' + cards;
+    case 'TURN_TIMEOUT': return who + ' ran out of time';
+    case 'PLAYER_AWAY': return who + ' is away';
+    case 'PLAYER_RETURNED': return who + ' is back';
+    case 'PLAYER_LEFT':
+    case 'PLAYER_FORFEIT': return who + ' left the game';
+    case 'CHAT': return who + ': ' + e.message;
+    case 'GAME_OVER': return 'Game over';
+    case 'GAME_EXPIRED': return 'The game expired';
+    case 'SERVER_SHUTDOWN': return 'The server is restarting, reconnecting...';
+    default: return e.type;
+  }
+}
+
+function onEvent(e) {
+  if (e.sequence) {
+    lastSequence = Number(e.sequence);
+  }
+  const li = document.createElement('li');
+  li.textContent = describe(e);
+  if (e.type === 'CHAT') {
+    li.className = 'chat';
+  }
+  const events = $('events');
+  events.appendChild(li);
+  events.scrollTop = events.scrollHeight;
+  refreshView();
+}
+
+async function loadStrategies() {
+  try {
+    const resp = await api('GET', '/v1/strategies');
+    const select = $('bot-strategy');
+    select.textContent = '';
+    for (const s of resp.strategies || []) {
+      const option = document.createElement('option');
+      option.value = s.name;
+      option.textContent = s.name;
+      option.title = s.description;
+      select.appendChild(option);
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function render(v) {
+  view = v;
+  const gs = v.game_state;
+  const status = gs.status || 'LOBBY';
+  const legal = new Set(v.legal_actions || []);
+  const current = gs.current_player_turn || 0;
+  const hand = v.hand || [];
+
+  // Forget selections of cards that have moved.
+  staged = staged.filter((c) => hand.some((h) => sameCard(h, c)));
+  for (const key of selectedHand) {
+    if (!hand.some((c) => cardKey(c) === key)) {
+      selectedHand.delete(key);
+    }
+  }
+  const discard = gs.discard_pile || [];
+  for (const key of selectedDiscard) {
+    if (!discard.some((c) => cardKey(c) === key)) {
+      selectedDiscard.delete(key);
+    }
+  }
+
+  if (status === 'COMPLETED') {
+    // Players who left the game cannot win.
+    const players = gs.players.filter((p) => !p.forfeited)
+      .sort((a, b) => (b.current_score || 0) - (a.current_score || 0));
+    $('status').textContent = players.length > 0 ? 'Game over: ' + players[0].name + ' wins' : 'Game over';
+    $('leave').textContent = 'Back to lobby';
+  } else if (status === 'IN_PROGRESS') {
+    $('status').textContent = session.game + ' — turn ' + ((gs.turn || 0) + 1);
+    $('leave').textContent = 'Leave game';
+  } else {
+    $('status').textContent = session.game;
+    $('leave').textContent = status === 'LOBBY' ? 'Leave table' : 'Back to lobby';
+  }
+
+  // Players and scores.
+  const players = $('players');
+  players.textContent = '';
+  for (const p of gs.players || []) {
+    // Seats left before the game started have no name.
+    if (!p.name) {
+      continue;
+    }
+    const el = document.createElement('div');
+    el.className = 'player';
+    if (status === 'IN_PROGRESS' && (p.id || 0) === current) {
+      el.classList.add('current');
+    }
+    if (p.forfeited || p.away) {
+      el.classList.add('gone');
+    }
+    let text = p.name + ': ' + (p.current_score || 0) + ' points, ' +
+      (p.num_cards_in_hand || 0) + ' cards';
+    if ((p.id || 0) === (v.player_id || 0)) {
+      text += ' (you)';
+    }
+    if (p.forfeited) {
+      text += ', left';
+    } else if (p.away) {
+      text += ', away';
+    }
+    el.textContent = text;
+    players.appendChild(el);
+  }
+
+  $('waiting').hidden = status !== 'LOBBY';
+  $('host-controls').hidden = status !== 'LOBBY';
+  $('invite').textContent = session.inviteCode ? 'Invite code: ' + session.inviteCode : '';
+  $('board').hidden = status === 'LOBBY';
+  $('my-area').hidden = status === 'LOBBY';
+
+  // Stock and discard pile.
+  $('stock').textContent = gs.num_cards_in_stock || 0;
+  const discardEl = $('discard');
+  discardEl.textContent = '';
+  for (const c of discard) {
+    const el = cardElement(c, false);
+    if (selectedDiscard.has(cardKey(c))) {
+      el.classList.add('selected');
+    }
+    el.addEventListener('click', () => {
+      toggle(selectedDiscard, cardKey(c));
+      render(view);
+    });
+    discardEl.appendChild(el);
+  }
+
+  // Melds on the table. Cards dropped on a meld are played off of it.
+  const melds = $('melds');
+  melds.textContent = '';
+  for (const m of gs.aggregated_melds || []) {
+    const el = document.createElement('div');
+    el.className = 'meld drop';
+    el.title = 'Meld ' + (m.id || '');
+    for (const c of m.cards || []) {
+      el.appendChild(cardElement(c, false));
+    }
+    dropTarget(el, (card) => act('/v1/play_cards', {cards: [card]}));
+    melds.appendChild(el);
+  }
+
+  // The new meld being assembled.
+  const stagingEl = $('staging');
+  stagingEl.textContent = '';
+  for (const c of staged) {
+    const el = cardElement(c, true);
+    el.addEventListener('click', () => {
+      staged = staged.filter((s) => !sameCard(s, c));
+      render(view);
+    });
+    stagingEl.appendChild(el);
+  }
+
+  // The player's hand, without the cards in the new meld.
+  const handEl = $('hand');
+  handEl.textContent = '';
+  for (const c of hand) {
+    if (staged.some((s) => sameCard(s, c))) {
+      continue;
+    }
+    const el = cardElement(c, true);
+    if (selectedHand.has(cardKey(c))) {
+      el.classList.add('selected');
+    }
+    if (v.must_play_card && sameCard(v.must_play_card, c)) {
+      el.classList.add('must-play');
+      el.title = 'Must be played this turn';
+    }
+    el.addEventListener('click', () => {
+      toggle(selectedHand, cardKey(c));
+      render(view);
+    });
+    handEl.appendChild(el);
+  }
+
+  $('turn-hint').textContent = v.is_my_turn ? '— your turn' : '';
+  $('draw').disabled = !legal.has('PICK_UP_STOCK');
+  $('pick-up-discard').disabled = !legal.has('PICK_UP_DISCARD') || selectedDiscard.size === 0;
+  $('call-rummy').disabled = !legal.has('CALL_RUMMY') || selectedDiscard.size === 0;
+  $('play-meld').disabled = !legal.has('PLAY_CARDS') || staged.length === 0;
+  $('clear-meld').disabled = staged.length === 0;
+  $('discard-selected').disabled = !legal.has('DISCARD') || selectedHand.size !== 1;
+}
+
+function toggle(set, key) {
+  if (set.has(key)) {
+    set.delete(key);
+  } else {
+    set.add(key);
+  }
+}
+
+function selectedDiscardCards() {
+  return (view.game_state.discard_pile || []).filter((c) => selectedDiscard.has(cardKey(c)));
+}
+
+function setUpTable() {
+  // Dragging cards between the hand and the new meld, and onto the
+  // discard pile to discard them.
+  dropTarget($('staging'), (card) => {
+    if (!staged.some((s) => sameCard(s, card))) {
+      staged.push(card);
+      selectedHand.delete(cardKey(card));
+    }
+    render(view);
+  });
+  dropTarget($('hand'), (card) => {
+    staged = staged.filter((s) => !sameCard(s, card));
+    render(view);
+  });
+  dropTarget($('discard'), (card) => act('/v1/discard', {card: card}));
+
+  $('draw').addEventListener('click', () => act('/v1/pick_up_stock', {}));
+  $('pick-up-discard').addEventListener('click', () => {
+    // Picking up a card takes every card above it too.
+    const discard = view.game_state.discard_pile || [];
+    const first = discard.findIndex((c) => selectedDiscard.has(cardKey(c)));
+    act('/v1/pick_up_discard', {n_cards: discard.length - first});
+  });
+  $('call-rummy').addEventListener('click', () => act('/v1/call_rummy', {cards: selectedDiscardCards()}));
+  $('play-meld').addEventListener('click', () => {
+    const cards = staged;
+    staged = [];
+    act('/v1/play_cards', {cards: cards});
+  });
+  $('clear-meld').addEventListener('click', () => {
+    staged = [];
+    render(view);
+  });
+  $('discard-selected').addEventListener('click', () => {
+    const card = handCard([...selectedHand][0]);
+    act('/v1/discard', {card: card});
+  });
+
+  $('add-bot').addEventListener('click', async () => {
+    showError(null);
+    const strategy = $('bot-strategy').value;
+    try {
+      await api('POST', '/v1/join_game', {
+        game_name: session.game,
+        player_name: 'Computer ' + ((view.game_state.players || []).length + 1),
+        strategy: strategy,
+        invite_code: session.inviteCode,
+      });
+    } catch (err) {
+      showError(err);
+    }
+  });
+  $('start-game').addEventListener('click', async () => {
+    showError(null);
+    try {
+      await api('POST', '/v1/start/' + encodeURIComponent(session.game), {
+        player_id: session.playerId,
+        player_secret: session.secret,
+      });
+    } catch (err) {
+      showError(err);
+    }
+  });
+
+  $('leave').addEventListener('click', async () => {
+    if (view && view.game_state.status === 'IN_PROGRESS') {
+      if (!confirm('Leave the game? Your cards will count against you.')) {
+        return;
+      }
+      try {
+        await api('POST', '/v1/leave', {
+          game_name: session.game,
+          player_id: session.playerId,
+          player_secret: session.secret,
+        });
+      } catch (err) {
+        showError(err);
+        return;
+      }
+    }
+    saveSession(null);
+    disconnect();
+    showLobby();
+  });
+
+  $('chat-form').addEventListener('submit', async (e) => {
+    e.preventDefault();
+    const message = $('chat-message').value.trim();
+    if (!message) {
+      return;
+    }
+    try {
+      await api('POST', '/v1/chat', {
+        game_name: session.game,
+        player_id: session.playerId,
+        player_secret: session.secret,
+        message: message,
+      });
+      $('chat-message').value = '';
+    } catch (err) {
+      showError(err);
+    }
+  });
+}
+
+function setUpLobby() {
+  $('player-name').value = localStorage.getItem('rummy.name') || '';
+  $('refresh-games').addEventListener('click', loadGames);
+  $('create-form').addEventListener('submit', createGame);
+  $('join-form').addEventListener('submit', (e) => {
+    e.preventDefault();
+    joinGame($('join-name').value.trim(), $('join-code').value.trim());
+  });
+}
+
+setUpLobby();
+setUpTable();
+if (session) {
+  enterTable();
+} else {
+  showLobby();
+}
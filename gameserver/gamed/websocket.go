@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+)
+
+// websocketError is sent as the last message on a WebSocket if the
+// stream fails, in the same form as errors from the JSON proxy.
+type websocketError struct {
+	Error   string `json:"error"`
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// websocketHandler serves the Play stream over WebSockets, so that
+// browsers can play on a single connection without gRPC-Web. Each text
+// message from the client is a PlayRequest, and each message from the
+// server a PlayResponse, encoded as JSON in the same way as the JSON proxy.
+// As with Play, the first message must identify the game and player with
+// the player's secret, which authenticates the connection.
+func websocketHandler(ctx context.Context, client rummy.RummyServiceClient) websocket.Handler {
+	marshaler := &runtime.JSONPb{OrigName: true}
+	return func(ws *websocket.Conn) {
+		defer ws.Close()
+		ctx, cancel := context.WithCancel(forwardedFor(ctx, ws.Request()))
+		defer cancel()
+
+		stream, err := client.Play(ctx)
+		if err != nil {
+			sendWebsocketError(ws, err)
+			return
+		}
+
+		// Requests are forwarded in the background, since responses
+		// may arrive at any time.
+		badRequest := make(chan error, 1)
+		go func() {
+			defer stream.CloseSend()
+			for {
+				var msg string
+				if err := websocket.Message.Receive(ws, &msg); err != nil {
+					if err != io.EOF {
+						glog.V(1).Infof("Error receiving from WebSocket: %v", err)
+					}
+					return
+				}
+
+				req := &rummy.PlayRequest{}
+				if err := marshaler.Unmarshal([]byte(msg), req); err != nil {
+					badRequest <- status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+					cancel()
+					return
+				}
+				if err := stream.Send(req); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				select {
+				case err = <-badRequest:
+				default:
+				}
+				sendWebsocketError(ws, err)
+				return
+			}
+
+			msg, err := marshaler.Marshal(resp)
+			if err != nil {
+				sendWebsocketError(ws, err)
+				return
+			}
+			if err := websocket.Message.Send(ws, string(msg)); err != nil {
+				glog.V(1).Infof("Error sending to WebSocket: %v", err)
+				return
+			}
+		}
+	}
+}
+
+func sendWebsocketError(ws *websocket.Conn, err error) {
+	st := status.Convert(err)
+	websocket.JSON.Send(ws, &websocketError{
+		Error:   st.Message(),
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	})
+}
+
+// forwardedFor identifies the client that made req to the server,
+// in the same way as the JSON proxy.
+func forwardedFor(ctx context.Context, req *http.Request) context.Context {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return ctx
+	}
+	if fwd := req.Header.Get("X-Forwarded-For"); fwd != "" {
+		host = fmt.Sprintf("%s, %s", fwd, host)
+	}
+	return metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", host)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/gameserver"
+)
+
+// TestWebsocketPlay plays a turn over the WebSocket bridge,
+// as the browser client does.
+func TestWebsocketPlay(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	s := gameserver.NewRummyServer(gameserver.DefaultOptions)
+	rummy.RegisterRummyServiceServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+	defer s.Stop()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := rummy.NewRummyServiceClient(conn)
+	ctx := context.Background()
+	proxy := httptest.NewServer(websocketHandler(ctx, client))
+	defer proxy.Close()
+
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var players []*rummy.JoinGameResponse
+	for _, name := range []string{"P0", "P1"} {
+		p, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, p)
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	state, err := client.GetGameState(ctx, &rummy.GetGameStateRequest{
+		GameName:     "game",
+		PlayerId:     players[0].PlayerId,
+		PlayerSecret: players[0].PlayerSecret,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := players[state.CurrentPlayerTurn]
+
+	url := "ws" + strings.TrimPrefix(proxy.URL, "http")
+	ws, err := websocket.Dial(url, "", proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	marshaler := &runtime.JSONPb{OrigName: true}
+	var events []*rummy.GameEvent
+	play := func(req *rummy.PlayRequest) *rummy.ActionResult {
+		msg, err := marshaler.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := websocket.Message.Send(ws, string(msg)); err != nil {
+			t.Fatal(err)
+		}
+		for {
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				t.Fatal(err)
+			}
+			resp := &rummy.PlayResponse{}
+			if err := marshaler.Unmarshal(msg, resp); err != nil {
+				t.Fatalf("invalid response %s: %v", msg, err)
+			}
+			if resp.Result != nil {
+				return resp.Result
+			}
+			events = append(events, resp.Event)
+		}
+	}
+
+	result := play(&rummy.PlayRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: p.PlayerSecret,
+		RequestId:    "pick up",
+		Action:       rummy.PlayerView_PICK_UP_STOCK,
+	})
+	if result.RequestId != "pick up" || result.Error != "" || len(result.Cards) != 1 {
+		t.Fatalf("pick up result = %v, expected one card", result)
+	}
+
+	// A discard without a card is rejected, and the stream goes on.
+	result = play(&rummy.PlayRequest{RequestId: "no card", Action: rummy.PlayerView_DISCARD})
+	if codes.Code(result.Code) != codes.InvalidArgument {
+		t.Errorf("discard without a card returned %v, expected %v", result, codes.InvalidArgument)
+	}
+
+	result = play(&rummy.PlayRequest{
+		RequestId: "discard",
+		Action:    rummy.PlayerView_DISCARD,
+		Card:      result.View.Hand[0],
+	})
+	if result.RequestId != "discard" || result.Error != "" {
+		t.Fatalf("discard result = %v, expected no error", result)
+	}
+	if result.View.GameState.CurrentPlayerTurn == p.PlayerId {
+		t.Errorf("turn did not pass after discarding: %v", result.View.GameState)
+	}
+
+	// The events of the discard are sent before its result.
+	if n := len(events); n < 2 || events[n-2].Type != rummy.GameEvent_DISCARD ||
+		events[n-1].Type != rummy.GameEvent_TURN_START {
+		t.Errorf("received events %v, expected a discard and the next turn last", events)
+	}
+}
@@ -0,0 +1,108 @@
+// gencert generates self-signed certificates for running gamed with
+// TLS: a CA, a certificate for the server, and a certificate for each
+// client that should be allowed to connect when client certificates
+// are required. All are signed by the CA, which clients should trust.
+//
+// If the CA already exists in -dir it is reused, so that certificates
+// for new clients can be added later.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/timpalpant/rummy/tlsutil"
+)
+
+// writeCert writes a certificate and its key to name.pem
+// and name-key.pem in dir.
+func writeCert(dir, name string, certPEM, keyPEM []byte) error {
+	certFile := filepath.Join(dir, name+".pem")
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		return err
+	}
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %v and %v\n", certFile, keyFile)
+	return nil
+}
+
+// loadOrCreateCA returns the CA in dir, creating it if it does not exist.
+func loadOrCreateCA(dir string) (certPEM, keyPEM []byte, err error) {
+	certPEM, err = ioutil.ReadFile(filepath.Join(dir, "ca.pem"))
+	if err == nil {
+		keyPEM, err = ioutil.ReadFile(filepath.Join(dir, "ca-key.pem"))
+		return certPEM, keyPEM, err
+	} else if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	certPEM, keyPEM, err = tlsutil.GenerateCA("rummy CA")
+	if err != nil {
+		return nil, nil, err
+	}
+	return certPEM, keyPEM, writeCert(dir, "ca", certPEM, keyPEM)
+}
+
+func run(dir string, hosts, clients []string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	caCert, caKey, err := loadOrCreateCA(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(hosts) > 0 {
+		cert, key, err := tlsutil.GenerateCert(caCert, caKey, hosts[0], hosts)
+		if err != nil {
+			return err
+		}
+		if err := writeCert(dir, "server", cert, key); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range clients {
+		cert, key, err := tlsutil.GenerateCert(caCert, caKey, name, nil)
+		if err != nil {
+			return err
+		}
+		if err := writeCert(dir, name, cert, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// split returns the non-empty elements of a comma-separated list.
+func split(list string) []string {
+	var result []string
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func main() {
+	dir := flag.String("dir", "certs", "Directory to write certificates to")
+	hosts := flag.String("hosts", "localhost,127.0.0.1",
+		"Comma-separated host names and IP addresses of the server (empty to skip the server certificate)")
+	clients := flag.String("clients", "",
+		"Comma-separated names of clients to generate certificates for")
+	flag.Parse()
+
+	if err := run(*dir, split(*hosts), split(*clients)); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,60 @@
+package gameserver
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+)
+
+// Invite codes are read aloud and typed by hand, so ambiguous
+// characters (0/O, 1/I/L) are omitted.
+const (
+	inviteCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+	inviteCodeLength   = 8
+	secretBytes        = 16
+)
+
+// newInviteCode returns a random code for joining a private game.
+func newInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// newSecret returns a random secret, used to identify the host,
+// players and spectators of a game.
+func newSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// secretsEqual compares two secrets in constant time.
+func secretsEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// checkInviteCode verifies that code may be used to join a game
+// with the given visibility and current invite code.
+// Public games do not require a code.
+func checkInviteCode(private bool, inviteCode, code string) error {
+	if !private {
+		return nil
+	} else if inviteCode == "" {
+		return fmt.Errorf("invite code has been revoked")
+	} else if !secretsEqual(inviteCode, code) {
+		return fmt.Errorf("invalid invite code")
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+// loadtest measures the throughput of the game server with many
+// concurrent tables. Each table is played to completion (or until
+// -max_turns) by players that issue the same RPCs a client would.
+//
+// By default an in-process server is started on a local port;
+// use -server to load test a running gamed instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
+	"github.com/timpalpant/rummy/deck"
+	"github.com/timpalpant/rummy/gameserver"
+)
+
+// stats aggregates the latencies of all RPCs issued by the load test.
+type stats struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    int
+}
+
+func (s *stats) record(start time.Time, err error) {
+	d := time.Since(start)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies = append(s.latencies, d)
+	if err != nil {
+		s.errors++
+	}
+}
+
+func (s *stats) percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	i := int(p * float64(len(s.latencies)-1))
+	return s.latencies[i]
+}
+
+// table plays a single game with the given strategies, one per seat.
+type table struct {
+	client     rummy.RummyServiceClient
+	name       string
+	strategies []strategy.Strategy
+	secrets    []string
+	maxTurns   int
+	stats      *stats
+}
+
+func (t *table) call(f func() error) error {
+	start := time.Now()
+	err := f()
+	t.stats.record(start, err)
+	return err
+}
+
+func (t *table) setUp() error {
+	ctx := context.Background()
+	if err := t.call(func() error {
+		_, err := t.client.CreateGame(ctx, &rummy.CreateGameRequest{
+			GameName: t.name,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	t.secrets = make([]string, len(t.strategies))
+	for i := range t.strategies {
+		if err := t.call(func() error {
+			resp, err := t.client.JoinGame(ctx, &rummy.JoinGameRequest{
+				GameName:   t.name,
+				PlayerName: fmt.Sprintf("P%d", i),
+			})
+			if err == nil {
+				t.secrets[resp.PlayerId] = resp.PlayerSecret
+			}
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	return t.call(func() error {
+		_, err := t.client.StartGame(ctx, &rummy.StartGameRequest{
+			GameName:     t.name,
+			PlayerId:     0,
+			PlayerSecret: t.secrets[0],
+		})
+		return err
+	})
+}
+
+// play runs the game until it is over or maxTurns have been played.
+// It returns the number of turns played.
+func (t *table) play() (int, error) {
+	ctx := context.Background()
+	for turn := 0; turn < t.maxTurns; turn++ {
+		var gs *rummy.GameState
+		if err := t.call(func() error {
+			var err error
+			gs, err = t.client.GetGameState(ctx, &rummy.GetGameStateRequest{
+				GameName: t.name,
+			})
+			return err
+		}); err != nil {
+			return turn, err
+		}
+
+		if gs.GameOver {
+			return turn, nil
+		}
+
+		playerId := gs.CurrentPlayerTurn
+		if err := t.playTurn(ctx, playerId, gs); err != nil {
+			return turn, fmt.Errorf("player %v: %v", playerId, err)
+		}
+	}
+
+	return t.maxTurns, nil
+}
+
+func (t *table) playTurn(ctx context.Context, playerId int32, gs *rummy.GameState) error {
+	strat := t.strategies[playerId]
+	n := strat.PickUpCards(valueSlice(gs.DiscardPile))
+	err := fmt.Errorf("no cards picked up")
+	if n > 0 {
+		err = t.call(func() error {
+			_, err := t.client.PickUpDiscard(ctx, &rummy.PickUpDiscardRequest{
+				GameName:     t.name,
+				PlayerId:     playerId,
+				PlayerSecret: t.secrets[playerId],
+				NCards:       int32(n),
+			})
+			return err
+		})
+	}
+	if err != nil {
+		if err := t.call(func() error {
+			_, err := t.client.PickUpStock(ctx, &rummy.PickUpStockRequest{
+				GameName:     t.name,
+				PlayerId:     playerId,
+				PlayerSecret: t.secrets[playerId],
+			})
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		hand, err := t.hand(ctx, playerId)
+		if err != nil {
+			return err
+		}
+		cards := strat.PlayCards(rummy.NewHand(hand))
+		if len(cards) == 0 {
+			break
+		}
+
+		if err := t.call(func() error {
+			_, err := t.client.PlayCards(ctx, &rummy.PlayCardsRequest{
+				GameName:     t.name,
+				PlayerId:     playerId,
+				PlayerSecret: t.secrets[playerId],
+				Cards:        protoSlice(cards),
+			})
+			return err
+		}); err != nil {
+			break
+		}
+	}
+
+	hand, err := t.hand(ctx, playerId)
+	if err != nil {
+		return err
+	}
+	card := strat.Discard(rummy.NewHand(hand))
+	return t.call(func() error {
+		_, err := t.client.DiscardCard(ctx, &rummy.DiscardCardRequest{
+			GameName:     t.name,
+			PlayerId:     playerId,
+			PlayerSecret: t.secrets[playerId],
+			Card:         &card,
+		})
+		return err
+	})
+}
+
+func (t *table) hand(ctx context.Context, playerId int32) ([]deck.Card, error) {
+	var resp *rummy.GetHandCardsResponse
+	err := t.call(func() error {
+		var err error
+		resp, err = t.client.GetHandCards(ctx, &rummy.GetHandCardsRequest{
+			GameName:     t.name,
+			PlayerId:     playerId,
+			PlayerSecret: t.secrets[playerId],
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return valueSlice(resp.Cards), nil
+}
+
+func valueSlice(cards []*deck.Card) []deck.Card {
+	result := make([]deck.Card, len(cards))
+	for i, c := range cards {
+		result[i] = *c
+	}
+	return result
+}
+
+func protoSlice(cards []deck.Card) []*deck.Card {
+	result := make([]*deck.Card, len(cards))
+	for i := range cards {
+		result[i] = &cards[i]
+	}
+	return result
+}
+
+// subscribe drains the event stream for a game, as an observing
+// client would, until the game is over.
+func subscribe(client rummy.RummyServiceClient, gameName string) {
+	stream, err := client.SubscribeGame(context.Background(), &rummy.SubscribeGameRequest{
+		GameName: gameName,
+	})
+	if err != nil {
+		glog.Warningf("Error subscribing to game %v: %v", gameName, err)
+		return
+	}
+
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return
+		}
+	}
+}
+
+func startLocalServer() (string, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		glog.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	rummyServer := gameserver.NewRummyServer(gameserver.DefaultOptions)
+	rummy.RegisterRummyServiceServer(grpcServer, rummyServer)
+	go grpcServer.Serve(lis)
+	return lis.Addr().String(), func() {
+		grpcServer.Stop()
+		rummyServer.Stop()
+	}
+}
+
+func main() {
+	server := flag.String("server", "", "Game server to load test (default: start one in-process)")
+	numTables := flag.Int("tables", 200, "Number of concurrent tables")
+	numPlayers := flag.Int("players", 4, "Number of players at each table")
+	numSubscribers := flag.Int("subscribers", 1, "Number of event subscribers at each table")
+	stratName := flag.String("strategy", "greedy", "Strategy used by each player")
+	maxTurns := flag.Int("max_turns", 200, "Maximum number of turns to play at each table")
+	seed := flag.Int64("seed", 1, "Seed for random shuffling")
+	flag.Parse()
+
+	rand.Seed(*seed)
+	endpoint := *server
+	if endpoint == "" {
+		var stop func()
+		endpoint, stop = startLocalServer()
+		defer stop()
+	}
+
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+	client := rummy.NewRummyServiceClient(conn)
+
+	runId := time.Now().UnixNano()
+	tables := make([]*table, *numTables)
+	st := &stats{}
+	for i := range tables {
+		strategies := make([]strategy.Strategy, *numPlayers)
+		for j := range strategies {
+			strategies[j], err = strategy.ForName(*stratName)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+
+		tables[i] = &table{
+			client:     client,
+			name:       fmt.Sprintf("loadtest-%d-%d", runId, i),
+			strategies: strategies,
+			maxTurns:   *maxTurns,
+			stats:      st,
+		}
+	}
+
+	glog.Infof("Playing %v tables with %v players each", *numTables, *numPlayers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	totalTurns, completed, failed := 0, 0, 0
+	start := time.Now()
+	for _, t := range tables {
+		wg.Add(1)
+		go func(t *table) {
+			defer wg.Done()
+			if err := t.setUp(); err != nil {
+				glog.Errorf("Error setting up table %v: %v", t.name, err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+
+			for i := 0; i < *numSubscribers; i++ {
+				go subscribe(client, t.name)
+			}
+
+			turns, err := t.play()
+			mu.Lock()
+			defer mu.Unlock()
+			totalTurns += turns
+			if err != nil {
+				glog.Errorf("Error playing table %v: %v", t.name, err)
+				failed++
+			} else if turns < t.maxTurns {
+				completed++
+			}
+		}(t)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(st.latencies, func(i, j int) bool {
+		return st.latencies[i] < st.latencies[j]
+	})
+	nRPCs := len(st.latencies)
+	fmt.Println("\nResults:")
+	fmt.Printf("Tables: %v (%v completed, %v failed)\n", *numTables, completed, failed)
+	fmt.Printf("Elapsed: %v\n", elapsed)
+	fmt.Printf("Turns: %v (%.1f turns/s)\n", totalTurns, float64(totalTurns)/elapsed.Seconds())
+	fmt.Printf("RPCs: %v (%.1f RPCs/s), %v errors\n", nRPCs, float64(nRPCs)/elapsed.Seconds(), st.errors)
+	fmt.Printf("Latency: p50 %v, p90 %v, p99 %v, max %v\n",
+		st.percentile(0.5), st.percentile(0.9), st.percentile(0.99), st.percentile(1))
+}
@@ -0,0 +1,379 @@
+package gameserver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+)
+
+// Computer players seated by the matchmaker are named botNamePrefix
+// followed by their seat number and strategy, so player names that
+// start with botNamePrefix and a digit are reserved for them.
+const botNamePrefix = "CP"
+
+// isReservedName returns true if name could be that of a computer
+// player seated by the matchmaker.
+func isReservedName(name string) bool {
+	rest := strings.TrimPrefix(name, botNamePrefix)
+	return len(rest) < len(name) && rest != "" && rest[0] >= '0' && rest[0] <= '9'
+}
+
+// matchKey identifies the kind of table a player is waiting for.
+// Players are only matched with others who want the same kind of table.
+type matchKey struct {
+	numPlayers   int32
+	rulesVariant rummy.GameOptions_RulesVariant
+	allowBots    bool
+}
+
+// matchRequest is a player waiting in the matchmaking queue.
+type matchRequest struct {
+	playerName   string
+	playerSecret string
+	// If set, the player has been authenticated as this account.
+	accountName string
+	enqueuedAt  time.Time
+	// Set once the player has been seated, or the match has failed.
+	result *rummy.FindMatchResponse
+	err    error
+	// Signalled whenever the queue or the result changes.
+	changed chan struct{}
+}
+
+func (mr *matchRequest) notify() {
+	select {
+	case mr.changed <- struct{}{}:
+	default:
+	}
+}
+
+// matchmaker holds the queues of players waiting for a match.
+type matchmaker struct {
+	mu     sync.Mutex
+	queues map[matchKey][]*matchRequest
+	// Used to generate the names of matched games.
+	nextGame int
+}
+
+func newMatchmaker() *matchmaker {
+	return &matchmaker{
+		queues: make(map[matchKey][]*matchRequest),
+	}
+}
+
+// enqueue adds a player to the queue for the given kind of table.
+// If the table is now full, the players to seat at it are removed
+// from the queue and returned. Since players at the same table must
+// have different names and accounts, a player is rejected if another
+// with the same name or account is already waiting for the same kind
+// of table.
+func (mm *matchmaker) enqueue(key matchKey, mr *matchRequest) ([]*matchRequest, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for _, other := range mm.queues[key] {
+		if other.playerName == mr.playerName {
+			return nil, fmt.Errorf("a player named %v is already waiting for a match", mr.playerName)
+		} else if mr.accountName != "" && other.accountName == mr.accountName {
+			return nil, fmt.Errorf("account %v is already waiting for a match", mr.accountName)
+		}
+	}
+
+	queue := append(mm.queues[key], mr)
+	if int32(len(queue)) < key.numPlayers {
+		mm.queues[key] = queue
+		notifyAll(queue)
+		return nil, nil
+	}
+
+	mm.queues[key] = queue[key.numPlayers:]
+	notifyAll(mm.queues[key])
+	return queue[:key.numPlayers], nil
+}
+
+// remove takes a player out of the queue, e.g. because they stopped
+// waiting. It returns false if they are no longer in the queue.
+func (mm *matchmaker) remove(key matchKey, mr *matchRequest) bool {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	queue := mm.queues[key]
+	for i, other := range queue {
+		if other == mr {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			mm.queues[key] = queue
+			notifyAll(queue)
+			return true
+		}
+	}
+
+	return false
+}
+
+// expired removes and returns the players in each queue that allows
+// computer players, if the first of them has been waiting since
+// before cutoff.
+func (mm *matchmaker) expired(cutoff time.Time) map[matchKey][]*matchRequest {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	result := make(map[matchKey][]*matchRequest)
+	for key, queue := range mm.queues {
+		if key.allowBots && len(queue) > 0 && queue[0].enqueuedAt.Before(cutoff) {
+			result[key] = queue
+			delete(mm.queues, key)
+		}
+	}
+
+	return result
+}
+
+// status returns the number of players in the queue for the
+// given kind of table, and the result of the given request if it has
+// been matched.
+func (mm *matchmaker) status(key matchKey, mr *matchRequest) (int32, *rummy.FindMatchResponse, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	return int32(len(mm.queues[key])), mr.result, mr.err
+}
+
+// finish records the result of a match for each of the given players.
+func (mm *matchmaker) finish(players []*matchRequest, results []*rummy.FindMatchResponse, err error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for i, mr := range players {
+		if err != nil {
+			mr.err = err
+		} else {
+			mr.result = results[i]
+		}
+		mr.notify()
+	}
+}
+
+// gameName returns a name for the next matched game.
+func (mm *matchmaker) gameName() string {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.nextGame++
+	return fmt.Sprintf("match-%d-%d", time.Now().Unix(), mm.nextGame)
+}
+
+// drain removes every player from the queues, failing their requests
+// with err.
+func (mm *matchmaker) drain(err error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for key, queue := range mm.queues {
+		for _, mr := range queue {
+			mr.err = err
+			mr.notify()
+		}
+		delete(mm.queues, key)
+	}
+}
+
+func notifyAll(queue []*matchRequest) {
+	for _, mr := range queue {
+		mr.notify()
+	}
+}
+
+func (s *RummyServer) FindMatch(req *rummy.FindMatchRequest, stream rummy.RummyService_FindMatchServer) error {
+	glog.V(1).Infof("FindMatch: %v", req)
+	key := matchKey{
+		numPlayers:   req.NumPlayers,
+		rulesVariant: req.RulesVariant,
+		allowBots:    req.AllowBots,
+	}
+	if key.numPlayers == 0 {
+		key.numPlayers = 2
+	}
+	if req.PlayerName == "" {
+		return fmt.Errorf("a player name is required")
+	} else if isReservedName(req.PlayerName) {
+		return fmt.Errorf("names starting with %v and a number are reserved for computer players", botNamePrefix)
+	} else if key.numPlayers < 2 {
+		return fmt.Errorf("a match must have at least 2 players")
+	} else if _, err := matchOptions(key); err != nil {
+		return err
+	} else if key.allowBots && s.opts.MatchStrategy == "" {
+		return fmt.Errorf("computer players are not available for matches")
+	}
+	if req.AccountName != "" {
+		if err := s.authenticateAccount(req.AccountName, req.AccountToken); err != nil {
+			return err
+		}
+	}
+
+	secret := req.PlayerSecret
+	if secret == "" {
+		var err error
+		secret, err = newSecret()
+		if err != nil {
+			return err
+		}
+	}
+
+	s.gamesMu.Lock()
+	shuttingDown := s.shuttingDown
+	s.gamesMu.Unlock()
+	if shuttingDown {
+		return fmt.Errorf("server is shutting down")
+	}
+
+	mr := &matchRequest{
+		playerName:   req.PlayerName,
+		playerSecret: secret,
+		accountName:  req.AccountName,
+		enqueuedAt:   time.Now(),
+		changed:      make(chan struct{}, 1),
+	}
+	players, err := s.matchmaker.enqueue(key, mr)
+	if err != nil {
+		return err
+	} else if players != nil {
+		s.startMatch(key, players)
+	}
+
+	var lastWaiting int32
+	left := false
+	for {
+		waiting, result, err := s.matchmaker.status(key, mr)
+		if err != nil {
+			return err
+		} else if result != nil && left {
+			s.leaveMatch(result)
+			return stream.Context().Err()
+		} else if result != nil {
+			if err := stream.Send(result); err != nil {
+				s.leaveMatch(result)
+				return err
+			}
+			return nil
+		} else if waiting != lastWaiting && !left {
+			lastWaiting = waiting
+			if err := stream.Send(&rummy.FindMatchResponse{
+				PlayersWaiting: waiting,
+			}); err != nil {
+				s.matchmaker.remove(key, mr)
+				return err
+			}
+		}
+
+		if left {
+			<-mr.changed
+			continue
+		}
+		select {
+		case <-mr.changed:
+		case <-stream.Context().Done():
+			if s.matchmaker.remove(key, mr) {
+				return stream.Context().Err()
+			}
+			// We were matched while the client was leaving; wait for
+			// the result so that their seat can be given up.
+			left = true
+		}
+	}
+}
+
+// leaveMatch forfeits the seat of a player who left before
+// they were sent the result of their match, so that the other
+// players are not left waiting for them.
+func (s *RummyServer) leaveMatch(result *rummy.FindMatchResponse) {
+	glog.Infof("Player %v left game %v before joining it", result.PlayerId, result.GameName)
+	_, err := s.LeaveGame(context.Background(), &rummy.LeaveGameRequest{
+		GameName:     result.GameName,
+		PlayerId:     result.PlayerId,
+		PlayerSecret: result.PlayerSecret,
+	})
+	if err != nil {
+		glog.Errorf("Error leaving game %v for player %v: %v",
+			result.GameName, result.PlayerId, err)
+	}
+}
+
+// fillMatches seats the players who have waited longer than the
+// server's match timeout for a table that allows computer players,
+// and fills the remaining seats with computer players.
+func (s *RummyServer) fillMatches(now time.Time) {
+	if s.opts.MatchTimeout == 0 || s.opts.MatchStrategy == "" {
+		return
+	}
+
+	for key, players := range s.matchmaker.expired(now.Add(-s.opts.MatchTimeout)) {
+		s.startMatch(key, players)
+	}
+}
+
+// startMatch creates a game for the given players, filling any
+// remaining seats with computer players, and starts it.
+func (s *RummyServer) startMatch(key matchKey, players []*matchRequest) {
+	results, err := s.createMatch(key, players)
+	if err != nil {
+		glog.Errorf("Error starting match for %v players: %v", len(players), err)
+	}
+	s.matchmaker.finish(players, results, err)
+}
+
+func (s *RummyServer) createMatch(key matchKey, players []*matchRequest) ([]*rummy.FindMatchResponse, error) {
+	opts, err := matchOptions(key)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := make([]seat, key.numPlayers)
+	for i := range seats {
+		if i < len(players) {
+			seats[i] = seat{
+				name:    players[i].playerName,
+				secret:  players[i].playerSecret,
+				account: players[i].accountName,
+			}
+		} else {
+			seats[i] = seat{
+				name:     fmt.Sprintf("%v%d-%v", botNamePrefix, i, s.opts.MatchStrategy),
+				strategy: s.opts.MatchStrategy,
+			}
+		}
+	}
+
+	glog.Infof("Starting match with %v players and %v computer players",
+		len(players), int(key.numPlayers)-len(players))
+	name, err := s.startGame(s.matchmaker.gameName(), opts, seats)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*rummy.FindMatchResponse, len(players))
+	for i, mr := range players {
+		results[i] = &rummy.FindMatchResponse{
+			GameName:     name,
+			PlayerId:     int32(i),
+			PlayerSecret: mr.playerSecret,
+		}
+	}
+	return results, nil
+}
+
+// matchOptions returns the options for a game matching key.
+// Matched games are private, with no invite code, so that
+// no one else may join them.
+func matchOptions(key matchKey) (*rummy.GameOptions, error) {
+	opts := rummy.DefaultGameOptions()
+	opts.MinPlayers = key.numPlayers
+	opts.MaxPlayers = key.numPlayers
+	opts.RulesVariant = key.rulesVariant
+	opts.DisallowBots = !key.allowBots
+	opts.Visibility = rummy.GameOptions_PRIVATE
+	return rummy.NormalizeGameOptions(opts)
+}
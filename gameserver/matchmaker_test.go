@@ -0,0 +1,150 @@
+package gameserver
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestIsReservedName(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved bool
+	}{
+		{"CP1-greedy", true},
+		{"CP0", true},
+		{"CP", false},
+		{"CPU", false},
+		{"alice", false},
+		{"cp1", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		if got := isReservedName(tc.name); got != tc.reserved {
+			t.Errorf("isReservedName(%q) = %v, expected %v", tc.name, got, tc.reserved)
+		}
+	}
+}
+
+func TestEnqueueRejectsDuplicates(t *testing.T) {
+	key := matchKey{numPlayers: 3}
+	tests := []struct {
+		name    string
+		player  string
+		account string
+		ok      bool
+	}{
+		{"first player", "alice", "alice-account", true},
+		{"same name", "alice", "", false},
+		{"same account", "bob", "alice-account", false},
+		{"different player", "bob", "bob-account", true},
+	}
+
+	mm := newMatchmaker()
+	for _, tc := range tests {
+		mr := &matchRequest{
+			playerName:  tc.player,
+			accountName: tc.account,
+			changed:     make(chan struct{}, 1),
+		}
+		_, err := mm.enqueue(key, mr)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: enqueue returned %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+	}
+
+	// The same name may wait for a different kind of table.
+	mr := &matchRequest{playerName: "alice", changed: make(chan struct{}, 1)}
+	if _, err := mm.enqueue(matchKey{numPlayers: 2}, mr); err != nil {
+		t.Errorf("enqueue for a different table returned %v", err)
+	}
+
+	// Once a table is assembled, the names are free again.
+	mr = &matchRequest{playerName: "carol", changed: make(chan struct{}, 1)}
+	players, err := mm.enqueue(key, mr)
+	if err != nil || len(players) != 3 {
+		t.Fatalf("enqueue returned %v players, %v; expected a full table", len(players), err)
+	}
+	mr = &matchRequest{playerName: "alice", changed: make(chan struct{}, 1)}
+	if _, err := mm.enqueue(key, mr); err != nil {
+		t.Errorf("enqueue after the table was assembled returned %v", err)
+	}
+}
+
+// testFindMatchStream is a FindMatch stream whose client
+// is gone once its context is done.
+type testFindMatchStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *rummy.FindMatchResponse
+}
+
+func (ss *testFindMatchStream) Context() context.Context {
+	return ss.ctx
+}
+
+func (ss *testFindMatchStream) Send(resp *rummy.FindMatchResponse) error {
+	if err := ss.ctx.Err(); err != nil {
+		return err
+	}
+	ss.responses <- resp
+	return nil
+}
+
+func TestFindMatchLeftAfterMatched(t *testing.T) {
+	s, client := startTestServer(t, DefaultOptions)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testFindMatchStream{
+		ctx:       ctx,
+		responses: make(chan *rummy.FindMatchResponse, 10),
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.FindMatch(&rummy.FindMatchRequest{PlayerName: "P0"}, stream)
+	}()
+	if resp := <-stream.responses; resp.PlayersWaiting != 1 {
+		t.Fatalf("first response %v, expected one player waiting", resp)
+	}
+
+	// The table is assembled, and the first player leaves
+	// before the game is started.
+	key := matchKey{numPlayers: 2}
+	mr := &matchRequest{playerName: "P1", playerSecret: "secret", changed: make(chan struct{}, 1)}
+	players, err := s.matchmaker.enqueue(key, mr)
+	if err != nil || len(players) != 2 {
+		t.Fatalf("enqueue returned %v players, %v; expected a full table", len(players), err)
+	}
+	cancel()
+	s.startMatch(key, players)
+	if err := <-done; err == nil {
+		t.Error("FindMatch succeeded after the client left")
+	}
+
+	// The player who left forfeits, so the other player is not
+	// left waiting for them.
+	_, result, err := s.matchmaker.status(key, mr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := client.GetGameState(context.Background(), &rummy.GetGameStateRequest{
+		GameName:     result.GameName,
+		IsPlayer:     true,
+		PlayerId:     result.PlayerId,
+		PlayerSecret: result.PlayerSecret,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range state.Players {
+		if forfeited := p.Name == "P0"; p.Forfeited != forfeited {
+			t.Errorf("player %v forfeited = %v, expected %v", p.Name, p.Forfeited, forfeited)
+		}
+	}
+	if state.Status != rummy.GameState_COMPLETED {
+		t.Errorf("game is %v, expected the remaining player to win", state.Status)
+	}
+}
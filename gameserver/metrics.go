@@ -0,0 +1,135 @@
+package gameserver
+
+import (
+	"path"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+)
+
+// metrics are the Prometheus metrics of a RummyServer.
+type metrics struct {
+	rpcs            *prometheus.CounterVec
+	rpcDuration     *prometheus.HistogramVec
+	subscribers     *prometheus.GaugeVec
+	botTurnDuration *prometheus.HistogramVec
+	gamesCompleted  prometheus.Counter
+	games           *prometheus.Desc
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		rpcs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rummy_rpcs_total",
+			Help: "Number of RPCs handled, by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rummy_rpc_duration_seconds",
+			Help:    "Time taken to handle RPCs, or that streams were open, by method.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 12),
+		}, []string{"method"}),
+		subscribers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rummy_subscribers",
+			Help: "Number of open subscriptions to game events, by whether the " +
+				"subscriber is a player, a computer player or a spectator.",
+		}, []string{"role"}),
+		botTurnDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rummy_bot_turn_duration_seconds",
+			Help:    "Time taken by computer players to complete their turns, by strategy.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"strategy"}),
+		gamesCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rummy_games_completed_total",
+			Help: "Number of games that have been played to completion.",
+		}),
+		games: prometheus.NewDesc("rummy_games",
+			"Number of games on the server, by status.", []string{"status"}, nil),
+	}
+}
+
+// gamesCollector reports the number of games on the server
+// by status each time the metrics are collected.
+type gamesCollector RummyServer
+
+func (c *gamesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.metrics.games
+}
+
+func (c *gamesCollector) Collect(ch chan<- prometheus.Metric) {
+	s := (*RummyServer)(c)
+	s.gamesMu.Lock()
+	games := s.allGames()
+	s.gamesMu.Unlock()
+
+	// Games that are busy are counted by the status they had when
+	// last collected, rather than waiting for their locks.
+	counts := make(map[rummy.GameState_Status]int)
+	for _, sg := range games {
+		if sg.mu.TryLock() {
+			atomic.StoreInt32(&sg.lastStatus, int32(sg.game.Status()))
+			sg.mu.Unlock()
+		}
+		counts[rummy.GameState_Status(atomic.LoadInt32(&sg.lastStatus))]++
+	}
+
+	for value, name := range rummy.GameState_Status_name {
+		status := rummy.GameState_Status(value)
+		ch <- prometheus.MustNewConstMetric(s.metrics.games,
+			prometheus.GaugeValue, float64(counts[status]), name)
+	}
+}
+
+// registerMetrics registers the server's metrics with r.
+func (s *RummyServer) registerMetrics(r prometheus.Registerer) {
+	m := s.metrics
+	r.MustRegister(m.rpcs, m.rpcDuration, m.subscribers,
+		m.botTurnDuration, m.gamesCompleted, (*gamesCollector)(s))
+}
+
+// UnaryInterceptor returns a gRPC interceptor that records
+// the count, duration and status of unary RPCs.
+func (s *RummyServer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		s.observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that records
+// the count, duration and status of streaming RPCs.
+func (s *RummyServer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		s.observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (s *RummyServer) observeRPC(fullMethod string, start time.Time, err error) {
+	method := path.Base(fullMethod)
+	s.metrics.rpcs.WithLabelValues(method, status.Code(err).String()).Inc()
+	s.metrics.rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// observeBotTurn records how long a computer player took over its turn,
+// if the request it made during the given turn ended the turn.
+// Must be called while holding sg.mu.
+func (s *RummyServer) observeBotTurn(ctx context.Context, sg *serverGame, playerId int32, turn int, startedAt time.Time) {
+	if !isBotRequest(ctx) {
+		return
+	} else if sg.game.Turn() == turn && sg.game.Status() == rummy.GameState_IN_PROGRESS {
+		return
+	}
+
+	s.metrics.botTurnDuration.WithLabelValues(sg.strategies[playerId]).
+		Observe(time.Since(startedAt).Seconds())
+}
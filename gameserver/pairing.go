@@ -0,0 +1,171 @@
+package gameserver
+
+import (
+	"sort"
+
+	"github.com/timpalpant/rummy"
+)
+
+// bye is paired with an entrant who does not play in a round.
+const bye = -1
+
+// pairing is two entrants (by index) who play each other in a round.
+// The second entrant may be a bye.
+type pairing [2]int32
+
+// numRounds returns the number of rounds needed for a tournament with
+// n entrants in the given format. The number of Swiss rounds is the
+// number needed to determine a single winner.
+func numRounds(format rummy.Tournament_Format, n int) int32 {
+	switch format {
+	case rummy.Tournament_ROUND_ROBIN:
+		if n%2 == 1 {
+			return int32(n)
+		}
+		return int32(n - 1)
+	default:
+		rounds := int32(0)
+		for size := 1; size < n; size *= 2 {
+			rounds++
+		}
+		return rounds
+	}
+}
+
+// roundRobinPairings returns the pairings for the given round (starting
+// from 1) of a round robin among n entrants, using the circle method:
+// the first entrant stays in place while the others rotate around them.
+func roundRobinPairings(n int, round int32) []pairing {
+	entrants := make([]int32, 0, n+1)
+	for i := 0; i < n; i++ {
+		entrants = append(entrants, int32(i))
+	}
+	if n%2 == 1 {
+		entrants = append(entrants, bye)
+	}
+
+	m := len(entrants)
+	rotated := make([]int32, m)
+	rotated[0] = entrants[0]
+	for i := 1; i < m; i++ {
+		rotated[1+(i-1+int(round)-1)%(m-1)] = entrants[i]
+	}
+
+	pairings := make([]pairing, m/2)
+	for i := range pairings {
+		pairings[i] = orderPairing(rotated[i], rotated[m-1-i])
+	}
+	return pairings
+}
+
+// bracketOrder returns the seeds (starting from 0) in the order that
+// they are placed in a single elimination bracket of the given size,
+// which must be a power of two, so that the top seeds can only meet
+// in the later rounds.
+func bracketOrder(size int) []int32 {
+	order := []int32{0}
+	for len(order) < size {
+		n := int32(2 * len(order))
+		next := make([]int32, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// eliminationPairings returns the pairings for the first round of a
+// single elimination bracket among n entrants. Top seeds receive byes
+// if n is not a power of two.
+func eliminationPairings(n int) []pairing {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+
+	order := bracketOrder(size)
+	pairings := make([]pairing, size/2)
+	for i := range pairings {
+		a, b := order[2*i], order[2*i+1]
+		if int(b) >= n {
+			b = bye
+		}
+		pairings[i] = pairing{a, b}
+	}
+	return pairings
+}
+
+// advancePairings pairs the winners of consecutive matches in the
+// previous round of a single elimination bracket.
+func advancePairings(winners []int32) []pairing {
+	pairings := make([]pairing, 0, len(winners)/2)
+	for i := 0; i+1 < len(winners); i += 2 {
+		pairings = append(pairings, orderPairing(winners[i], winners[i+1]))
+	}
+	return pairings
+}
+
+// swissPairings pairs entrants with similar records. Entrants are
+// considered in order of their standings, and each is paired with the
+// next entrant they have not yet played, if any. If there are an odd
+// number of entrants, the lowest-ranked entrant who has not yet had
+// a bye receives one.
+func swissPairings(standings []int32, played map[pairing]bool, hadBye map[int32]bool) []pairing {
+	remaining := append([]int32(nil), standings...)
+	var pairings []pairing
+	if len(remaining)%2 == 1 {
+		byeIndex := len(remaining) - 1
+		for i := len(remaining) - 1; i >= 0; i-- {
+			if !hadBye[remaining[i]] {
+				byeIndex = i
+				break
+			}
+		}
+		pairings = append(pairings, pairing{remaining[byeIndex], bye})
+		remaining = append(remaining[:byeIndex], remaining[byeIndex+1:]...)
+	}
+
+	for len(remaining) > 0 {
+		a := remaining[0]
+		opponent := 1
+		for i := 1; i < len(remaining); i++ {
+			if !played[orderPairing(a, remaining[i])] {
+				opponent = i
+				break
+			}
+		}
+
+		pairings = append(pairings, orderPairing(a, remaining[opponent]))
+		remaining = append(remaining[1:opponent], remaining[opponent+1:]...)
+	}
+
+	return pairings
+}
+
+// orderPairing puts the higher seed (lower index) first,
+// with byes last.
+func orderPairing(a, b int32) pairing {
+	if a == bye || (b != bye && b < a) {
+		a, b = b, a
+	}
+	return pairing{a, b}
+}
+
+// standings returns the indices of the entrants ordered by wins,
+// then points, then seed.
+func standings(entrants []*rummy.Tournament_Entrant) []int32 {
+	result := make([]int32, len(entrants))
+	for i := range result {
+		result[i] = int32(i)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := entrants[result[i]], entrants[result[j]]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Points > b.Points
+	})
+	return result
+}
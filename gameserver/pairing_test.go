@@ -0,0 +1,172 @@
+package gameserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestNumRounds(t *testing.T) {
+	tests := []struct {
+		format   rummy.Tournament_Format
+		n        int
+		expected int32
+	}{
+		{rummy.Tournament_ROUND_ROBIN, 2, 1},
+		{rummy.Tournament_ROUND_ROBIN, 3, 3},
+		{rummy.Tournament_ROUND_ROBIN, 4, 3},
+		{rummy.Tournament_SINGLE_ELIMINATION, 2, 1},
+		{rummy.Tournament_SINGLE_ELIMINATION, 5, 3},
+		{rummy.Tournament_SINGLE_ELIMINATION, 8, 3},
+		{rummy.Tournament_SWISS, 4, 2},
+		{rummy.Tournament_SWISS, 9, 4},
+	}
+
+	for _, tc := range tests {
+		if result := numRounds(tc.format, tc.n); result != tc.expected {
+			t.Errorf("numRounds(%v, %v) = %v, expected %v", tc.format, tc.n, result, tc.expected)
+		}
+	}
+}
+
+func TestRoundRobinPairings(t *testing.T) {
+	tests := []struct {
+		n        int
+		round    int32
+		expected []pairing
+	}{
+		{2, 1, []pairing{{0, 1}}},
+		{3, 1, []pairing{{0, bye}, {1, 2}}},
+		{3, 2, []pairing{{0, 2}, {1, bye}}},
+		{4, 1, []pairing{{0, 3}, {1, 2}}},
+		{4, 2, []pairing{{0, 2}, {1, 3}}},
+		{4, 3, []pairing{{0, 1}, {2, 3}}},
+	}
+
+	for _, tc := range tests {
+		result := roundRobinPairings(tc.n, tc.round)
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("roundRobinPairings(%v, %v) = %v, expected %v", tc.n, tc.round, result, tc.expected)
+		}
+	}
+}
+
+func TestRoundRobinPlaysEveryPairOnce(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		played := make(map[pairing]int)
+		for round := int32(1); round <= numRounds(rummy.Tournament_ROUND_ROBIN, n); round++ {
+			seen := make(map[int32]bool)
+			for _, p := range roundRobinPairings(n, round) {
+				for _, e := range p {
+					if e != bye && seen[e] {
+						t.Errorf("n = %v: entrant %v plays twice in round %v", n, e, round)
+					}
+					seen[e] = true
+				}
+				played[p]++
+			}
+		}
+
+		for a := int32(0); a < int32(n); a++ {
+			for b := a + 1; b < int32(n); b++ {
+				if played[pairing{a, b}] != 1 {
+					t.Errorf("n = %v: %v and %v play %v times, expected 1",
+						n, a, b, played[pairing{a, b}])
+				}
+			}
+		}
+	}
+}
+
+func TestEliminationPairings(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected []pairing
+	}{
+		{2, []pairing{{0, 1}}},
+		{3, []pairing{{0, bye}, {1, 2}}},
+		{4, []pairing{{0, 3}, {1, 2}}},
+		{5, []pairing{{0, bye}, {3, 4}, {1, bye}, {2, bye}}},
+	}
+
+	for _, tc := range tests {
+		result := eliminationPairings(tc.n)
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("eliminationPairings(%v) = %v, expected %v", tc.n, result, tc.expected)
+		}
+	}
+}
+
+func TestAdvancePairings(t *testing.T) {
+	tests := []struct {
+		winners  []int32
+		expected []pairing
+	}{
+		{[]int32{0, 1}, []pairing{{0, 1}}},
+		{[]int32{3, 1, 0, 2}, []pairing{{1, 3}, {0, 2}}},
+		{[]int32{0}, []pairing{}},
+	}
+
+	for _, tc := range tests {
+		result := advancePairings(tc.winners)
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("advancePairings(%v) = %v, expected %v", tc.winners, result, tc.expected)
+		}
+	}
+}
+
+func TestSwissPairings(t *testing.T) {
+	tests := []struct {
+		name      string
+		standings []int32
+		played    []pairing
+		hadBye    []int32
+		expected  []pairing
+	}{
+		{"first round", []int32{0, 1, 2, 3}, nil, nil,
+			[]pairing{{0, 1}, {2, 3}}},
+		{"by standings", []int32{2, 0, 3, 1}, nil, nil,
+			[]pairing{{0, 2}, {1, 3}}},
+		{"no rematches", []int32{0, 1, 2, 3}, []pairing{{0, 1}}, nil,
+			[]pairing{{0, 2}, {1, 3}}},
+		{"rematch if all played", []int32{0, 1, 2, 3}, []pairing{{0, 1}, {0, 2}, {0, 3}}, nil,
+			[]pairing{{0, 1}, {2, 3}}},
+		{"bye to lowest", []int32{0, 1, 2}, nil, nil,
+			[]pairing{{2, bye}, {0, 1}}},
+		{"one bye each", []int32{0, 1, 2}, nil, []int32{2},
+			[]pairing{{1, bye}, {0, 2}}},
+		{"bye to lowest if all had one", []int32{0, 1, 2}, nil, []int32{0, 1, 2},
+			[]pairing{{2, bye}, {0, 1}}},
+	}
+
+	for _, tc := range tests {
+		played := make(map[pairing]bool)
+		for _, p := range tc.played {
+			played[p] = true
+		}
+		hadBye := make(map[int32]bool)
+		for _, e := range tc.hadBye {
+			hadBye[e] = true
+		}
+
+		result := swissPairings(tc.standings, played, hadBye)
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("%v: swissPairings returned %v, expected %v", tc.name, result, tc.expected)
+		}
+	}
+}
+
+func TestStandings(t *testing.T) {
+	entrants := []*rummy.Tournament_Entrant{
+		{Wins: 1, Points: 100},
+		{Wins: 2, Points: 50},
+		{Wins: 1, Points: 150},
+		{Wins: 1, Points: 100},
+	}
+	expected := []int32{1, 2, 0, 3}
+
+	if result := standings(entrants); !reflect.DeepEqual(result, expected) {
+		t.Errorf("standings = %v, expected %v", result, expected)
+	}
+}
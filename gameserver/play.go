@@ -0,0 +1,327 @@
+package gameserver
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/deck"
+)
+
+// Play serves a player's actions and the game's events on a single
+// stream. Actions are taken one at a time in the order they are received,
+// and the result of each is sent after all of the events that were
+// published before it finished, so the client sees a single total order.
+//
+// The stream is closed once the game is over and the results of any
+// actions in progress have been sent, or once the client closes its
+// side and all results have been sent.
+func (s *RummyServer) Play(stream rummy.RummyService_PlayServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	glog.V(1).Infof("Play: %v", first)
+
+	ctx := stream.Context()
+	sub, history, err := s.subscribe(ctx, &rummy.SubscribeGameRequest{
+		GameName:     first.GameName,
+		FromSequence: first.FromSequence,
+		IsPlayer:     true,
+		PlayerId:     first.PlayerId,
+		PlayerSecret: first.PlayerSecret,
+	})
+	if err != nil {
+		return err
+	}
+	defer s.unsubscribe(sub)
+
+	for _, e := range history {
+		if err := stream.Send(&rummy.PlayResponse{Event: e}); err != nil {
+			return err
+		}
+	}
+	lastSent := sub.lastSequence
+
+	ps := newPlaySession()
+	go s.takeActions(ctx, stream, ps, sub.sg, first)
+
+	// Results wait here until the events preceding them have been sent.
+	var pending []*playResult
+	sendReady := func(final bool) error {
+		for len(pending) > 0 {
+			r := pending[0]
+			if !final && r.lastSequence > lastSent {
+				break
+			}
+			if err := stream.Send(&rummy.PlayResponse{Result: r.result}); err != nil {
+				return err
+			}
+			pending = pending[1:]
+		}
+		return nil
+	}
+
+	clientDone := false
+	for !clientDone || len(pending) > 0 {
+		select {
+		case e, ok := <-sub.events:
+			if !ok {
+				if err := s.checkFellBehind(sub, lastSent); err != nil {
+					// Results of actions that are not sent can be
+					// retrieved by retrying them with the same request id.
+					ps.close()
+					return err
+				}
+				// No more events will be published, so all remaining
+				// results are ready once the action in progress finishes.
+				pending = append(pending, ps.close()...)
+				return sendReady(true)
+			}
+			if err := stream.Send(&rummy.PlayResponse{Event: e}); err != nil {
+				return err
+			}
+			if e.Sequence > lastSent {
+				lastSent = e.Sequence
+			}
+		case <-ps.notify:
+			pending = append(pending, ps.take()...)
+		case err := <-ps.done:
+			if err != io.EOF {
+				return err
+			}
+			clientDone = true
+			pending = append(pending, ps.take()...)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if err := sendReady(false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// takeActions receives the client's requests on a Play stream and takes
+// the requested actions, until the client closes its side of the stream
+// or the session is closed.
+func (s *RummyServer) takeActions(ctx context.Context, stream rummy.RummyService_PlayServer, ps *playSession, sg *serverGame, first *rummy.PlayRequest) {
+	req := first
+	for {
+		if !ps.begin() {
+			return
+		}
+		result := s.takeAction(ctx, first, req)
+		ps.finish(&playResult{result, resultSequence(sg, result)})
+
+		var err error
+		req, err = stream.Recv()
+		if err != nil {
+			ps.done <- err
+			return
+		}
+	}
+}
+
+// takeAction takes the requested action as the player identified by
+// the first request on the stream.
+func (s *RummyServer) takeAction(ctx context.Context, first, req *rummy.PlayRequest) *rummy.ActionResult {
+	result := &rummy.ActionResult{RequestId: req.RequestId}
+
+	var err error
+	switch req.Action {
+	case rummy.PlayerView_UNKNOWN_ACTION:
+	case rummy.PlayerView_PICK_UP_STOCK:
+		var resp *rummy.PickUpStockResponse
+		resp, err = s.PickUpStock(ctx, &rummy.PickUpStockRequest{
+			GameName:         first.GameName,
+			PlayerId:         first.PlayerId,
+			PlayerSecret:     first.PlayerSecret,
+			RequestId:        req.RequestId,
+			ExpectedSequence: req.ExpectedSequence,
+			CheckTurn:        req.CheckTurn,
+			ExpectedTurn:     req.ExpectedTurn,
+		})
+		if err == nil {
+			result.Cards = []*deck.Card{resp.Card}
+		}
+	case rummy.PlayerView_PICK_UP_DISCARD:
+		var resp *rummy.PickUpDiscardResponse
+		resp, err = s.PickUpDiscard(ctx, &rummy.PickUpDiscardRequest{
+			GameName:         first.GameName,
+			PlayerId:         first.PlayerId,
+			PlayerSecret:     first.PlayerSecret,
+			NCards:           req.NCards,
+			RequestId:        req.RequestId,
+			ExpectedSequence: req.ExpectedSequence,
+			CheckTurn:        req.CheckTurn,
+			ExpectedTurn:     req.ExpectedTurn,
+		})
+		if err == nil {
+			result.Cards = resp.Cards
+		}
+	case rummy.PlayerView_PLAY_CARDS:
+		var resp *rummy.PlayCardsResponse
+		resp, err = s.PlayCards(ctx, &rummy.PlayCardsRequest{
+			GameName:         first.GameName,
+			PlayerId:         first.PlayerId,
+			PlayerSecret:     first.PlayerSecret,
+			Cards:            req.Cards,
+			RequestId:        req.RequestId,
+			ExpectedSequence: req.ExpectedSequence,
+			CheckTurn:        req.CheckTurn,
+			ExpectedTurn:     req.ExpectedTurn,
+		})
+		if err == nil {
+			result.Score = resp.Score
+		}
+	case rummy.PlayerView_DISCARD:
+		_, err = s.DiscardCard(ctx, &rummy.DiscardCardRequest{
+			GameName:         first.GameName,
+			PlayerId:         first.PlayerId,
+			PlayerSecret:     first.PlayerSecret,
+			Card:             req.Card,
+			RequestId:        req.RequestId,
+			ExpectedSequence: req.ExpectedSequence,
+			CheckTurn:        req.CheckTurn,
+			ExpectedTurn:     req.ExpectedTurn,
+		})
+	case rummy.PlayerView_CALL_RUMMY:
+		_, err = s.CallRummy(ctx, &rummy.CallRummyRequest{
+			GameName:         first.GameName,
+			PlayerId:         first.PlayerId,
+			PlayerSecret:     first.PlayerSecret,
+			Cards:            req.Cards,
+			RequestId:        req.RequestId,
+			ExpectedSequence: req.ExpectedSequence,
+			CheckTurn:        req.CheckTurn,
+			ExpectedTurn:     req.ExpectedTurn,
+		})
+	default:
+		err = fmt.Errorf("unknown action: %v", req.Action)
+	}
+	if err != nil {
+		st := status.Convert(err)
+		result.Error = st.Message()
+		result.Code = int32(st.Code())
+	}
+
+	view, err := s.GetPlayerView(ctx, &rummy.GetPlayerViewRequest{
+		GameName:     first.GameName,
+		PlayerId:     first.PlayerId,
+		PlayerSecret: first.PlayerSecret,
+	})
+	if err != nil {
+		if result.Error == "" {
+			st := status.Convert(err)
+			result.Error = st.Message()
+			result.Code = int32(st.Code())
+		}
+		return result
+	}
+	result.View = view
+	return result
+}
+
+// resultSequence returns the sequence number of the last event that
+// must be sent before the given result. Results without a view (because
+// it could not be retrieved) are ordered after the events published
+// before they were taken, like any other result.
+func resultSequence(sg *serverGame, result *rummy.ActionResult) int64 {
+	if result.View != nil {
+		return result.View.GameState.LastSequence
+	}
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.game.LastSequence()
+}
+
+// playResult is the result of an action on a Play stream, with the
+// sequence number of the last event published before it finished.
+type playResult struct {
+	result       *rummy.ActionResult
+	lastSequence int64
+}
+
+// playSession passes the results of actions on a Play stream from
+// the goroutine taking them to the one sending them.
+type playSession struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// Results that have not yet been taken, in order.
+	results  []*playResult
+	inFlight bool
+	closed   bool
+
+	// Signalled when there are new results.
+	notify chan struct{}
+	// Receives the error that ended the client's side of the stream.
+	done chan error
+}
+
+func newPlaySession() *playSession {
+	ps := &playSession{
+		notify: make(chan struct{}, 1),
+		done:   make(chan error, 1),
+	}
+	ps.cond = sync.NewCond(&ps.mu)
+	return ps
+}
+
+// begin marks the start of an action, returning false if the session
+// has been closed and no more actions should be taken.
+func (ps *playSession) begin() bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return false
+	}
+	ps.inFlight = true
+	return true
+}
+
+// finish adds the result of the action in progress.
+func (ps *playSession) finish(result *playResult) {
+	ps.mu.Lock()
+	ps.results = append(ps.results, result)
+	ps.inFlight = false
+	ps.mu.Unlock()
+	ps.cond.Broadcast()
+
+	select {
+	case ps.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the results added since the last call.
+func (ps *playSession) take() []*playResult {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	results := ps.results
+	ps.results = nil
+	return results
+}
+
+// close stops any further actions from being taken, waits for the
+// action in progress (if any) to finish, and returns the remaining results.
+// The action in progress cannot be blocked on the game's events once
+// the subscription has been closed.
+func (ps *playSession) close() []*playResult {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.closed = true
+	for ps.inFlight {
+		ps.cond.Wait()
+	}
+	results := ps.results
+	ps.results = nil
+	return results
+}
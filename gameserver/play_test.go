@@ -0,0 +1,98 @@
+package gameserver
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/timpalpant/rummy"
+)
+
+// startTestGame creates and starts a game between two players,
+// and returns the players' seats.
+func startTestGame(t *testing.T, client rummy.RummyServiceClient, name string) []*rummy.JoinGameResponse {
+	ctx := context.Background()
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var players []*rummy.JoinGameResponse
+	for _, playerName := range []string{"P0", "P1"} {
+		resp, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+			GameName:   name,
+			PlayerName: playerName,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, resp)
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   name,
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return players
+}
+
+// recvResult returns the next action result on a Play stream,
+// skipping any events sent before it.
+func recvResult(t *testing.T, stream rummy.RummyService_PlayClient) *rummy.ActionResult {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Result != nil {
+			return resp.Result
+		}
+	}
+}
+
+func TestPlayRejectsDiscardWithoutCard(t *testing.T) {
+	_, client := startTestServer(t, DefaultOptions)
+	players := startTestGame(t, client, "game")
+	p := players[0]
+
+	stream, err := client.Play(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&rummy.PlayRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: p.PlayerSecret,
+		RequestId:    "view",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if result := recvResult(t, stream); result.RequestId != "view" || result.Error != "" {
+		t.Fatalf("first result = %v, expected view without error", result)
+	}
+
+	// The stream is still served after the request is rejected.
+	for _, req := range []*rummy.PlayRequest{
+		{RequestId: "discard", Action: rummy.PlayerView_DISCARD},
+		{RequestId: "discard again", Action: rummy.PlayerView_DISCARD},
+	} {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		result := recvResult(t, stream)
+		if result.RequestId != req.RequestId || codes.Code(result.Code) != codes.InvalidArgument {
+			t.Errorf("%v: result = %v, expected %v", req.RequestId, result, codes.InvalidArgument)
+		}
+	}
+
+	_, err = client.DiscardCard(context.Background(), &rummy.DiscardCardRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: p.PlayerSecret,
+	})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("DiscardCard without a card returned %v, expected %v", err, codes.InvalidArgument)
+	}
+}
@@ -2,11 +2,19 @@ package gameserver
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/timpalpant/rummy"
 	"github.com/timpalpant/rummy/clients/ai"
@@ -14,28 +22,743 @@ import (
 	"github.com/timpalpant/rummy/deck"
 )
 
-const (
-	gameExpiration   = time.Hour
-	eventsBufferSize = 100
-)
+const eventsBufferSize = 100
+
+// Options configure a RummyServer.
+// A TTL of zero disables the corresponding expiration.
+type Options struct {
+	// How long a game that has not been started may go without
+	// any requests before it is removed.
+	LobbyIdleTTL time.Duration
+	// How long a game in progress may go without any requests
+	// before it is removed.
+	InProgressIdleTTL time.Duration
+	// How long a completed game is retained, so that players can
+	// still observe the final state, before it is removed.
+	CompletedTTL time.Duration
+	// How often to check for games that should be removed.
+	ReapInterval time.Duration
+	// How often to check for turns that have exceeded their game's
+	// turn time limit, and for players who have been disconnected for
+	// longer than AwayGracePeriod. If zero, neither is enforced.
+	TurnClockInterval time.Duration
+	// Name of the strategy used to finish turns that time out.
+	TimeoutStrategy string
+	// How long a player may have no open subscriptions to a game in
+	// progress before they are marked as away.
+	AwayGracePeriod time.Duration
+	// If set, the seats of players who are away are automatically
+	// taken over by a computer player with this strategy.
+	AwayStrategy string
+	// Each player may send at most ChatRateLimit chat messages
+	// per ChatRateInterval. If zero, chat is not rate limited.
+	ChatRateLimit    int
+	ChatRateInterval time.Duration
+	// Maximum length of a chat message, in characters.
+	MaxChatLength int
+	// If non-nil, called to filter each chat message before it is sent.
+	ChatFilter ChatFilter
+	// How long players who allow computer players wait in the
+	// matchmaking queue before the empty seats at their table are
+	// filled with computer players using MatchStrategy.
+	MatchTimeout  time.Duration
+	MatchStrategy string
+	// How often to check for tournament games that have ended, and
+	// start the next round of tournaments. If zero, tournaments
+	// do not advance.
+	TournamentInterval time.Duration
+	// If non-nil, games are saved to Store by Flush and
+	// loaded from it by Restore.
+	Store Store
+	// If non-nil, the requests made in each game and their
+	// outcomes are recorded in AuditLog.
+	AuditLog AuditLog
+	// If non-nil, the server's metrics are registered with Registerer.
+	Registerer prometheus.Registerer
+}
+
+var DefaultOptions = Options{
+	LobbyIdleTTL:       time.Hour,
+	InProgressIdleTTL:  24 * time.Hour,
+	CompletedTTL:       time.Hour,
+	ReapInterval:       time.Minute,
+	TurnClockInterval:  time.Second,
+	TimeoutStrategy:    "autoplay",
+	AwayGracePeriod:    time.Minute,
+	ChatRateLimit:      5,
+	ChatRateInterval:   10 * time.Second,
+	MaxChatLength:      280,
+	MatchTimeout:       30 * time.Second,
+	MatchStrategy:      "greedy",
+	TournamentInterval: time.Second,
+}
+
+// serverGame holds a Game along with the bookkeeping needed
+// to manage its lifecycle.
+type serverGame struct {
+	// mu serializes all actions on this game, and protects
+	// the fields below.
+	mu   sync.Mutex
+	game *rummy.Game
+	// The last time a request was made for this game.
+	lastActivity time.Time
+	// The time at which the game was first observed to be completed.
+	completedAt time.Time
+	// Strategy names of the computer players in this game, by player id.
+	strategies map[int32]string
+	// Parameters of the computer players' strategies, by player id.
+	// Computer players with default parameters are omitted.
+	strategyParams map[int32]map[string]string
+	// Secrets provided by players when they joined, by player id.
+	secrets map[int32]string
+	// Secret returned to the creator of the game.
+	hostSecret string
+	// The code required to join a private game,
+	// or empty if it has been revoked.
+	inviteCode string
+
+	// The computer players currently running in this game, by player id.
+	bots map[int32]*serverBot
+	// Seats of computer players that have been paused.
+	paused map[int32]bool
+	// Seats of human players that have been taken over by a computer
+	// player, and may be reclaimed.
+	takenOver map[int32]bool
+	// Names of the spectators who have joined this game, by token.
+	spectators map[string]string
+	// Number of open subscriptions for each player.
+	connections map[int32]int
+	// When each player with no open subscriptions was last connected.
+	disconnectedAt map[int32]time.Time
+	// Limits the rate at which players may send chat messages.
+	// Created when the first message is sent.
+	chat *chatLimiter
+	// Names of the accounts that players joined as, by player id.
+	accounts map[int32]string
+	// True once the server has subscribed to the game to record
+	// its result.
+	watched bool
+	// True once the result of the completed game has been recorded.
+	recorded bool
+	// The results of each player's most recent actions that had
+	// request ids, oldest first, so that retries are not applied twice.
+	recentActions map[int32][]*actionResult
+
+	// The status of the game when it was last seen by the metrics
+	// collector, which is accessed atomically, without holding mu.
+	lastStatus int32
+}
+
+func newServerGame(g *rummy.Game) *serverGame {
+	return &serverGame{
+		game:           g,
+		lastActivity:   time.Now(),
+		strategies:     make(map[int32]string),
+		strategyParams: make(map[int32]map[string]string),
+		secrets:        make(map[int32]string),
+		bots:           make(map[int32]*serverBot),
+		paused:         make(map[int32]bool),
+		takenOver:      make(map[int32]bool),
+		spectators:     make(map[string]string),
+		connections:    make(map[int32]int),
+		disconnectedAt: make(map[int32]time.Time),
+		accounts:       make(map[int32]string),
+		recentActions:  make(map[int32][]*actionResult),
+	}
+}
+
+// authenticateHost verifies that secret is the host secret
+// returned when the game was created.
+// Must be called while holding sg.mu.
+func (sg *serverGame) authenticateHost(secret string) error {
+	if sg.hostSecret == "" || !secretsEqual(sg.hostSecret, secret) {
+		return fmt.Errorf("invalid host secret")
+	}
+	return nil
+}
+
+// authorizeObserver verifies that the caller may observe the game,
+// either as one of its players, as a spectator who has joined it, or
+// anonymously if the game is public and allows spectators.
+// Must be called while holding sg.mu.
+func (sg *serverGame) authorizeObserver(isPlayer bool, playerId int32, playerSecret, spectatorToken string) error {
+	if isPlayer {
+		if _, err := sg.game.IsPlayerAway(playerId); err != nil {
+			return err
+		}
+		return sg.authenticate(playerId, playerSecret)
+	} else if spectatorToken != "" {
+		if _, ok := sg.spectators[spectatorToken]; !ok {
+			return fmt.Errorf("invalid spectator token")
+		}
+		return nil
+	}
+
+	if sg.isPrivate() || sg.game.Options().SpectatorPolicy == rummy.GameOptions_NO_SPECTATORS {
+		return fmt.Errorf("game may only be observed by its players and spectators")
+	}
+	return nil
+}
+
+// connect records that the player has opened a subscription.
+// Must be called while holding sg.mu.
+func (sg *serverGame) connect(playerId int32) {
+	sg.connections[playerId]++
+	delete(sg.disconnectedAt, playerId)
+	if err := sg.game.SetPlayerAway(playerId, false); err != nil {
+		glog.Warningf("Error marking player %v as returned: %v", playerId, err)
+	}
+}
+
+// disconnect records that one of the player's subscriptions has closed.
+// Must be called while holding sg.mu.
+func (sg *serverGame) disconnect(playerId int32, now time.Time) {
+	sg.connections[playerId]--
+	if sg.connections[playerId] <= 0 {
+		delete(sg.connections, playerId)
+		sg.disconnectedAt[playerId] = now
+	}
+}
+
+// checkAccount verifies that no other player has joined the game
+// as the given account.
+// Must be called while holding sg.mu.
+func (sg *serverGame) checkAccount(name string) error {
+	for _, other := range sg.accounts {
+		if other == name {
+			return fmt.Errorf("account %v has already joined this game", name)
+		}
+	}
+	return nil
+}
+
+// isPrivate returns true if the game requires an invite code to join.
+// Must be called while holding sg.mu.
+func (sg *serverGame) isPrivate() bool {
+	return sg.game.Options().Visibility == rummy.GameOptions_PRIVATE
+}
+
+// authenticate verifies that secret matches the one provided or
+// assigned when the player joined the game. Every seat is given a
+// secret when it is filled, so a seat without one (e.g. an invalid
+// player id) cannot be acted on.
+// Must be called while holding sg.mu.
+func (sg *serverGame) authenticate(playerId int32, secret string) error {
+	if sg.isBot(playerId, secret) {
+		return nil
+	} else if expected := sg.secrets[playerId]; expected == "" || !secretsEqual(expected, secret) {
+		return fmt.Errorf("invalid secret for player %v", playerId)
+	}
+	return nil
+}
+
+// expired returns true if the game should be removed at time now.
+// Must be called while holding sg.mu.
+func (sg *serverGame) expired(now time.Time, opts Options) bool {
+	switch sg.game.Status() {
+	case rummy.GameState_LOBBY:
+		return ttlExceeded(sg.lastActivity, opts.LobbyIdleTTL, now)
+	case rummy.GameState_IN_PROGRESS:
+		return ttlExceeded(sg.lastActivity, opts.InProgressIdleTTL, now)
+	case rummy.GameState_COMPLETED:
+		if sg.completedAt.IsZero() {
+			sg.completedAt = now
+		}
+		return ttlExceeded(sg.completedAt, opts.CompletedTTL, now)
+	}
+
+	return true
+}
+
+func ttlExceeded(since time.Time, ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(since) > ttl
+}
 
 // RummyServer implements RummyService.
 // RummyServer creates new Games of rummy and provides an interface
 // for players to perform actions on the Game.
 type RummyServer struct {
-	// gamesMu protects games and completedGames.
+	opts Options
+
+	// gamesMu protects games. It is held only while looking up, adding
+	// or removing games; each game is serialized by its own lock.
+	// A game's lock may be held while acquiring gamesMu, but not
+	// the other way around.
 	gamesMu sync.Mutex
 	// map of game name -> Game.
-	games map[string]*rummy.Game
-	// map of game name -> expiration time at which it will be deleted.
-	completedGames map[string]time.Time
+	games map[string]*serverGame
+	// True once Shutdown has been called; no new games may be created.
+	shuttingDown bool
+	// Players waiting for FindMatch to seat them.
+	matchmaker *matchmaker
+
+	// tournamentsMu protects tournaments. It may be held while
+	// acquiring gamesMu or a game's lock, but not the other way around.
+	tournamentsMu sync.Mutex
+	// map of tournament name -> tournament.
+	tournaments map[string]*serverTournament
+
+	// accountsMu protects accounts. It may be acquired while holding
+	// a game's lock, but no other lock may be acquired while holding it.
+	accountsMu sync.Mutex
+	// map of account name -> account.
+	accounts map[string]*account
+
+	// Serves the server's own computer players, which play through
+	// botClient with the same view of the game as any other client.
+	botServer *grpc.Server
+	botConn   *grpc.ClientConn
+	botClient rummy.RummyServiceClient
+
+	metrics *metrics
+
+	// Closed to stop the background reaper.
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-func NewRummyServer() *RummyServer {
-	return &RummyServer{
-		games:          make(map[string]*rummy.Game),
-		completedGames: make(map[string]time.Time),
+// NewRummyServer creates a new RummyServer and starts background
+// goroutines that remove idle and completed games and enforce turn
+// time limits according to opts. Call Stop to terminate them.
+func NewRummyServer(opts Options) *RummyServer {
+	s := &RummyServer{
+		opts:        opts,
+		games:       make(map[string]*serverGame),
+		matchmaker:  newMatchmaker(),
+		tournaments: make(map[string]*serverTournament),
+		accounts:    make(map[string]*account),
+		metrics:     newMetrics(),
+		stop:        make(chan struct{}),
+	}
+	if opts.Registerer != nil {
+		s.registerMetrics(opts.Registerer)
+	}
+	s.startBotServer()
+	if opts.ReapInterval > 0 {
+		go s.reapGames()
+	}
+	if opts.TurnClockInterval > 0 {
+		go s.runClocks()
 	}
+	if opts.TournamentInterval > 0 {
+		go s.runTournaments(opts.TournamentInterval)
+	}
+	return s
+}
+
+// Stop terminates the background goroutines and computer players.
+func (s *RummyServer) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.stopBotServer()
+	})
+}
+
+// Shutdown prepares the server to exit. New games are no longer
+// accepted, the background goroutines are stopped, and all subscribers
+// are sent a SERVER_SHUTDOWN event and their streams are closed,
+// so that in-flight RPCs can drain. Games may still be played until
+// the RPC server itself is stopped; call Flush afterwards to save them.
+func (s *RummyServer) Shutdown() {
+	s.gamesMu.Lock()
+	if s.shuttingDown {
+		s.gamesMu.Unlock()
+		return
+	}
+	s.shuttingDown = true
+	games := s.allGames()
+	s.gamesMu.Unlock()
+
+	glog.Infof("Shutting down %v games", len(games))
+	s.matchmaker.drain(fmt.Errorf("server is shutting down"))
+	for _, sg := range games {
+		sg.mu.Lock()
+		sg.game.Shutdown()
+		sg.mu.Unlock()
+	}
+	s.Stop()
+}
+
+// Flush saves all current games to the configured Store, if any.
+func (s *RummyServer) Flush() error {
+	if s.opts.Store == nil {
+		return nil
+	}
+
+	s.gamesMu.Lock()
+	games := s.allGames()
+	s.gamesMu.Unlock()
+
+	saved := make([]*SavedGame, 0, len(games))
+	for name, sg := range games {
+		sg.mu.Lock()
+		// Games that finished while the server was shutting down are
+		// no longer watched, so their results are recorded before
+		// they are saved as completed.
+		s.recordCompletedGame(name, sg)
+		saved = append(saved, &SavedGame{
+			Name:           name,
+			Game:           sg.game.Snapshot(),
+			Strategies:     copyMap(sg.strategies),
+			StrategyParams: copyStrategyParams(sg.strategyParams),
+			Secrets:        copyMap(sg.secrets),
+			HostSecret:     sg.hostSecret,
+			InviteCode:     sg.inviteCode,
+			TakenOver:      sortedSeats(sg.takenOver),
+			PausedBots:     sortedSeats(sg.paused),
+			Spectators:     copyStringMap(sg.spectators),
+			Accounts:       copyMap(sg.accounts),
+			CompletedAt:    sg.completedAt,
+		})
+		sg.mu.Unlock()
+	}
+
+	glog.Infof("Saving %v games", len(saved))
+	if err := s.opts.Store.SaveGames(saved); err != nil {
+		return err
+	}
+
+	s.tournamentsMu.Lock()
+	tournaments := make([]*SavedTournament, 0, len(s.tournaments))
+	for _, t := range s.tournaments {
+		tournaments = append(tournaments, &SavedTournament{
+			Tournament: proto.Clone(t.state).(*rummy.Tournament),
+			Secrets:    append([]string(nil), t.secrets...),
+			Strategies: append([]string(nil), t.strategies...),
+		})
+	}
+	s.tournamentsMu.Unlock()
+
+	glog.Infof("Saving %v tournaments", len(tournaments))
+	if err := s.opts.Store.SaveTournaments(tournaments); err != nil {
+		return err
+	}
+
+	s.accountsMu.Lock()
+	accounts := make([]*SavedAccount, 0, len(s.accounts))
+	for name, a := range s.accounts {
+		accounts = append(accounts, &SavedAccount{
+			Name:    name,
+			Token:   a.token,
+			Rating:  a.rating,
+			History: append([]*rummy.PlayerStats_Game(nil), a.history...),
+		})
+	}
+	s.accountsMu.Unlock()
+
+	glog.Infof("Saving %v accounts", len(accounts))
+	return s.opts.Store.SaveAccounts(accounts)
+}
+
+// Restore loads all games from the configured Store, if any,
+// and restarts their computer players. It should be called
+// before the server begins handling requests.
+func (s *RummyServer) Restore() error {
+	if s.opts.Store == nil {
+		return nil
+	}
+
+	saved, err := s.opts.Store.LoadGames()
+	if err != nil {
+		return err
+	}
+
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+	for _, sgame := range saved {
+		g, err := rummy.RestoreGame(sgame.Game)
+		if err != nil {
+			return fmt.Errorf("error restoring game %v: %v", sgame.Name, err)
+		}
+
+		// Time spent while the server was down does not count
+		// towards the idle TTL, so that players can reconnect.
+		sg := newServerGame(g)
+		sg.completedAt = sgame.CompletedAt
+		sg.secrets = copyMap(sgame.Secrets)
+		sg.hostSecret = sgame.HostSecret
+		sg.inviteCode = sgame.InviteCode
+		for token, name := range sgame.Spectators {
+			sg.spectators[token] = name
+		}
+		for _, id := range sgame.TakenOver {
+			sg.takenOver[id] = true
+		}
+		for _, id := range sgame.PausedBots {
+			sg.paused[id] = true
+		}
+		sg.accounts = copyMap(sgame.Accounts)
+		sg.mu.Lock()
+		for id, strategyName := range sgame.Strategies {
+			params := sgame.StrategyParams[id]
+			if sg.paused[id] {
+				// Paused computer players keep their seat until resumed.
+				sg.strategies[id] = strategyName
+				if len(params) > 0 {
+					sg.strategyParams[id] = params
+				}
+				continue
+			}
+			if err := s.startComputerPlayer(sgame.Name, sg, id, strategyName, params); err != nil {
+				sg.mu.Unlock()
+				return err
+			}
+		}
+		// Results of completed games have already been recorded.
+		if g.Status() == rummy.GameState_COMPLETED {
+			sg.recorded = true
+		} else {
+			s.watchGame(sgame.Name, sg)
+		}
+		sg.mu.Unlock()
+		s.games[sgame.Name] = sg
+	}
+
+	glog.Infof("Restored %v games", len(saved))
+
+	tournaments, err := s.opts.Store.LoadTournaments()
+	if err != nil {
+		return err
+	}
+
+	s.tournamentsMu.Lock()
+	defer s.tournamentsMu.Unlock()
+	for _, st := range tournaments {
+		n := len(st.Tournament.Entrants)
+		if len(st.Secrets) != n || len(st.Strategies) != n {
+			return fmt.Errorf("error restoring tournament %v: expected %v entrants",
+				st.Tournament.Name, n)
+		}
+
+		s.tournaments[st.Tournament.Name] = &serverTournament{
+			state:       st.Tournament,
+			secrets:     st.Secrets,
+			strategies:  st.Strategies,
+			subscribers: make(map[chan struct{}]bool),
+			games:       make(map[string]*serverGame),
+		}
+	}
+
+	glog.Infof("Restored %v tournaments", len(tournaments))
+
+	accounts, err := s.opts.Store.LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	for _, sa := range accounts {
+		s.accounts[sa.Name] = &account{
+			token:   sa.Token,
+			rating:  sa.Rating,
+			history: sa.History,
+		}
+	}
+
+	glog.Infof("Restored %v accounts", len(accounts))
+	return nil
+}
+
+// sortedSeats returns the player ids in the given set, in order.
+func sortedSeats(seats map[int32]bool) []int32 {
+	var result []int32
+	for id := range seats {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func copyStrategyParams(m map[int32]map[string]string) map[int32]map[string]string {
+	result := make(map[int32]map[string]string, len(m))
+	for id, params := range m {
+		result[id] = copyStringMap(params)
+	}
+	return result
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func copyMap(m map[int32]string) map[int32]string {
+	result := make(map[int32]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// allGames returns a copy of the current map of games.
+// Must be called while holding gamesMu.
+func (s *RummyServer) allGames() map[string]*serverGame {
+	games := make(map[string]*serverGame, len(s.games))
+	for name, sg := range s.games {
+		games[name] = sg
+	}
+	return games
+}
+
+// forEachIdleGame calls f with each game whose lock is not held by
+// anyone else, while holding its lock. Busy games are skipped, so that
+// background sweeps are never held up waiting on a single game, and
+// are visited again on the next sweep.
+func (s *RummyServer) forEachIdleGame(f func(name string, sg *serverGame)) {
+	s.gamesMu.Lock()
+	games := s.allGames()
+	s.gamesMu.Unlock()
+
+	for name, sg := range games {
+		if !sg.mu.TryLock() {
+			continue
+		}
+		f(name, sg)
+		sg.mu.Unlock()
+	}
+}
+
+func (s *RummyServer) reapGames() {
+	ticker := time.NewTicker(s.opts.ReapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.reapExpiredGames(now)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// reapExpiredGames removes all games that have expired as of now.
+// Subscribers to games that were not yet over are sent a GAME_EXPIRED
+// event and their streams are closed.
+func (s *RummyServer) reapExpiredGames(now time.Time) {
+	s.forEachIdleGame(func(name string, sg *serverGame) {
+		if !sg.expired(now, s.opts) {
+			return
+		}
+
+		glog.Infof("Removing %v game %v, last active at %v",
+			sg.game.Status(), name, sg.lastActivity)
+		s.gamesMu.Lock()
+		delete(s.games, name)
+		s.gamesMu.Unlock()
+		sg.game.Expire()
+		if s.opts.AuditLog != nil {
+			if err := s.opts.AuditLog.CloseGame(name); err != nil {
+				glog.Errorf("Error closing audit log for game %v: %v", name, err)
+			}
+		}
+	})
+}
+
+func (s *RummyServer) runClocks() {
+	ticker := time.NewTicker(s.opts.TurnClockInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.timeOutTurns(now)
+			s.markAwayPlayers(now)
+			s.fillMatches(now)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// timeOutTurns finishes the current turn of every game in which
+// the current player has exceeded the turn time limit as of now.
+// Timeouts do not count as activity for expiring idle games.
+func (s *RummyServer) timeOutTurns(now time.Time) {
+	s.forEachIdleGame(func(name string, sg *serverGame) {
+		playerId, ok := sg.game.CheckTurnTimeout(now)
+		if !ok {
+			return
+		}
+
+		glog.Infof("Player %v timed out in game %v", playerId, name)
+		err := s.playTimedOutTurn(sg.game, playerId)
+		if err != nil {
+			glog.Errorf("Error playing timed out turn for player %v in game %v: %v",
+				playerId, name, err)
+		}
+		s.audit(context.Background(), name, sg, "TurnTimeout", nil,
+			auditCaller{role: "server", playerId: playerId}, &err)
+	})
+}
+
+// markAwayPlayers marks players in games in progress who have been
+// disconnected for longer than the grace period as away, and hands
+// their seats to computer players if configured to do so.
+func (s *RummyServer) markAwayPlayers(now time.Time) {
+	s.forEachIdleGame(func(name string, sg *serverGame) {
+		if sg.game.Status() != rummy.GameState_IN_PROGRESS || len(sg.disconnectedAt) == 0 {
+			return
+		}
+
+		players := sg.game.GameState().Players
+		for id, disconnectedAt := range sg.disconnectedAt {
+			if now.Sub(disconnectedAt) < s.opts.AwayGracePeriod {
+				continue
+			} else if players[id].Forfeited {
+				delete(sg.disconnectedAt, id)
+				continue
+			}
+
+			glog.Infof("Player %v in game %v is away", id, name)
+			delete(sg.disconnectedAt, id)
+			if err := sg.game.SetPlayerAway(id, true); err != nil {
+				glog.Errorf("Error marking player %v away in game %v: %v", id, name, err)
+			}
+			_, isBot := sg.strategies[id]
+			if s.opts.AwayStrategy != "" && !isBot && !sg.game.Options().DisallowBots {
+				if err := s.takeOverSeat(name, sg, id, s.opts.AwayStrategy); err != nil {
+					glog.Errorf("Error taking over seat %v in game %v: %v", id, name, err)
+				}
+			}
+		}
+	})
+}
+
+func (s *RummyServer) playTimedOutTurn(g *rummy.Game, playerId int32) error {
+	strat, err := strategy.ForName(s.opts.TimeoutStrategy)
+	if err != nil {
+		return err
+	}
+
+	return ai.PlayTurn(g, playerId, strat)
+}
+
+// lockGame looks up the game with the given name and acquires its lock.
+// The caller must call sg.mu.Unlock when done with the game.
+func (s *RummyServer) lockGame(ctx context.Context, name string) (*serverGame, error) {
+	s.gamesMu.Lock()
+	sg, ok := s.games[name]
+	s.gamesMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no such game: %v", name)
+	}
+
+	sg.mu.Lock()
+	if sg.game.Status() == rummy.GameState_EXPIRED {
+		// Game was removed while we were waiting for the lock.
+		sg.mu.Unlock()
+		return nil, fmt.Errorf("no such game: %v", name)
+	}
+
+	// Requests from our own computer players do not count as activity,
+	// so that games with only computer players still expire when idle.
+	if !isBotRequest(ctx) {
+		sg.lastActivity = time.Now()
+	}
+	return sg, nil
 }
 
 func (s *RummyServer) CreateGame(ctx context.Context, req *rummy.CreateGameRequest) (*rummy.CreateGameResponse, error) {
@@ -43,116 +766,588 @@ func (s *RummyServer) CreateGame(ctx context.Context, req *rummy.CreateGameReque
 
 	s.gamesMu.Lock()
 	defer s.gamesMu.Unlock()
-	s.garbageCollectCompletedGames()
+	if s.shuttingDown {
+		return nil, fmt.Errorf("server is shutting down")
+	}
 
 	if _, ok := s.games[req.GameName]; ok {
 		return nil, fmt.Errorf("game %v already exists", req.GameName)
 	}
 
-	s.games[req.GameName] = rummy.NewGame()
-	return &rummy.CreateGameResponse{}, nil
+	g, err := rummy.NewGameWithOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	hostSecret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	inviteCode := req.InviteCode
+	if g.Options().Visibility != rummy.GameOptions_PRIVATE {
+		if inviteCode != "" {
+			return nil, fmt.Errorf("invite codes may only be used with private games")
+		}
+	} else if inviteCode == "" {
+		inviteCode, err = newInviteCode()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sg := newServerGame(g)
+	sg.hostSecret = hostSecret
+	sg.inviteCode = inviteCode
+	s.watchGame(req.GameName, sg)
+	s.games[req.GameName] = sg
+	s.audit(ctx, req.GameName, sg, "CreateGame", req, hostCaller, &err)
+	return &rummy.CreateGameResponse{
+		InviteCode: inviteCode,
+		HostSecret: hostSecret,
+	}, nil
 }
 
-// Must be called while holding gamesMu.
-func (s *RummyServer) garbageCollectCompletedGames() {
-	for name, game := range s.games {
-		if game.GameState().GameOver {
-			glog.Infof("Detected that game %v is over", name)
-			s.completedGames[name] = time.Now()
+func (s *RummyServer) ListGames(ctx context.Context, req *rummy.ListGamesRequest) (*rummy.ListGamesResponse, error) {
+	glog.V(1).Infof("ListGames: %v", req)
+	s.gamesMu.Lock()
+	games := s.allGames()
+	s.gamesMu.Unlock()
+
+	resp := &rummy.ListGamesResponse{}
+	for name, sg := range games {
+		// Listing a game does not count as activity.
+		sg.mu.Lock()
+		status := sg.game.Status()
+		if !sg.isPrivate() && status != rummy.GameState_EXPIRED {
+			resp.Games = append(resp.Games, &rummy.ListGamesResponse_Game{
+				GameName:   name,
+				Status:     status,
+				NumPlayers: int32(sg.game.NumPlayers()),
+				Options:    sg.game.Options(),
+			})
 		}
+		sg.mu.Unlock()
+	}
+
+	sort.Slice(resp.Games, func(i, j int) bool {
+		return resp.Games[i].GameName < resp.Games[j].GameName
+	})
+	return resp, nil
+}
+
+func (s *RummyServer) UpdateInviteCode(ctx context.Context, req *rummy.UpdateInviteCodeRequest) (_ *rummy.UpdateInviteCodeResponse, err error) {
+	glog.V(1).Infof("UpdateInviteCode: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "UpdateInviteCode", req, hostCaller, &err)
+
+	if err := sg.authenticateHost(req.HostSecret); err != nil {
+		return nil, err
+	} else if !sg.isPrivate() {
+		return nil, fmt.Errorf("game %v is not private", req.GameName)
 	}
 
-	now := time.Now()
-	for name, expiration := range s.completedGames {
-		glog.V(1).Infof("Completed game %v expires at %v", name, expiration)
-		if expiration.After(now) {
-			glog.Infof("Removing completed game %v", name)
-			delete(s.games, name)
-			delete(s.completedGames, name)
+	switch {
+	case req.Revoke:
+		sg.inviteCode = ""
+	case req.InviteCode != "":
+		sg.inviteCode = req.InviteCode
+	default:
+		sg.inviteCode, err = newInviteCode()
+		if err != nil {
+			return nil, err
 		}
 	}
+
+	return &rummy.UpdateInviteCodeResponse{
+		InviteCode: sg.inviteCode,
+	}, nil
 }
 
-func (s *RummyServer) JoinGame(ctx context.Context, req *rummy.JoinGameRequest) (*rummy.JoinGameResponse, error) {
+func (s *RummyServer) JoinSpectator(ctx context.Context, req *rummy.JoinSpectatorRequest) (_ *rummy.JoinSpectatorResponse, err error) {
+	glog.V(1).Infof("JoinSpectator: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "JoinSpectator", req, spectatorCaller, &err)
+
+	if sg.game.Options().SpectatorPolicy == rummy.GameOptions_NO_SPECTATORS {
+		return nil, fmt.Errorf("spectators are not allowed in game %v", req.GameName)
+	} else if err := checkInviteCode(sg.isPrivate(), sg.inviteCode, req.InviteCode); err != nil {
+		return nil, err
+	}
+
+	token, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Spectator %v joined game %v", req.SpectatorName, req.GameName)
+	sg.spectators[token] = req.SpectatorName
+	return &rummy.JoinSpectatorResponse{
+		SpectatorToken: token,
+	}, nil
+}
+
+func (s *RummyServer) JoinGame(ctx context.Context, req *rummy.JoinGameRequest) (_ *rummy.JoinGameResponse, err error) {
 	glog.V(1).Infof("JoinGame: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	// The player is identified once they have joined.
+	caller := playerCaller(noPlayer)
+	defer func() {
+		s.audit(ctx, req.GameName, sg, "JoinGame", req, caller, &err)
+	}()
+	g := sg.game
+
+	if err := checkInviteCode(sg.isPrivate(), sg.inviteCode, req.InviteCode); err != nil {
+		return nil, err
+	}
+	if req.Strategy != "" && g.Options().DisallowBots {
+		return nil, fmt.Errorf("computer players are not allowed in game %v", req.GameName)
+	}
+	// Computer players are prepared before they are given a seat,
+	// so that a seat is never left filled without one.
+	var cp *computerPlayer
+	if req.Strategy != "" {
+		cp, err = newComputerPlayer(req.Strategy, req.StrategyParams)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(req.StrategyParams) > 0 {
+		return nil, fmt.Errorf("strategy parameters may only be given for computer players")
+	}
+	if req.AccountName != "" {
+		if err := s.authenticateAccount(req.AccountName, req.AccountToken); err != nil {
+			return nil, err
+		} else if err := sg.checkAccount(req.AccountName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Players who do not choose a secret are assigned one, so that
+	// nobody else can act on their behalf or see their hand.
+	secret := req.PlayerSecret
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	id, err := g.AddPlayer(req.PlayerName)
-	if err == nil && req.Strategy != "" {
-		// Start computer player.
+	if err == nil {
+		caller.playerId = id
+		sg.secrets[id] = secret
+	}
+	if err == nil && req.AccountName != "" {
+		sg.accounts[id] = req.AccountName
+	}
+	if err == nil && cp != nil {
 		glog.Infof("Starting computer player %v for game %v with strategy %v",
 			req.PlayerName, req.GameName, req.Strategy)
-		strat, err := strategy.ForName(req.Strategy)
+		s.runComputerPlayer(req.GameName, sg, id, cp)
+	}
+
+	return &rummy.JoinGameResponse{
+		PlayerId:     id,
+		PlayerSecret: secret,
+	}, err
+}
+
+// seat is a player to be seated in a game started by the server.
+type seat struct {
+	name string
+	// If empty, a random secret is generated.
+	secret string
+	// If set, the seat is played by a computer player with this strategy.
+	strategy string
+	// If set, the player has been authenticated as this account.
+	account string
+}
+
+// startGame creates a game with the given options, seats the given
+// players in order, and deals. If the name is already taken, a numeric
+// suffix is added to it. It returns the name of the game.
+func (s *RummyServer) startGame(name string, opts *rummy.GameOptions, seats []seat) (_ string, err error) {
+	g, err := rummy.NewGameWithOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	hostSecret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	sg := newServerGame(g)
+	sg.hostSecret = hostSecret
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	s.gamesMu.Lock()
+	if s.shuttingDown {
+		s.gamesMu.Unlock()
+		return "", fmt.Errorf("server is shutting down")
+	}
+	base := name
+	for i := 2; s.games[name] != nil; i++ {
+		name = fmt.Sprintf("%v-%d", base, i)
+	}
+	s.games[name] = sg
+	s.gamesMu.Unlock()
+
+	// If the game cannot be started, it is removed along with
+	// any computer players that have already joined.
+	defer func() {
 		if err != nil {
-			return nil, err
+			g.Expire()
+		}
+	}()
+
+	for _, st := range seats {
+		id, err := g.AddPlayer(st.name)
+		if err != nil {
+			return "", err
 		}
 
-		go func() {
-			if err := ai.PlayGame(g, id, strat); err != nil {
-				glog.Errorf("Error in computer player %v:%v in game %v: %v",
-					id, req.PlayerName, req.GameName, err)
+		secret := st.secret
+		if secret == "" {
+			if secret, err = newSecret(); err != nil {
+				return "", err
 			}
-		}()
+		}
+		sg.secrets[id] = secret
+		if st.strategy != "" {
+			if err := s.startComputerPlayer(name, sg, id, st.strategy, nil); err != nil {
+				return "", err
+			}
+		}
+		if st.account != "" {
+			if err := sg.checkAccount(st.account); err != nil {
+				return "", err
+			}
+			sg.accounts[id] = st.account
+		}
 	}
+	s.watchGame(name, sg)
 
-	return &rummy.JoinGameResponse{
-		PlayerId: id,
-	}, err
+	glog.Infof("Starting game %v with %v players", name, len(seats))
+	return name, g.Deal()
+}
+
+func (s *RummyServer) TakeOverSeat(ctx context.Context, req *rummy.TakeOverSeatRequest) (_ *rummy.TakeOverSeatResponse, err error) {
+	glog.V(1).Infof("TakeOverSeat: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "TakeOverSeat", req, hostCaller, &err)
+
+	if err := sg.authenticateHost(req.HostSecret); err != nil {
+		return nil, err
+	}
+
+	away, err := sg.game.IsPlayerAway(req.PlayerId)
+	if err != nil {
+		return nil, err
+	} else if !away {
+		return nil, fmt.Errorf("player %v is not away", req.PlayerId)
+	}
+
+	return &rummy.TakeOverSeatResponse{}, s.takeOverSeat(req.GameName, sg, req.PlayerId, req.Strategy)
+}
+
+// takeOverSeat hands the seat of a human player to a computer player.
+// Must be called while holding sg.mu.
+func (s *RummyServer) takeOverSeat(gameName string, sg *serverGame, id int32, strategyName string) error {
+	if _, ok := sg.strategies[id]; ok {
+		return fmt.Errorf("player %v is already a computer player", id)
+	} else if sg.game.Options().DisallowBots {
+		return fmt.Errorf("computer players are not allowed in game %v", gameName)
+	}
+
+	glog.Infof("Computer player with strategy %v taking over seat %v in game %v",
+		strategyName, id, gameName)
+	if err := s.startComputerPlayer(gameName, sg, id, strategyName, nil); err != nil {
+		return err
+	}
+	sg.takenOver[id] = true
+	return nil
+}
+
+func (s *RummyServer) ReclaimSeat(ctx context.Context, req *rummy.ReclaimSeatRequest) (_ *rummy.ReclaimSeatResponse, err error) {
+	glog.V(1).Infof("ReclaimSeat: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "ReclaimSeat", req, playerCaller(req.PlayerId), &err)
+
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
+	} else if !sg.takenOver[req.PlayerId] {
+		return nil, fmt.Errorf("seat %v has not been taken over", req.PlayerId)
+	}
+
+	glog.Infof("Player %v reclaimed their seat in game %v", req.PlayerId, req.GameName)
+	stopComputerPlayer(sg, req.PlayerId)
+	return &rummy.ReclaimSeatResponse{}, sg.game.SetPlayerAway(req.PlayerId, false)
+}
+
+func (s *RummyServer) LeaveGame(ctx context.Context, req *rummy.LeaveGameRequest) (_ *rummy.LeaveGameResponse, err error) {
+	glog.V(1).Infof("LeaveGame: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "LeaveGame", req, playerCaller(req.PlayerId), &err)
+
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
+	}
+
+	inLobby := sg.game.Status() == rummy.GameState_LOBBY
+	if err := sg.game.Forfeit(req.PlayerId); err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Player %v left game %v", req.PlayerId, req.GameName)
+	stopComputerPlayer(sg, req.PlayerId)
+	delete(sg.disconnectedAt, req.PlayerId)
+	if inLobby {
+		// The seat is free for another player to join.
+		delete(sg.secrets, req.PlayerId)
+		delete(sg.accounts, req.PlayerId)
+		delete(sg.connections, req.PlayerId)
+		delete(sg.recentActions, req.PlayerId)
+	}
+	return &rummy.LeaveGameResponse{}, nil
+}
+
+func (s *RummyServer) SendChat(ctx context.Context, req *rummy.SendChatRequest) (_ *rummy.SendChatResponse, err error) {
+	glog.V(1).Infof("SendChat: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "SendChat", req, playerCaller(req.PlayerId), &err)
+
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if s.opts.MaxChatLength > 0 && utf8.RuneCountInString(message) > s.opts.MaxChatLength {
+		return nil, fmt.Errorf("message is longer than %d characters", s.opts.MaxChatLength)
+	}
+	if s.opts.ChatFilter != nil {
+		message, err = s.opts.ChatFilter(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if sg.chat == nil {
+		sg.chat = newChatLimiter(s.opts.ChatRateLimit, s.opts.ChatRateInterval)
+	}
+	if _, err := sg.game.IsPlayerAway(req.PlayerId); err != nil {
+		return nil, err
+	} else if err := sg.chat.allow(req.PlayerId, time.Now()); err != nil {
+		return nil, err
+	}
+
+	seq, err := sg.game.Chat(req.PlayerId, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rummy.SendChatResponse{
+		Sequence: seq,
+	}, nil
 }
 
-func (s *RummyServer) StartGame(ctx context.Context, req *rummy.StartGameRequest) (*rummy.StartGameResponse, error) {
+func (s *RummyServer) StartGame(ctx context.Context, req *rummy.StartGameRequest) (_ *rummy.StartGameResponse, err error) {
 	glog.V(1).Infof("StartGame: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	caller := hostCaller
+	if req.HostSecret == "" {
+		caller = playerCaller(req.PlayerId)
+	}
+	defer s.audit(ctx, req.GameName, sg, "StartGame", req, caller, &err)
+	g := sg.game
+
+	// The game may be started by its host or by any of its players.
+	if req.HostSecret != "" {
+		if err := sg.authenticateHost(req.HostSecret); err != nil {
+			return nil, err
+		}
+	} else if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
 	glog.Infof("Starting game: %v", req.GameName)
-	err := g.Deal()
+	err = g.Deal()
 	return &rummy.StartGameResponse{}, err
 }
 
 func (s *RummyServer) SubscribeGame(req *rummy.SubscribeGameRequest, stream rummy.RummyService_SubscribeGameServer) error {
 	glog.V(1).Infof("SubscribeGame: %v", req)
-	s.gamesMu.Lock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return fmt.Errorf("no such game: %v", req.GameName)
+	sub, history, err := s.subscribe(stream.Context(), req)
+	if err != nil {
+		return err
 	}
+	defer s.unsubscribe(sub)
 
-	eventsCh := make(chan *rummy.GameEvent, eventsBufferSize)
-	g.Subscribe(eventsCh)
-	s.gamesMu.Unlock()
-
-	for e := range eventsCh {
+	for _, e := range history {
 		if err := stream.Send(e); err != nil {
 			return err
 		}
 	}
+	lastSent := sub.lastSequence
+
+	for {
+		select {
+		case e, ok := <-sub.events:
+			if !ok {
+				return s.checkFellBehind(sub, lastSent)
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+			if e.Sequence > lastSent {
+				lastSent = e.Sequence
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// subscription is an open stream of a game's events.
+type subscription struct {
+	sg        *serverGame
+	events    chan *rummy.GameEvent
+	playerId  int32
+	connected bool
+	// The secret a connected player subscribed with. If they leave the
+	// game before it starts, their seat may be taken by another player,
+	// whose connections are not affected by this subscription.
+	secret string
+	// Whether the subscriber is a "player", "bot" or "spectator".
+	role string
+	// Sequence number of the last event published before
+	// the subscription was opened.
+	lastSequence int64
+}
+
+// subscribe authorizes and opens a subscription to a game, returning
+// the events in its history from req.FromSequence.
+func (s *RummyServer) subscribe(ctx context.Context, req *rummy.SubscribeGameRequest) (*subscription, []*rummy.GameEvent, error) {
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer sg.mu.Unlock()
+
+	// Checked while holding the game lock so that we either see the
+	// shutdown here, or our stream is closed by Shutdown.
+	s.gamesMu.Lock()
+	shuttingDown := s.shuttingDown
+	s.gamesMu.Unlock()
+	if shuttingDown {
+		return nil, nil, fmt.Errorf("server is shutting down")
+	}
+
+	if err := sg.authorizeObserver(req.IsPlayer, req.PlayerId, req.PlayerSecret, req.SpectatorToken); err != nil {
+		return nil, nil, err
+	}
+	// Computer players do not count as connections, so that the seats
+	// they have taken over stay away until the player returns.
+	sub := &subscription{
+		sg:        sg,
+		events:    make(chan *rummy.GameEvent, eventsBufferSize),
+		playerId:  req.PlayerId,
+		connected: req.IsPlayer && !sg.isBot(req.PlayerId, req.PlayerSecret),
+	}
+	if sub.connected {
+		sub.secret = req.PlayerSecret
+		sg.connect(req.PlayerId)
+	}
+	switch {
+	case sub.connected:
+		sub.role = "player"
+	case req.IsPlayer:
+		sub.role = "bot"
+	default:
+		sub.role = "spectator"
+	}
+	s.metrics.subscribers.WithLabelValues(sub.role).Inc()
+
+	sub.lastSequence = sg.game.GameState().LastSequence
+	history := sg.game.SubscribeFrom(sub.events, req.FromSequence)
+	return sub, history, nil
+}
 
+// checkFellBehind is called once a subscription's events channel has
+// been closed, and returns an error if the game closed it because the
+// subscriber fell behind rather than because no more events will be
+// published. The subscriber should then subscribe again from the
+// sequence number after lastSent, the last event it was sent.
+func (s *RummyServer) checkFellBehind(sub *subscription, lastSent int64) error {
+	sub.sg.mu.Lock()
+	defer sub.sg.mu.Unlock()
+	if sub.sg.game.LastSequence() > lastSent {
+		return status.Errorf(codes.ResourceExhausted,
+			"subscriber fell behind, subscribe again from sequence %v", lastSent+1)
+	}
 	return nil
 }
 
+// unsubscribe cleans up after a subscription ends.
+func (s *RummyServer) unsubscribe(sub *subscription) {
+	sg := sub.sg
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.game.Unsubscribe(sub.events)
+	s.metrics.subscribers.WithLabelValues(sub.role).Dec()
+	if sub.connected && sg.secrets[sub.playerId] == sub.secret {
+		sg.disconnect(sub.playerId, time.Now())
+	}
+}
+
 func (s *RummyServer) GetGameState(ctx context.Context, req *rummy.GetGameStateRequest) (*rummy.GameState, error) {
 	glog.V(1).Infof("GetGameState: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
 	}
+	defer sg.mu.Unlock()
+	g := sg.game
 
+	if err := sg.authorizeObserver(req.IsPlayer, req.PlayerId, req.PlayerSecret, req.SpectatorToken); err != nil {
+		return nil, err
+	}
+
+	if !req.IsPlayer && req.SpectatorToken != "" {
+		return g.SpectatorState(time.Now()), nil
+	}
 	return g.GameState(), nil
 }
 
@@ -174,12 +1369,14 @@ func valueSlice(cards []*deck.Card) []deck.Card {
 
 func (s *RummyServer) GetHandCards(ctx context.Context, req *rummy.GetHandCardsRequest) (*rummy.GetHandCardsResponse, error) {
 	glog.V(1).Infof("GetHandCards: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	g := sg.game
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
 	cards, err := g.PlayerHand(req.PlayerId)
@@ -194,78 +1391,158 @@ func (s *RummyServer) GetHandCards(ctx context.Context, req *rummy.GetHandCardsR
 	}, nil
 }
 
-func (s *RummyServer) PickUpStock(ctx context.Context, req *rummy.PickUpStockRequest) (*rummy.PickUpStockResponse, error) {
+func (s *RummyServer) GetPlayerView(ctx context.Context, req *rummy.GetPlayerViewRequest) (*rummy.PlayerView, error) {
+	glog.V(1).Infof("GetPlayerView: %v", req)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	g := sg.game
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
+	}
+
+	return g.PlayerView(req.PlayerId)
+}
+
+func (s *RummyServer) PickUpStock(ctx context.Context, req *rummy.PickUpStockRequest) (_ *rummy.PickUpStockResponse, err error) {
 	glog.V(1).Infof("PickUpStock: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "PickUpStock", req, playerCaller(req.PlayerId), &err)
+	g := sg.game
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
-	card, err := g.PickUpStock(req.PlayerId)
-	return &rummy.PickUpStockResponse{
-		Card: &card,
-	}, err
+	resp, err := sg.act(req.PlayerId, "PickUpStock", actionPreconditions{
+		requestId:        req.RequestId,
+		expectedSequence: req.ExpectedSequence,
+		checkTurn:        req.CheckTurn,
+		expectedTurn:     req.ExpectedTurn,
+	}, func() (proto.Message, error) {
+		card, err := g.PickUpStock(req.PlayerId)
+		return &rummy.PickUpStockResponse{
+			Card: &card,
+		}, err
+	})
+	r, _ := resp.(*rummy.PickUpStockResponse)
+	return r, err
 }
 
-func (s *RummyServer) PickUpDiscard(ctx context.Context, req *rummy.PickUpDiscardRequest) (*rummy.PickUpDiscardResponse, error) {
+func (s *RummyServer) PickUpDiscard(ctx context.Context, req *rummy.PickUpDiscardRequest) (_ *rummy.PickUpDiscardResponse, err error) {
 	glog.V(1).Infof("PickUpDiscard: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "PickUpDiscard", req, playerCaller(req.PlayerId), &err)
+	g := sg.game
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
-	cards, err := g.PickUpDiscard(req.PlayerId, int(req.NCards))
-	return &rummy.PickUpDiscardResponse{
-		Cards: protoSlice(cards),
-	}, err
+	resp, err := sg.act(req.PlayerId, "PickUpDiscard", actionPreconditions{
+		requestId:        req.RequestId,
+		expectedSequence: req.ExpectedSequence,
+		checkTurn:        req.CheckTurn,
+		expectedTurn:     req.ExpectedTurn,
+	}, func() (proto.Message, error) {
+		cards, err := g.PickUpDiscard(req.PlayerId, int(req.NCards))
+		return &rummy.PickUpDiscardResponse{
+			Cards: protoSlice(cards),
+		}, err
+	})
+	r, _ := resp.(*rummy.PickUpDiscardResponse)
+	return r, err
 }
 
-func (s *RummyServer) PlayCards(ctx context.Context, req *rummy.PlayCardsRequest) (*rummy.PlayCardsResponse, error) {
+func (s *RummyServer) PlayCards(ctx context.Context, req *rummy.PlayCardsRequest) (_ *rummy.PlayCardsResponse, err error) {
 	glog.V(1).Infof("PlayCards: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "PlayCards", req, playerCaller(req.PlayerId), &err)
+	g := sg.game
+	defer s.observeBotTurn(ctx, sg, req.PlayerId, g.Turn(), g.TurnStartedAt())
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
-	score, err := g.PlayCards(req.PlayerId, valueSlice(req.Cards))
-	return &rummy.PlayCardsResponse{
-		Score: int32(score),
-	}, err
+	resp, err := sg.act(req.PlayerId, "PlayCards", actionPreconditions{
+		requestId:        req.RequestId,
+		expectedSequence: req.ExpectedSequence,
+		checkTurn:        req.CheckTurn,
+		expectedTurn:     req.ExpectedTurn,
+	}, func() (proto.Message, error) {
+		score, err := g.PlayCards(req.PlayerId, valueSlice(req.Cards))
+		return &rummy.PlayCardsResponse{
+			Score: int32(score),
+		}, err
+	})
+	r, _ := resp.(*rummy.PlayCardsResponse)
+	return r, err
 }
 
-func (s *RummyServer) DiscardCard(ctx context.Context, req *rummy.DiscardCardRequest) (*rummy.DiscardCardResponse, error) {
+func (s *RummyServer) DiscardCard(ctx context.Context, req *rummy.DiscardCardRequest) (_ *rummy.DiscardCardResponse, err error) {
 	glog.V(1).Infof("DiscardCard: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	if req.Card == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no card to discard")
+	}
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "DiscardCard", req, playerCaller(req.PlayerId), &err)
+	g := sg.game
+	defer s.observeBotTurn(ctx, sg, req.PlayerId, g.Turn(), g.TurnStartedAt())
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
-	err := g.DiscardCard(req.PlayerId, *req.Card)
-	return &rummy.DiscardCardResponse{}, err
+	resp, err := sg.act(req.PlayerId, "DiscardCard", actionPreconditions{
+		requestId:        req.RequestId,
+		expectedSequence: req.ExpectedSequence,
+		checkTurn:        req.CheckTurn,
+		expectedTurn:     req.ExpectedTurn,
+	}, func() (proto.Message, error) {
+		err := g.DiscardCard(req.PlayerId, *req.Card)
+		return &rummy.DiscardCardResponse{}, err
+	})
+	r, _ := resp.(*rummy.DiscardCardResponse)
+	return r, err
 }
 
-func (s *RummyServer) CallRummy(ctx context.Context, req *rummy.CallRummyRequest) (*rummy.CallRummyResponse, error) {
+func (s *RummyServer) CallRummy(ctx context.Context, req *rummy.CallRummyRequest) (_ *rummy.CallRummyResponse, err error) {
 	glog.V(1).Infof("CallRummy: %v", req)
-	s.gamesMu.Lock()
-	defer s.gamesMu.Unlock()
-	var g *rummy.Game
-	var ok bool
-	if g, ok = s.games[req.GameName]; !ok {
-		return nil, fmt.Errorf("no such game: %v", req.GameName)
+	sg, err := s.lockGame(ctx, req.GameName)
+	if err != nil {
+		return nil, err
+	}
+	defer sg.mu.Unlock()
+	defer s.audit(ctx, req.GameName, sg, "CallRummy", req, playerCaller(req.PlayerId), &err)
+	g := sg.game
+	if err := sg.authenticate(req.PlayerId, req.PlayerSecret); err != nil {
+		return nil, err
 	}
 
-	err := g.CallRummy(req.PlayerId, valueSlice(req.Cards))
-	return &rummy.CallRummyResponse{}, err
+	resp, err := sg.act(req.PlayerId, "CallRummy", actionPreconditions{
+		requestId:        req.RequestId,
+		expectedSequence: req.ExpectedSequence,
+		checkTurn:        req.CheckTurn,
+		expectedTurn:     req.ExpectedTurn,
+	}, func() (proto.Message, error) {
+		err := g.CallRummy(req.PlayerId, valueSlice(req.Cards))
+		return &rummy.CallRummyResponse{}, err
+	})
+	r, _ := resp.(*rummy.CallRummyResponse)
+	return r, err
 }
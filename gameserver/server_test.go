@@ -0,0 +1,629 @@
+package gameserver
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
+)
+
+// startTestServer serves a RummyServer with the given options over an
+// in-process connection, and returns a client connected to it.
+func startTestServer(tb testing.TB, opts Options) (*RummyServer, rummy.RummyServiceClient) {
+	lis := bufconn.Listen(botConnBufferSize)
+	grpcServer := grpc.NewServer()
+	s := NewRummyServer(opts)
+	rummy.RegisterRummyServiceServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	tb.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+		s.Stop()
+	})
+	return s, rummy.NewRummyServiceClient(conn)
+}
+
+// Tables that have not ended after this many turns are abandoned,
+// since games between computer players may go on indefinitely.
+const benchmarkMaxTurns = 200
+
+// playBenchmarkTable plays a game between two computer players, each
+// connected through the client as a remote player would be, and
+// returns the number of turns played.
+func playBenchmarkTable(client rummy.RummyServiceClient, name string) (int64, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: name}); err != nil {
+		return 0, err
+	}
+	var players []*rummy.JoinGameResponse
+	for i := 0; i < 2; i++ {
+		resp, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+			GameName:   name,
+			PlayerName: fmt.Sprintf("P%d", i),
+		})
+		if err != nil {
+			return 0, err
+		}
+		players = append(players, resp)
+	}
+
+	stream, err := client.SubscribeGame(ctx, &rummy.SubscribeGameRequest{GameName: name})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:     name,
+		PlayerId:     players[0].PlayerId,
+		PlayerSecret: players[0].PlayerSecret,
+	}); err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range players {
+		wg.Add(1)
+		go func(p *rummy.JoinGameResponse) {
+			defer wg.Done()
+			strat, _ := strategy.ForName("greedy")
+			ai.PlayRemoteGame(ctx, client, name, p.PlayerId, p.PlayerSecret, strat)
+		}(p)
+	}
+	// Stop the players once the game ends or is abandoned.
+	defer wg.Wait()
+	defer cancel()
+
+	var turns int64
+	for turns < benchmarkMaxTurns {
+		e, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		if e.Type == rummy.GameEvent_TURN_START {
+			turns++
+		}
+	}
+	return turns, nil
+}
+
+// BenchmarkConcurrentTables measures the throughput of the server
+// with many tables being played at once.
+func BenchmarkConcurrentTables(b *testing.B) {
+	for _, tables := range []int{1, 10, 100, 200, 500} {
+		b.Run(fmt.Sprintf("tables=%d", tables), func(b *testing.B) {
+			_, client := startTestServer(b, DefaultOptions)
+
+			var turns int64
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var wg sync.WaitGroup
+				for j := 0; j < tables; j++ {
+					wg.Add(1)
+					go func(name string) {
+						defer wg.Done()
+						n, err := playBenchmarkTable(client, name)
+						if err != nil {
+							b.Error(err)
+						}
+						atomic.AddInt64(&turns, n)
+					}(fmt.Sprintf("table-%d-%d", i, j))
+				}
+				wg.Wait()
+			}
+			b.ReportMetric(float64(turns)/b.Elapsed().Seconds(), "turns/s")
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	sg := newServerGame(rummy.NewGame())
+	sg.secrets[0] = "secret"
+	sg.bots[2] = &serverBot{token: "bot-token"}
+
+	tests := []struct {
+		playerId int32
+		secret   string
+		ok       bool
+	}{
+		{0, "secret", true},
+		{0, "wrong", false},
+		{0, "", false},
+		// Seats without a secret cannot be acted on.
+		{1, "", false},
+		{1, "secret", false},
+		{2, "bot-token", true},
+		{2, "", false},
+	}
+
+	for _, tc := range tests {
+		err := sg.authenticate(tc.playerId, tc.secret)
+		if (err == nil) != tc.ok {
+			t.Errorf("authenticate(%v, %q) = %v, expected ok = %v",
+				tc.playerId, tc.secret, err, tc.ok)
+		}
+	}
+}
+
+func TestStartGameRequiresSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		req  func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest
+		ok   bool
+	}{
+		{"no secret", func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest {
+			return &rummy.StartGameRequest{}
+		}, false},
+		{"host secret", func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest {
+			return &rummy.StartGameRequest{HostSecret: host.HostSecret}
+		}, true},
+		{"wrong host secret", func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest {
+			return &rummy.StartGameRequest{HostSecret: player.PlayerSecret}
+		}, false},
+		{"player secret", func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest {
+			return &rummy.StartGameRequest{PlayerId: player.PlayerId, PlayerSecret: player.PlayerSecret}
+		}, true},
+		{"wrong player", func(host *rummy.CreateGameResponse, player *rummy.JoinGameResponse) *rummy.StartGameRequest {
+			return &rummy.StartGameRequest{PlayerId: player.PlayerId + 1, PlayerSecret: player.PlayerSecret}
+		}, false},
+	}
+
+	_, client := startTestServer(t, DefaultOptions)
+	ctx := context.Background()
+	for i, tc := range tests {
+		name := fmt.Sprintf("game-%d", i)
+		host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		player, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: name, PlayerName: "P0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := tc.req(host, player)
+		req.GameName = name
+		_, err = client.StartGame(ctx, req)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: StartGame returned %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
+
+// TestGameStateHidesHands checks that no one observing a game that is
+// in progress is shown players' hands until they are old enough.
+func TestGameStateHidesHands(t *testing.T) {
+	_, client := startTestServer(t, DefaultOptions)
+	ctx := context.Background()
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{
+		GameName: "game",
+		Options:  &rummy.GameOptions{SpectatorHandDelaySeconds: 300},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var players []*rummy.JoinGameResponse
+	for _, name := range []string{"P0", "P1"} {
+		p, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, p)
+	}
+	spectator, err := client.JoinSpectator(ctx, &rummy.JoinSpectatorRequest{
+		GameName:      "game",
+		SpectatorName: "S",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  *rummy.GetGameStateRequest
+	}{
+		{"anonymous", &rummy.GetGameStateRequest{GameName: "game"}},
+		{"spectator", &rummy.GetGameStateRequest{GameName: "game", SpectatorToken: spectator.SpectatorToken}},
+		{"player", &rummy.GetGameStateRequest{
+			GameName:     "game",
+			IsPlayer:     true,
+			PlayerId:     players[0].PlayerId,
+			PlayerSecret: players[0].PlayerSecret,
+		}},
+	}
+
+	for _, tc := range tests {
+		gs, err := client.GetGameState(ctx, tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range gs.Players {
+			if len(p.Hand) > 0 {
+				t.Errorf("%v: shown hand of player %v: %v", tc.name, p.Id, p.Hand)
+			}
+		}
+		if gs.HandsRevealedSequence != 0 {
+			t.Errorf("%v: hands revealed at sequence %v", tc.name, gs.HandsRevealedSequence)
+		}
+	}
+
+	// Games may not reveal hands to spectators sooner.
+	_, err = client.CreateGame(ctx, &rummy.CreateGameRequest{
+		GameName: "sooner",
+		Options:  &rummy.GameOptions{SpectatorHandDelaySeconds: 1},
+	})
+	if err == nil {
+		t.Error("created a game that reveals hands to spectators after 1 second")
+	}
+}
+
+func TestJoinGameAllowsBots(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *rummy.GameOptions
+		ok   bool
+	}{
+		{"no options", nil, true},
+		{"max players only", &rummy.GameOptions{MaxPlayers: 2}, true},
+		{"bots disallowed", &rummy.GameOptions{DisallowBots: true}, false},
+	}
+
+	_, client := startTestServer(t, DefaultOptions)
+	ctx := context.Background()
+	for i, tc := range tests {
+		name := fmt.Sprintf("game-%d", i)
+		if _, err := client.CreateGame(ctx, &rummy.CreateGameRequest{
+			GameName: name,
+			Options:  tc.opts,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+			GameName:   name,
+			PlayerName: "CP0",
+			Strategy:   "greedy",
+		})
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: JoinGame as a computer player returned %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
+
+func TestLeaveGameInLobby(t *testing.T) {
+	_, client := startTestServer(t, DefaultOptions)
+	ctx := context.Background()
+	if _, err := client.CreateGame(ctx, &rummy.CreateGameRequest{
+		GameName: "game",
+		Options:  &rummy.GameOptions{MaxPlayers: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var players []*rummy.JoinGameResponse
+	for _, name := range []string{"P0", "P1"} {
+		p, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, p)
+	}
+
+	left := players[0]
+	if _, err := client.LeaveGame(ctx, &rummy.LeaveGameRequest{
+		GameName:     "game",
+		PlayerId:     left.PlayerId,
+		PlayerSecret: left.PlayerSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another player takes the seat, and the name may be used again.
+	joined, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: "P0"})
+	if err != nil {
+		t.Fatal(err)
+	} else if joined.PlayerId != left.PlayerId {
+		t.Errorf("joined seat %v, expected the free seat %v", joined.PlayerId, left.PlayerId)
+	}
+	// The seat can only be used with the new player's secret.
+	_, err = client.GetGameState(ctx, &rummy.GetGameStateRequest{
+		GameName:     "game",
+		IsPlayer:     true,
+		PlayerId:     left.PlayerId,
+		PlayerSecret: left.PlayerSecret,
+	})
+	if err == nil {
+		t.Error("player who left could still observe the game as a player")
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:     "game",
+		PlayerId:     joined.PlayerId,
+		PlayerSecret: joined.PlayerSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTimedOutTurnIsPlayed(t *testing.T) {
+	opts := DefaultOptions
+	// Turns are timed out by the test.
+	opts.TurnClockInterval = 0
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{
+		GameName: "game",
+		Options:  &rummy.GameOptions{TurnTimeLimitSeconds: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"P0", "P1"} {
+		if _, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.gamesMu.Lock()
+	sg := s.games["game"]
+	s.gamesMu.Unlock()
+	sg.mu.Lock()
+	playerId := sg.game.GameState().CurrentPlayerTurn
+	start := sg.game.LastSequence()
+	sg.mu.Unlock()
+
+	s.timeOutTurns(time.Now().Add(5 * time.Second))
+	sg.mu.Lock()
+	if seq := sg.game.LastSequence(); seq != start {
+		t.Errorf("turn timed out before the limit, game is at sequence %v", seq)
+	}
+	sg.mu.Unlock()
+
+	// The turn is played by the timeout strategy, which picks up from
+	// the stock, plays any melds and discards.
+	s.timeOutTurns(time.Now().Add(11 * time.Second))
+	sg.mu.Lock()
+	history := sg.game.Snapshot().History[start:]
+	sg.mu.Unlock()
+	var types []rummy.GameEvent_Type
+	for _, e := range history {
+		types = append(types, e.Type)
+		if e.Type != rummy.GameEvent_TURN_START && e.PlayerId != playerId {
+			t.Errorf("event %v was not taken for player %v", e, playerId)
+		}
+	}
+	n := len(types)
+	if n < 4 || types[0] != rummy.GameEvent_TURN_TIMEOUT || types[1] != rummy.GameEvent_PICK_UP_STOCK ||
+		types[n-2] != rummy.GameEvent_DISCARD || types[n-1] != rummy.GameEvent_TURN_START {
+		t.Errorf("timed out turn published %v, expected a timeout, pick up from the stock and discard", types)
+	}
+	for _, typ := range types[2 : n-2] {
+		if typ != rummy.GameEvent_PLAY_CARDS {
+			t.Errorf("timed out turn published %v, expected only melds to be played", types)
+		}
+	}
+	if e := history[n-1]; e.PlayerId == playerId {
+		t.Errorf("turn did not pass after timing out: %v", e)
+	}
+}
+
+// disconnectPlayer records that the player's last connection to
+// the game closed at the given time.
+func disconnectPlayer(s *RummyServer, gameName string, playerId int32, at time.Time) *serverGame {
+	s.gamesMu.Lock()
+	sg := s.games[gameName]
+	s.gamesMu.Unlock()
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.connect(playerId)
+	sg.disconnect(playerId, at)
+	return sg
+}
+
+func TestAwayPlayerIsTakenOver(t *testing.T) {
+	opts := DefaultOptions
+	// Players are marked away by the test.
+	opts.TurnClockInterval = 0
+	opts.AwayStrategy = "greedy"
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+	players := startTestGame(t, client, "game")
+
+	// The player who is not on turn goes away, so that the computer
+	// player does not act while the test checks the game.
+	state, err := client.GetGameState(ctx, &rummy.GetGameStateRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := players[1-state.CurrentPlayerTurn]
+	disconnectedAt := time.Now()
+	sg := disconnectPlayer(s, "game", p.PlayerId, disconnectedAt)
+
+	s.markAwayPlayers(disconnectedAt.Add(opts.AwayGracePeriod - time.Second))
+	sg.mu.Lock()
+	if away, _ := sg.game.IsPlayerAway(p.PlayerId); away || sg.takenOver[p.PlayerId] {
+		t.Errorf("player was marked away within the grace period")
+	}
+	sg.mu.Unlock()
+
+	s.markAwayPlayers(disconnectedAt.Add(opts.AwayGracePeriod))
+	sg.mu.Lock()
+	if away, _ := sg.game.IsPlayerAway(p.PlayerId); !away {
+		t.Error("player was not marked away after the grace period")
+	}
+	if !sg.takenOver[p.PlayerId] || sg.strategies[p.PlayerId] != opts.AwayStrategy {
+		t.Errorf("seat was taken over by %q, expected %q",
+			sg.strategies[p.PlayerId], opts.AwayStrategy)
+	}
+	bot := sg.bots[p.PlayerId]
+	sg.mu.Unlock()
+
+	// A seat that has already been taken over is not taken over again.
+	disconnectPlayer(s, "game", p.PlayerId, disconnectedAt)
+	s.markAwayPlayers(disconnectedAt.Add(opts.AwayGracePeriod))
+	sg.mu.Lock()
+	if sg.bots[p.PlayerId] != bot {
+		t.Error("computer player in seat was replaced")
+	}
+	sg.mu.Unlock()
+
+	if _, err := client.ReclaimSeat(ctx, &rummy.ReclaimSeatRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: players[1-p.PlayerId].PlayerSecret,
+	}); err == nil {
+		t.Error("seat was reclaimed with another player's secret")
+	}
+	if _, err := client.ReclaimSeat(ctx, &rummy.ReclaimSeatRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: p.PlayerSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sg.mu.Lock()
+	if away, _ := sg.game.IsPlayerAway(p.PlayerId); away {
+		t.Error("player is still away after reclaiming their seat")
+	}
+	if _, ok := sg.strategies[p.PlayerId]; ok || sg.takenOver[p.PlayerId] {
+		t.Error("computer player still holds the reclaimed seat")
+	}
+	sg.mu.Unlock()
+
+	if _, err := client.ReclaimSeat(ctx, &rummy.ReclaimSeatRequest{
+		GameName:     "game",
+		PlayerId:     p.PlayerId,
+		PlayerSecret: p.PlayerSecret,
+	}); err == nil {
+		t.Error("seat was reclaimed twice")
+	}
+}
+
+func TestAwayBotIsNotTakenOver(t *testing.T) {
+	opts := DefaultOptions
+	// Players are marked away by the test.
+	opts.TurnClockInterval = 0
+	opts.AwayStrategy = "greedy"
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+	host, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: "game"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.JoinGame(ctx, &rummy.JoinGameRequest{GameName: "game", PlayerName: "P0"}); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := client.JoinGame(ctx, &rummy.JoinGameRequest{
+		GameName:   "game",
+		PlayerName: "CP1",
+		Strategy:   "autoplay",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartGame(ctx, &rummy.StartGameRequest{
+		GameName:   "game",
+		HostSecret: host.HostSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	disconnectedAt := time.Now()
+	sg := disconnectPlayer(s, "game", cp.PlayerId, disconnectedAt)
+	sg.mu.Lock()
+	bot := sg.bots[cp.PlayerId]
+	sg.mu.Unlock()
+
+	s.markAwayPlayers(disconnectedAt.Add(opts.AwayGracePeriod))
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	if sg.takenOver[cp.PlayerId] || sg.bots[cp.PlayerId] != bot ||
+		sg.strategies[cp.PlayerId] != "autoplay" {
+		t.Errorf("computer player was taken over by %q", sg.strategies[cp.PlayerId])
+	}
+}
+
+func TestReapExpiredGames(t *testing.T) {
+	opts := DefaultOptions
+	// Games are reaped by the test.
+	opts.ReapInterval = 0
+	opts.TurnClockInterval = 0
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+
+	if _, err := client.CreateGame(ctx, &rummy.CreateGameRequest{GameName: "lobby"}); err != nil {
+		t.Fatal(err)
+	}
+	startTestGame(t, client, "abandoned")
+	startTestGame(t, client, "active")
+	players := startTestGame(t, client, "finished")
+	if _, err := client.LeaveGame(ctx, &rummy.LeaveGameRequest{
+		GameName:     "finished",
+		PlayerId:     players[0].PlayerId,
+		PlayerSecret: players[0].PlayerSecret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+
+	checkGames := func(step string, expected ...string) {
+		s.gamesMu.Lock()
+		defer s.gamesMu.Unlock()
+		if len(s.games) != len(expected) {
+			t.Errorf("%v: %v games remain, expected %v", step, len(s.games), expected)
+		}
+		for _, name := range expected {
+			if _, ok := s.games[name]; !ok {
+				t.Errorf("%v: game %v was removed", step, name)
+			}
+		}
+	}
+
+	// The time a game finished is first noticed by the reaper.
+	s.reapExpiredGames(start.Add(30 * time.Minute))
+	checkGames("before any TTL", "lobby", "abandoned", "active", "finished")
+
+	s.reapExpiredGames(start.Add(30*time.Minute + opts.CompletedTTL + time.Second))
+	checkGames("after the lobby and completed TTLs", "abandoned", "active")
+
+	s.gamesMu.Lock()
+	sg := s.games["active"]
+	s.gamesMu.Unlock()
+	sg.mu.Lock()
+	sg.lastActivity = start.Add(opts.InProgressIdleTTL - time.Hour)
+	sg.mu.Unlock()
+	s.reapExpiredGames(start.Add(opts.InProgressIdleTTL + time.Second))
+	checkGames("after the in progress TTL", "active")
+
+	if _, err := client.GetGameState(ctx, &rummy.GetGameStateRequest{GameName: "abandoned"}); err == nil {
+		t.Error("removed game could still be observed")
+	}
+}
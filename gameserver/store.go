@@ -0,0 +1,289 @@
+package gameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/timpalpant/rummy"
+)
+
+// SavedGame is the persisted form of a game managed by a RummyServer.
+type SavedGame struct {
+	Name string
+	Game *rummy.GameSnapshot
+	// Strategy names of the computer players in the game, by player id.
+	Strategies map[int32]string
+	// Parameters of the computer players' strategies, by player id.
+	StrategyParams map[int32]map[string]string
+	// Secrets provided by players when they joined, by player id.
+	Secrets    map[int32]string
+	HostSecret string
+	InviteCode string
+	// Seats of human players that have been taken over by
+	// computer players.
+	TakenOver []int32
+	// Seats of computer players that have been paused.
+	PausedBots []int32
+	// Names of the spectators who have joined the game, by token.
+	Spectators map[string]string
+	// Names of the accounts that players joined as, by player id.
+	Accounts    map[int32]string
+	CompletedAt time.Time
+}
+
+// SavedTournament is the persisted form of a tournament
+// managed by a RummyServer.
+type SavedTournament struct {
+	Tournament *rummy.Tournament
+	// Secrets of the human entrants and strategies of the
+	// computer entrants, in seed order.
+	Secrets    []string
+	Strategies []string
+}
+
+// SavedAccount is the persisted form of a player's account.
+type SavedAccount struct {
+	Name    string
+	Token   string
+	Rating  float64
+	History []*rummy.PlayerStats_Game
+}
+
+// The proto messages in saved data are encoded with jsonpb,
+// in the same form as the JSON proxy, rather than as Go structs.
+
+// savedGame, savedTournament and savedAccount have the fields
+// of the saved types, without their JSON methods.
+type (
+	savedGame       SavedGame
+	savedTournament SavedTournament
+	savedAccount    SavedAccount
+)
+
+func (sg *SavedGame) MarshalJSON() ([]byte, error) {
+	game, err := marshalProto(sg.Game)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&struct {
+		*savedGame
+		Game json.RawMessage `json:",omitempty"`
+	}{(*savedGame)(sg), game})
+}
+
+func (sg *SavedGame) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*savedGame
+		Game json.RawMessage
+	}{savedGame: (*savedGame)(sg)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	sg.Game = nil
+	if v.Game != nil {
+		sg.Game = &rummy.GameSnapshot{}
+		return unmarshalProto(v.Game, sg.Game)
+	}
+	return nil
+}
+
+func (st *SavedTournament) MarshalJSON() ([]byte, error) {
+	tournament, err := marshalProto(st.Tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&struct {
+		*savedTournament
+		Tournament json.RawMessage `json:",omitempty"`
+	}{(*savedTournament)(st), tournament})
+}
+
+func (st *SavedTournament) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*savedTournament
+		Tournament json.RawMessage
+	}{savedTournament: (*savedTournament)(st)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	st.Tournament = nil
+	if v.Tournament != nil {
+		st.Tournament = &rummy.Tournament{}
+		return unmarshalProto(v.Tournament, st.Tournament)
+	}
+	return nil
+}
+
+func (sa *SavedAccount) MarshalJSON() ([]byte, error) {
+	var history []json.RawMessage
+	for _, g := range sa.History {
+		data, err := marshalProto(g)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, data)
+	}
+
+	return json.Marshal(&struct {
+		*savedAccount
+		History []json.RawMessage
+	}{(*savedAccount)(sa), history})
+}
+
+func (sa *SavedAccount) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*savedAccount
+		History []json.RawMessage
+	}{savedAccount: (*savedAccount)(sa)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	sa.History = nil
+	for _, data := range v.History {
+		g := &rummy.PlayerStats_Game{}
+		if err := unmarshalProto(data, g); err != nil {
+			return err
+		}
+		sa.History = append(sa.History, g)
+	}
+	return nil
+}
+
+// marshalProto encodes m with jsonpb. Nil messages are encoded as nil.
+func marshalProto(m proto.Message) (json.RawMessage, error) {
+	if reflect.ValueOf(m).IsNil() {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	marshaler := jsonpb.Marshaler{OrigName: true}
+	if err := marshaler.Marshal(&buf, m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalProto decodes data encoded by marshalProto into m.
+// Unknown fields are ignored, so that fields may be removed
+// from the messages without losing saved data.
+func unmarshalProto(data json.RawMessage, m proto.Message) error {
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	return unmarshaler.Unmarshal(bytes.NewReader(data), m)
+}
+
+// Store persists the server's games so that they survive restarts.
+type Store interface {
+	// SaveGames replaces all previously saved games with the given games.
+	SaveGames(games []*SavedGame) error
+	// LoadGames returns the most recently saved games.
+	LoadGames() ([]*SavedGame, error)
+	// SaveTournaments replaces all previously saved tournaments
+	// with the given tournaments.
+	SaveTournaments(tournaments []*SavedTournament) error
+	// LoadTournaments returns the most recently saved tournaments.
+	LoadTournaments() ([]*SavedTournament, error)
+	// SaveAccounts replaces all previously saved accounts
+	// with the given accounts.
+	SaveAccounts(accounts []*SavedAccount) error
+	// LoadAccounts returns the most recently saved accounts.
+	LoadAccounts() ([]*SavedAccount, error)
+}
+
+const (
+	gamesFile       = "games.json"
+	tournamentsFile = "tournaments.json"
+	accountsFile    = "accounts.json"
+)
+
+// FileStore is a Store that keeps its data as JSON files in a directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in the given directory,
+// creating the directory if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir}, nil
+}
+
+func (fs *FileStore) SaveGames(games []*SavedGame) error {
+	return fs.writeJSON(gamesFile, games)
+}
+
+func (fs *FileStore) LoadGames() ([]*SavedGame, error) {
+	var games []*SavedGame
+	err := fs.readJSON(gamesFile, &games)
+	return games, err
+}
+
+func (fs *FileStore) SaveTournaments(tournaments []*SavedTournament) error {
+	return fs.writeJSON(tournamentsFile, tournaments)
+}
+
+func (fs *FileStore) LoadTournaments() ([]*SavedTournament, error) {
+	var tournaments []*SavedTournament
+	err := fs.readJSON(tournamentsFile, &tournaments)
+	return tournaments, err
+}
+
+func (fs *FileStore) SaveAccounts(accounts []*SavedAccount) error {
+	return fs.writeJSON(accountsFile, accounts)
+}
+
+func (fs *FileStore) LoadAccounts() ([]*SavedAccount, error) {
+	var accounts []*SavedAccount
+	err := fs.readJSON(accountsFile, &accounts)
+	return accounts, err
+}
+
+// writeJSON atomically replaces the named file with the JSON encoding of v.
+func (fs *FileStore) writeJSON(name string, v interface{}) error {
+	f, err := ioutil.TempFile(fs.dir, name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filepath.Join(fs.dir, name))
+}
+
+// readJSON decodes the named file into v.
+// If the file does not exist, v is left unchanged.
+func (fs *FileStore) readJSON(name string, v interface{}) error {
+	f, err := os.Open(filepath.Join(fs.dir, name))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(v)
+}
@@ -0,0 +1,136 @@
+package gameserver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fs, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing has been saved yet.
+	if games, err := fs.LoadGames(); err != nil || games != nil {
+		t.Errorf("LoadGames from an empty store returned %v, %v", games, err)
+	}
+
+	g := rummy.NewGame()
+	for _, name := range []string{"P0", "CP1"} {
+		if _, err := g.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	games := []*SavedGame{{
+		Name:           "game",
+		Game:           g.Snapshot(),
+		Strategies:     map[int32]string{1: "greedy"},
+		StrategyParams: map[int32]map[string]string{1: {"aggressiveness": "0.5"}},
+		Secrets:        map[int32]string{0: "secret"},
+		HostSecret:     "host",
+		InviteCode:     "invite",
+		TakenOver:      []int32{0},
+		Spectators:     map[string]string{"token": "spectator"},
+		Accounts:       map[int32]string{0: "account"},
+		CompletedAt:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+	tournaments := []*SavedTournament{{
+		Tournament: &rummy.Tournament{
+			Name:     "tournament",
+			Format:   rummy.Tournament_SWISS,
+			Entrants: []*rummy.Tournament_Entrant{{Name: "P0", Wins: 1}, {Name: "CP1"}},
+		},
+		Secrets:    []string{"secret", ""},
+		Strategies: []string{"", "greedy"},
+	}}
+	accounts := []*SavedAccount{{
+		Name:   "account",
+		Token:  "token",
+		Rating: 1516.5,
+		History: []*rummy.PlayerStats_Game{
+			{GameName: "game", Score: 50, RatingChange: 16.5},
+		},
+	}}
+
+	if err := fs.SaveGames(games); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SaveTournaments(tournaments); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SaveAccounts(accounts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Proto messages are saved in the same form as the JSON proxy.
+	data, err := ioutil.ReadFile(filepath.Join(dir, tournamentsFile))
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Contains(data, []byte(`"format": "SWISS"`)) {
+		t.Errorf("saved tournaments %s, expected the format by name", data)
+	}
+
+	loadedGames, err := fs.LoadGames()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(loadedGames) != 1 {
+		t.Fatalf("loaded %v games, expected 1", len(loadedGames))
+	}
+	// Empty lists in the snapshot are loaded as nil,
+	// so it is compared as a proto.
+	loaded, expected := *loadedGames[0], *games[0]
+	if !proto.Equal(loaded.Game, expected.Game) {
+		t.Errorf("loaded game snapshot %v, expected %v", loaded.Game, expected.Game)
+	}
+	loaded.Game, expected.Game = nil, nil
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("loaded game %+v, expected %+v", loaded, expected)
+	}
+	loadedTournaments, err := fs.LoadTournaments()
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(loadedTournaments, tournaments) {
+		t.Errorf("loaded tournaments %v, expected %v", loadedTournaments, tournaments)
+	}
+	loadedAccounts, err := fs.LoadAccounts()
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(loadedAccounts, accounts) {
+		t.Errorf("loaded accounts %v, expected %v", loadedAccounts, accounts)
+	}
+
+	// The loaded game picks up where it left off.
+	restored, err := rummy.RestoreGame(loadedGames[0].Game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.GameState(), g.GameState()) {
+		t.Errorf("restored game state %v, expected %v", restored.GameState(), g.GameState())
+	}
+
+	// Saving again replaces what was saved before.
+	if err := fs.SaveGames(nil); err != nil {
+		t.Fatal(err)
+	}
+	if loadedGames, err := fs.LoadGames(); err != nil || len(loadedGames) != 0 {
+		t.Errorf("LoadGames after saving none returned %v, %v", loadedGames, err)
+	}
+}
@@ -0,0 +1,426 @@
+package gameserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+	"github.com/timpalpant/rummy/clients/ai/strategy"
+)
+
+// serverTournament holds a Tournament along with the secrets and
+// strategies of its entrants. Tournaments are protected by
+// RummyServer.tournamentsMu.
+type serverTournament struct {
+	state *rummy.Tournament
+	// Secrets of the human entrants, and strategies of the computer
+	// entrants, in seed order.
+	secrets    []string
+	strategies []string
+	// Signalled whenever the state changes.
+	subscribers map[chan struct{}]bool
+	// The games of matches that have not been recorded yet, by name,
+	// so that their results are not lost if they are reaped first.
+	games map[string]*serverGame
+}
+
+func (t *serverTournament) notify() {
+	for ch := range t.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// currentMatches returns the matches in the current round.
+func (t *serverTournament) currentMatches() []*rummy.Tournament_Match {
+	var matches []*rummy.Tournament_Match
+	for _, m := range t.state.Matches {
+		if m.Round == t.state.Round {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// nextPairings returns the pairings for the next round.
+func (t *serverTournament) nextPairings() []pairing {
+	n := len(t.state.Entrants)
+	round := t.state.Round + 1
+	switch t.state.Format {
+	case rummy.Tournament_ROUND_ROBIN:
+		return roundRobinPairings(n, round)
+	case rummy.Tournament_SINGLE_ELIMINATION:
+		if round == 1 {
+			return eliminationPairings(n)
+		}
+		var winners []int32
+		for _, m := range t.currentMatches() {
+			winners = append(winners, m.Winner)
+		}
+		return advancePairings(winners)
+	default:
+		played := make(map[pairing]bool)
+		hadBye := make(map[int32]bool)
+		for _, m := range t.state.Matches {
+			if len(m.Entrants) == 1 {
+				hadBye[m.Entrants[0]] = true
+			} else {
+				played[orderPairing(m.Entrants[0], m.Entrants[1])] = true
+			}
+		}
+		return swissPairings(t.state.Standings, played, hadBye)
+	}
+}
+
+// recordResult completes a match in which the entrants scored the
+// given points. The winner is the entrant with the highest score who
+// did not forfeit, with ties going to the higher seed.
+func (t *serverTournament) recordResult(m *rummy.Tournament_Match, scores []int32, forfeited []bool) {
+	winner := -1
+	for i := range m.Entrants {
+		if forfeited[i] {
+			continue
+		}
+		if winner == -1 || scores[i] > scores[winner] ||
+			(scores[i] == scores[winner] && m.Entrants[i] < m.Entrants[winner]) {
+			winner = i
+		}
+	}
+	if winner == -1 {
+		winner = 0
+	}
+
+	m.Completed = true
+	m.Winner = m.Entrants[winner]
+	m.Scores = scores
+	for i, idx := range m.Entrants {
+		e := t.state.Entrants[idx]
+		e.Points += scores[i]
+		if i == winner {
+			e.Wins++
+		} else {
+			e.Losses++
+			if t.state.Format == rummy.Tournament_SINGLE_ELIMINATION {
+				e.Eliminated = true
+			}
+		}
+	}
+	t.state.Standings = standings(t.state.Entrants)
+}
+
+func (s *RummyServer) CreateTournament(ctx context.Context, req *rummy.CreateTournamentRequest) (*rummy.CreateTournamentResponse, error) {
+	glog.V(1).Infof("CreateTournament: %v", req)
+	if req.TournamentName == "" {
+		return nil, fmt.Errorf("tournament name is required")
+	} else if len(req.Entrants) < 2 {
+		return nil, fmt.Errorf("a tournament must have at least 2 entrants")
+	} else if _, ok := rummy.Tournament_Format_name[int32(req.Format)]; !ok {
+		return nil, fmt.Errorf("unknown tournament format: %v", req.Format)
+	}
+
+	rounds := numRounds(req.Format, len(req.Entrants))
+	if req.NumRounds != 0 {
+		if req.Format != rummy.Tournament_SWISS {
+			return nil, fmt.Errorf("the number of rounds may only be chosen for Swiss tournaments")
+		} else if req.NumRounds < 0 {
+			return nil, fmt.Errorf("invalid number of rounds: %v", req.NumRounds)
+		}
+		rounds = req.NumRounds
+	}
+
+	// Tournament games are always head-to-head.
+	gameOptions := rummy.DefaultGameOptions()
+	if req.GameOptions != nil {
+		gameOptions = proto.Clone(req.GameOptions).(*rummy.GameOptions)
+		if (gameOptions.MinPlayers != 0 && gameOptions.MinPlayers != 2) ||
+			(gameOptions.MaxPlayers != 0 && gameOptions.MaxPlayers != 2) {
+			return nil, fmt.Errorf("tournament games must have 2 players, not %v-%v",
+				gameOptions.MinPlayers, gameOptions.MaxPlayers)
+		}
+	}
+	gameOptions.MinPlayers = 2
+	gameOptions.MaxPlayers = 2
+	gameOptions, err := rummy.NormalizeGameOptions(gameOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &serverTournament{
+		state: &rummy.Tournament{
+			Name:        req.TournamentName,
+			Format:      req.Format,
+			GameOptions: gameOptions,
+			NumRounds:   rounds,
+		},
+		secrets:     make([]string, len(req.Entrants)),
+		strategies:  make([]string, len(req.Entrants)),
+		subscribers: make(map[chan struct{}]bool),
+		games:       make(map[string]*serverGame),
+	}
+	names := make(map[string]bool)
+	for i, e := range req.Entrants {
+		if e.Name == "" {
+			return nil, fmt.Errorf("entrant %d has no name", i)
+		} else if names[e.Name] {
+			return nil, fmt.Errorf("duplicate entrant: %v", e.Name)
+		}
+		names[e.Name] = true
+
+		if e.Strategy != "" {
+			if _, err := strategy.ForName(e.Strategy); err != nil {
+				return nil, err
+			}
+			t.strategies[i] = e.Strategy
+		} else if e.PlayerSecret != "" {
+			t.secrets[i] = e.PlayerSecret
+		} else if t.secrets[i], err = newSecret(); err != nil {
+			return nil, err
+		}
+
+		t.state.Entrants = append(t.state.Entrants, &rummy.Tournament_Entrant{
+			Name:       e.Name,
+			IsComputer: e.Strategy != "",
+		})
+	}
+	t.state.Standings = standings(t.state.Entrants)
+
+	s.gamesMu.Lock()
+	shuttingDown := s.shuttingDown
+	s.gamesMu.Unlock()
+	if shuttingDown {
+		return nil, fmt.Errorf("server is shutting down")
+	}
+
+	s.tournamentsMu.Lock()
+	defer s.tournamentsMu.Unlock()
+	if _, ok := s.tournaments[req.TournamentName]; ok {
+		return nil, fmt.Errorf("tournament %v already exists", req.TournamentName)
+	}
+	s.tournaments[req.TournamentName] = t
+
+	glog.Infof("Starting %v tournament %v with %v entrants",
+		req.Format, req.TournamentName, len(req.Entrants))
+	s.startRound(t)
+	return &rummy.CreateTournamentResponse{
+		PlayerSecrets: append([]string(nil), t.secrets...),
+	}, nil
+}
+
+// startRound pairs the entrants for the next round of the tournament
+// and starts their games. Entrants with a bye win automatically.
+// Must be called while holding s.tournamentsMu.
+func (s *RummyServer) startRound(t *serverTournament) {
+	pairings := t.nextPairings()
+	t.state.Round++
+	for _, p := range pairings {
+		m := &rummy.Tournament_Match{
+			Round:    t.state.Round,
+			Entrants: []int32{p[0]},
+		}
+		if p[1] == bye {
+			m.Completed = true
+			m.Winner = p[0]
+			m.Scores = []int32{0}
+			t.state.Entrants[p[0]].Wins++
+			t.state.Entrants[p[0]].Byes++
+		} else {
+			m.Entrants = append(m.Entrants, p[1])
+		}
+		t.state.Matches = append(t.state.Matches, m)
+	}
+
+	t.state.Standings = standings(t.state.Entrants)
+	s.startMatches(t)
+	t.notify()
+}
+
+// startMatches starts the games for any matches in the current round
+// that do not yet have one, e.g. because the server was shutting down,
+// and returns true if any were started.
+// Must be called while holding s.tournamentsMu.
+func (s *RummyServer) startMatches(t *serverTournament) bool {
+	started := false
+	for i, m := range t.currentMatches() {
+		if m.Completed || m.GameName != "" {
+			continue
+		}
+
+		seats := make([]seat, len(m.Entrants))
+		for j, idx := range m.Entrants {
+			seats[j] = seat{
+				name:     t.state.Entrants[idx].Name,
+				secret:   t.secrets[idx],
+				strategy: t.strategies[idx],
+			}
+		}
+
+		name := fmt.Sprintf("%v-round%d-%d", t.state.Name, m.Round, i+1)
+		name, err := s.startGame(name, t.state.GameOptions, seats)
+		if err != nil {
+			glog.Errorf("Error starting game for tournament %v: %v", t.state.Name, err)
+			continue
+		}
+		m.GameName = name
+		started = true
+		s.gamesMu.Lock()
+		if sg, ok := s.games[name]; ok {
+			t.games[name] = sg
+		}
+		s.gamesMu.Unlock()
+	}
+
+	return started
+}
+
+// advanceTournaments records the results of tournament games that have
+// ended, and starts the next round of each tournament once all of the
+// matches in its current round are completed.
+func (s *RummyServer) advanceTournaments() {
+	s.tournamentsMu.Lock()
+	defer s.tournamentsMu.Unlock()
+
+	for name, t := range s.tournaments {
+		if t.state.Status == rummy.Tournament_COMPLETED {
+			continue
+		}
+
+		changed := false
+		roundOver := true
+		for _, m := range t.currentMatches() {
+			if !m.Completed && m.GameName != "" && s.checkMatch(t, m) {
+				changed = true
+			}
+			roundOver = roundOver && m.Completed
+		}
+
+		if !roundOver {
+			if s.startMatches(t) || changed {
+				t.notify()
+			}
+			continue
+		}
+
+		if t.state.Round >= t.state.NumRounds {
+			t.state.Status = rummy.Tournament_COMPLETED
+			glog.Infof("Tournament %v is over: %v wins", name,
+				t.state.Entrants[t.state.Standings[0]].Name)
+			t.notify()
+		} else {
+			s.startRound(t)
+		}
+	}
+}
+
+// checkMatch records the result of the match if its game has ended,
+// and returns true if so. Games that are removed before they complete
+// are scored as they stood at the time. If the game cannot be found
+// at all, e.g. because it was not saved when the server restarted,
+// both entrants score zero.
+// Must be called while holding s.tournamentsMu.
+func (s *RummyServer) checkMatch(t *serverTournament, m *rummy.Tournament_Match) bool {
+	sg, ok := t.games[m.GameName]
+	if !ok {
+		s.gamesMu.Lock()
+		sg, ok = s.games[m.GameName]
+		s.gamesMu.Unlock()
+		if ok {
+			t.games[m.GameName] = sg
+		}
+	}
+
+	scores := make([]int32, len(m.Entrants))
+	forfeited := make([]bool, len(m.Entrants))
+	if ok {
+		// Busy games are checked again on the next sweep.
+		if !sg.mu.TryLock() {
+			return false
+		}
+		gs := sg.game.GameState()
+		sg.mu.Unlock()
+		if gs.Status != rummy.GameState_COMPLETED && gs.Status != rummy.GameState_EXPIRED {
+			return false
+		}
+
+		for i, p := range gs.Players {
+			scores[i] = p.CurrentScore
+			forfeited[i] = p.Forfeited
+		}
+	}
+
+	delete(t.games, m.GameName)
+	t.recordResult(m, scores, forfeited)
+	glog.Infof("Tournament %v: %v won %v", t.state.Name,
+		t.state.Entrants[m.Winner].Name, m.GameName)
+	return true
+}
+
+func (s *RummyServer) GetTournament(ctx context.Context, req *rummy.GetTournamentRequest) (*rummy.Tournament, error) {
+	glog.V(1).Infof("GetTournament: %v", req)
+	s.tournamentsMu.Lock()
+	defer s.tournamentsMu.Unlock()
+	t, ok := s.tournaments[req.TournamentName]
+	if !ok {
+		return nil, fmt.Errorf("no such tournament: %v", req.TournamentName)
+	}
+
+	return proto.Clone(t.state).(*rummy.Tournament), nil
+}
+
+func (s *RummyServer) SubscribeTournament(req *rummy.SubscribeTournamentRequest, stream rummy.RummyService_SubscribeTournamentServer) error {
+	glog.V(1).Infof("SubscribeTournament: %v", req)
+	s.tournamentsMu.Lock()
+	t, ok := s.tournaments[req.TournamentName]
+	if !ok {
+		s.tournamentsMu.Unlock()
+		return fmt.Errorf("no such tournament: %v", req.TournamentName)
+	}
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	t.subscribers[ch] = true
+	s.tournamentsMu.Unlock()
+
+	defer func() {
+		s.tournamentsMu.Lock()
+		delete(t.subscribers, ch)
+		s.tournamentsMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ch:
+			s.tournamentsMu.Lock()
+			state := proto.Clone(t.state).(*rummy.Tournament)
+			s.tournamentsMu.Unlock()
+			if err := stream.Send(state); err != nil {
+				return err
+			} else if state.Status == rummy.Tournament_COMPLETED {
+				return nil
+			}
+		case <-s.stop:
+			return fmt.Errorf("server is shutting down")
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// runTournaments periodically advances tournaments until the server
+// is stopped.
+func (s *RummyServer) runTournaments(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.advanceTournaments()
+		case <-s.stop:
+			return
+		}
+	}
+}
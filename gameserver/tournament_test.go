@@ -0,0 +1,94 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/timpalpant/rummy"
+)
+
+func TestCreateTournamentRequiresTwoPlayerGames(t *testing.T) {
+	tests := []struct {
+		minPlayers, maxPlayers int32
+		ok                     bool
+	}{
+		{0, 0, true},
+		{2, 2, true},
+		{0, 2, true},
+		{1, 2, false},
+		{2, 4, false},
+		{0, 3, false},
+	}
+
+	_, client := startTestServer(t, DefaultOptions)
+	for i, tc := range tests {
+		opts := &rummy.GameOptions{
+			MinPlayers: tc.minPlayers,
+			MaxPlayers: tc.maxPlayers,
+		}
+		_, err := client.CreateTournament(context.Background(), &rummy.CreateTournamentRequest{
+			TournamentName: string(rune('a' + i)),
+			Entrants: []*rummy.TournamentEntrant{
+				{Name: "alice"},
+				{Name: "bob"},
+			},
+			GameOptions: opts,
+		})
+		if (err == nil) != tc.ok {
+			t.Errorf("CreateTournament with %v-%v players returned %v, expected ok = %v",
+				tc.minPlayers, tc.maxPlayers, err, tc.ok)
+		}
+	}
+}
+
+func TestReapedMatchIsScoredAsItStood(t *testing.T) {
+	opts := DefaultOptions
+	// Tournaments are advanced by the test.
+	opts.TournamentInterval = 0
+	s, client := startTestServer(t, opts)
+	ctx := context.Background()
+
+	resp, err := client.CreateTournament(ctx, &rummy.CreateTournamentRequest{
+		TournamentName: "cup",
+		Entrants: []*rummy.TournamentEntrant{
+			{Name: "alice"},
+			{Name: "bob"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tournament, err := client.GetTournament(ctx, &rummy.GetTournamentRequest{TournamentName: "cup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gameName := tournament.Matches[0].GameName
+
+	// The top seed forfeits, and the game is reaped before
+	// the tournament sees the result.
+	if _, err := client.LeaveGame(ctx, &rummy.LeaveGameRequest{
+		GameName:     gameName,
+		PlayerId:     0,
+		PlayerSecret: resp.PlayerSecrets[0],
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Completed games are reaped some time after they are first seen.
+	s.reapExpiredGames(time.Now().Add(48 * time.Hour))
+	s.reapExpiredGames(time.Now().Add(72 * time.Hour))
+	if _, err := client.GetGameState(ctx, &rummy.GetGameStateRequest{GameName: gameName}); err == nil {
+		t.Fatal("game was not reaped")
+	}
+
+	s.advanceTournaments()
+	tournament, err = client.GetTournament(ctx, &rummy.GetTournamentRequest{TournamentName: "cup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := tournament.Matches[0]; !m.Completed || m.Winner != 1 {
+		t.Errorf("match completed = %v with winner %v, expected bob (1) to win",
+			m.Completed, m.Winner)
+	}
+}
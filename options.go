@@ -0,0 +1,81 @@
+package rummy
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/timpalpant/rummy/deck"
+)
+
+// The most players that can be dealt a hand from a single deck,
+// leaving one card for the discard pile.
+var maxPlayers = (len(deck.New()) - 1) / initialNumCards
+
+const maxTurnTimeLimitSeconds = 60 * 60
+
+// Hands are revealed to spectators at least this long after the fact.
+// Anyone may spectate a public game, including its own players, so
+// hands must be too old to be of use to them.
+const minSpectatorHandDelaySeconds = 5 * 60
+
+// DefaultGameOptions returns the options used for games that are
+// created without specifying any.
+func DefaultGameOptions() *GameOptions {
+	return &GameOptions{
+		MinPlayers: 1,
+		MaxPlayers: int32(maxPlayers),
+	}
+}
+
+// NormalizeGameOptions returns a copy of opts with defaults filled in
+// for any unset fields, or an error if the options are invalid.
+// If opts is nil, the DefaultGameOptions are returned.
+func NormalizeGameOptions(opts *GameOptions) (*GameOptions, error) {
+	if opts == nil {
+		return DefaultGameOptions(), nil
+	}
+
+	result := proto.Clone(opts).(*GameOptions)
+	if result.MinPlayers == 0 {
+		result.MinPlayers = 1
+	}
+	if result.MaxPlayers == 0 {
+		result.MaxPlayers = int32(maxPlayers)
+	}
+
+	if result.MinPlayers < 1 {
+		return nil, fmt.Errorf("invalid min players: %v", result.MinPlayers)
+	} else if result.MaxPlayers > int32(maxPlayers) {
+		return nil, fmt.Errorf("max players %v exceeds limit of %v", result.MaxPlayers, maxPlayers)
+	} else if result.MinPlayers > result.MaxPlayers {
+		return nil, fmt.Errorf("min players %v > max players %v", result.MinPlayers, result.MaxPlayers)
+	}
+
+	if _, ok := GameOptions_RulesVariant_name[int32(result.RulesVariant)]; !ok {
+		return nil, fmt.Errorf("unknown rules variant: %v", result.RulesVariant)
+	} else if _, ok := GameOptions_Visibility_name[int32(result.Visibility)]; !ok {
+		return nil, fmt.Errorf("unknown visibility: %v", result.Visibility)
+	} else if _, ok := GameOptions_SpectatorPolicy_name[int32(result.SpectatorPolicy)]; !ok {
+		return nil, fmt.Errorf("unknown spectator policy: %v", result.SpectatorPolicy)
+	}
+
+	if result.TargetScore < 0 {
+		return nil, fmt.Errorf("invalid target score: %v", result.TargetScore)
+	}
+	if result.TimeBankSeconds < 0 || result.TimeBankSeconds > maxTurnTimeLimitSeconds {
+		return nil, fmt.Errorf("time bank must be between 0 and %v seconds, got %v",
+			maxTurnTimeLimitSeconds, result.TimeBankSeconds)
+	}
+	if delay := result.SpectatorHandDelaySeconds; delay != 0 &&
+		(delay < minSpectatorHandDelaySeconds || delay > maxTurnTimeLimitSeconds) {
+		return nil, fmt.Errorf("spectator hand delay must be 0 or between %v and %v seconds, got %v",
+			minSpectatorHandDelaySeconds, maxTurnTimeLimitSeconds, delay)
+	}
+	if result.TurnTimeLimitSeconds < 0 || result.TurnTimeLimitSeconds > maxTurnTimeLimitSeconds {
+		return nil, fmt.Errorf("turn time limit must be between 0 and %v seconds, got %v",
+			maxTurnTimeLimitSeconds, result.TurnTimeLimitSeconds)
+	}
+
+	return result, nil
+}
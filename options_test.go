@@ -0,0 +1,78 @@
+package rummy
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestNormalizeGameOptions(t *testing.T) {
+	max := int32(maxPlayers)
+	all := &GameOptions{
+		MinPlayers:                2,
+		MaxPlayers:                4,
+		RulesVariant:              GameOptions_NO_RUMMY,
+		TargetScore:               500,
+		TurnTimeLimitSeconds:      30,
+		Visibility:                GameOptions_PRIVATE,
+		DisallowBots:              true,
+		SpectatorPolicy:           GameOptions_NO_SPECTATORS,
+		TimeBankSeconds:           120,
+		SpectatorHandDelaySeconds: 600,
+	}
+
+	tests := []struct {
+		name string
+		opts *GameOptions
+		// Nil if the options are invalid.
+		expected *GameOptions
+	}{
+		{"no options", nil, &GameOptions{MinPlayers: 1, MaxPlayers: max}},
+		{"defaults", &GameOptions{}, &GameOptions{MinPlayers: 1, MaxPlayers: max}},
+		// Bots are allowed unless disallowed, as when no options are given.
+		{"max players only", &GameOptions{MaxPlayers: 2}, &GameOptions{MinPlayers: 1, MaxPlayers: 2}},
+		{"min players only", &GameOptions{MinPlayers: 3}, &GameOptions{MinPlayers: 3, MaxPlayers: max}},
+		{"bots disallowed", &GameOptions{DisallowBots: true},
+			&GameOptions{MinPlayers: 1, MaxPlayers: max, DisallowBots: true}},
+		{"all options", all, all},
+		{"most players", &GameOptions{MinPlayers: max}, &GameOptions{MinPlayers: max, MaxPlayers: max}},
+		{"negative min players", &GameOptions{MinPlayers: -1}, nil},
+		{"too many players", &GameOptions{MaxPlayers: max + 1}, nil},
+		{"min players above max", &GameOptions{MinPlayers: 3, MaxPlayers: 2}, nil},
+		{"unknown rules variant", &GameOptions{RulesVariant: 99}, nil},
+		{"unknown visibility", &GameOptions{Visibility: 99}, nil},
+		{"unknown spectator policy", &GameOptions{SpectatorPolicy: 99}, nil},
+		{"negative target score", &GameOptions{TargetScore: -1}, nil},
+		{"negative turn time limit", &GameOptions{TurnTimeLimitSeconds: -1}, nil},
+		{"turn time limit too long", &GameOptions{TurnTimeLimitSeconds: 3601}, nil},
+		{"negative time bank", &GameOptions{TimeBankSeconds: -1}, nil},
+		{"time bank too long", &GameOptions{TimeBankSeconds: 3601}, nil},
+		{"shortest spectator hand delay", &GameOptions{SpectatorHandDelaySeconds: 300},
+			&GameOptions{MinPlayers: 1, MaxPlayers: max, SpectatorHandDelaySeconds: 300}},
+		{"longest spectator hand delay", &GameOptions{SpectatorHandDelaySeconds: 3600},
+			&GameOptions{MinPlayers: 1, MaxPlayers: max, SpectatorHandDelaySeconds: 3600}},
+		{"short spectator hand delay", &GameOptions{SpectatorHandDelaySeconds: 1}, nil},
+		{"spectator hand delay too long", &GameOptions{SpectatorHandDelaySeconds: 3601}, nil},
+		{"negative spectator hand delay", &GameOptions{SpectatorHandDelaySeconds: -300}, nil},
+	}
+
+	for _, tc := range tests {
+		var original *GameOptions
+		if tc.opts != nil {
+			original = proto.Clone(tc.opts).(*GameOptions)
+		}
+		result, err := NormalizeGameOptions(tc.opts)
+		if tc.expected == nil {
+			if err == nil {
+				t.Errorf("%v: NormalizeGameOptions(%v) = %v, expected error", tc.name, tc.opts, result)
+			}
+		} else if err != nil {
+			t.Errorf("%v: NormalizeGameOptions(%v) returned error: %v", tc.name, tc.opts, err)
+		} else if !proto.Equal(result, tc.expected) {
+			t.Errorf("%v: NormalizeGameOptions(%v) = %v, expected %v", tc.name, tc.opts, result, tc.expected)
+		}
+		if tc.opts != nil && !proto.Equal(tc.opts, original) {
+			t.Errorf("%v: NormalizeGameOptions modified its argument to %v", tc.name, tc.opts)
+		}
+	}
+}
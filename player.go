@@ -1,6 +1,8 @@
 package rummy
 
 import (
+	"time"
+
 	"github.com/timpalpant/rummy/deck"
 	"github.com/timpalpant/rummy/meld"
 	"github.com/timpalpant/rummy/scoring"
@@ -14,10 +16,21 @@ type player struct {
 	hand Hand
 	// Played melds (sets or runs).
 	melds []meld.Meld
+	// The id of each of the melds.
+	meldIds []int32
 	// Played rummies off of other melds.
 	// The melds may be ones we have played, or ones that another
 	// player in the Game has played.
 	rummies []deck.Card
+	// Time remaining in the player's time bank, as of the start
+	// of the current turn.
+	timeBank time.Duration
+	// True if the player has lost their connection to the game.
+	away bool
+	// True if the player has left the game.
+	forfeited bool
+	// The hand dealt to the player.
+	initialHand []deck.Card
 }
 
 // Score returns the current score for this player, the sum of
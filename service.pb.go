@@ -19,12 +19,94 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type StrategyParam_Type int32
+
+const (
+	StrategyParam_INT   StrategyParam_Type = 0
+	StrategyParam_FLOAT StrategyParam_Type = 1
+	StrategyParam_BOOL  StrategyParam_Type = 2
+)
+
+var StrategyParam_Type_name = map[int32]string{
+	0: "INT",
+	1: "FLOAT",
+	2: "BOOL",
+}
+var StrategyParam_Type_value = map[string]int32{
+	"INT":   0,
+	"FLOAT": 1,
+	"BOOL":  2,
+}
+
+func (x StrategyParam_Type) String() string {
+	return proto.EnumName(StrategyParam_Type_name, int32(x))
+}
+func (StrategyParam_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{22, 0} }
+
+type Tournament_Format int32
+
+const (
+	// Every entrant plays every other entrant once.
+	Tournament_ROUND_ROBIN Tournament_Format = 0
+	// Losers are eliminated until one entrant remains.
+	// Byes are given to the top seeds if needed.
+	Tournament_SINGLE_ELIMINATION Tournament_Format = 1
+	// Entrants with similar records are paired each round,
+	// without rematches where possible.
+	Tournament_SWISS Tournament_Format = 2
+)
+
+var Tournament_Format_name = map[int32]string{
+	0: "ROUND_ROBIN",
+	1: "SINGLE_ELIMINATION",
+	2: "SWISS",
+}
+var Tournament_Format_value = map[string]int32{
+	"ROUND_ROBIN":        0,
+	"SINGLE_ELIMINATION": 1,
+	"SWISS":              2,
+}
+
+func (x Tournament_Format) String() string {
+	return proto.EnumName(Tournament_Format_name, int32(x))
+}
+func (Tournament_Format) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{50, 0} }
+
+type Tournament_Status int32
+
+const (
+	Tournament_IN_PROGRESS Tournament_Status = 0
+	Tournament_COMPLETED   Tournament_Status = 1
+)
+
+var Tournament_Status_name = map[int32]string{
+	0: "IN_PROGRESS",
+	1: "COMPLETED",
+}
+var Tournament_Status_value = map[string]int32{
+	"IN_PROGRESS": 0,
+	"COMPLETED":   1,
+}
+
+func (x Tournament_Status) String() string {
+	return proto.EnumName(Tournament_Status_name, int32(x))
+}
+func (Tournament_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{50, 1} }
+
 // Create a new game with the given name.
 // Each game must have a unique name; if the name has been
 // used before, an error will be returned. Games must be
 // created before they can be joined.
 type CreateGameRequest struct {
 	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	// Optional, if not provided then the default options are used:
+	// 1 to 7 players, standard rules, no turn time limit,
+	// public, bots and spectators allowed.
+	Options *GameOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+	// Optional password that players must provide to join a private
+	// game. If the game is private and no password is provided, then
+	// a random invite code is generated.
+	InviteCode string `protobuf:"bytes,3,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
 }
 
 func (m *CreateGameRequest) Reset()                    { *m = CreateGameRequest{} }
@@ -39,7 +121,26 @@ func (m *CreateGameRequest) GetGameName() string {
 	return ""
 }
 
+func (m *CreateGameRequest) GetOptions() *GameOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *CreateGameRequest) GetInviteCode() string {
+	if m != nil {
+		return m.InviteCode
+	}
+	return ""
+}
+
 type CreateGameResponse struct {
+	// The code that players must provide to join a private game.
+	InviteCode string `protobuf:"bytes,1,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
+	// A secret that identifies the creator of the game,
+	// e.g. to rotate or revoke the invite code.
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
 }
 
 func (m *CreateGameResponse) Reset()                    { *m = CreateGameResponse{} }
@@ -47,6 +148,20 @@ func (m *CreateGameResponse) String() string            { return proto.CompactTe
 func (*CreateGameResponse) ProtoMessage()               {}
 func (*CreateGameResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
 
+func (m *CreateGameResponse) GetInviteCode() string {
+	if m != nil {
+		return m.InviteCode
+	}
+	return ""
+}
+
+func (m *CreateGameResponse) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
+}
+
 // Join a game (that must already have been created)
 // as the player with the given name. Only one player with
 // each name is allowed in a game. If a player with this
@@ -65,6 +180,17 @@ type JoinGameRequest struct {
 	// Optional, if provided then initialize a computer player
 	// with this strategy.
 	Strategy string `protobuf:"bytes,4,opt,name=strategy" json:"strategy,omitempty"`
+	// Required to join private games.
+	InviteCode string `protobuf:"bytes,5,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
+	// Optional, if provided then the player joins as this account,
+	// and the result of the game counts towards its stats and rating.
+	AccountName  string `protobuf:"bytes,6,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	AccountToken string `protobuf:"bytes,7,opt,name=account_token,json=accountToken" json:"account_token,omitempty"`
+	// Values for the parameters of the strategy, as listed by
+	// ListStrategies, by name. Values are formatted according to the
+	// parameter's type, e.g. "3", "0.5" or "true". Parameters that are
+	// not given take their default values.
+	StrategyParams map[string]string `protobuf:"bytes,8,rep,name=strategy_params,json=strategyParams" json:"strategy_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *JoinGameRequest) Reset()                    { *m = JoinGameRequest{} }
@@ -100,9 +226,40 @@ func (m *JoinGameRequest) GetStrategy() string {
 	return ""
 }
 
+func (m *JoinGameRequest) GetInviteCode() string {
+	if m != nil {
+		return m.InviteCode
+	}
+	return ""
+}
+
+func (m *JoinGameRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *JoinGameRequest) GetAccountToken() string {
+	if m != nil {
+		return m.AccountToken
+	}
+	return ""
+}
+
+func (m *JoinGameRequest) GetStrategyParams() map[string]string {
+	if m != nil {
+		return m.StrategyParams
+	}
+	return nil
+}
+
 type JoinGameResponse struct {
 	// The player id within this game. Must be included in all requests.
 	PlayerId int32 `protobuf:"varint,1,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	// The player's secret: either the one given in the request, or a
+	// random one if none was. Must be included in all requests.
+	PlayerSecret string `protobuf:"bytes,2,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
 }
 
 func (m *JoinGameResponse) Reset()                    { *m = JoinGameResponse{} }
@@ -117,17 +274,198 @@ func (m *JoinGameResponse) GetPlayerId() int32 {
 	return 0
 }
 
+func (m *JoinGameResponse) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+// Wait in the matchmaking queue for a table with other players
+// who have the same preferences.
+type FindMatchRequest struct {
+	PlayerName string `protobuf:"bytes,1,opt,name=player_name,json=playerName" json:"player_name,omitempty"`
+	// Secret for the assigned seat. If empty, a random one is generated.
+	PlayerSecret string `protobuf:"bytes,2,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	// Number of players at the table, including any computer players.
+	// Defaults to 2.
+	NumPlayers   int32                    `protobuf:"varint,3,opt,name=num_players,json=numPlayers" json:"num_players,omitempty"`
+	RulesVariant GameOptions_RulesVariant `protobuf:"varint,4,opt,name=rules_variant,json=rulesVariant,enum=rummy.GameOptions_RulesVariant" json:"rules_variant,omitempty"`
+	// If true, and not enough players are found within the server's
+	// wait timeout, the remaining seats are filled by computer players.
+	AllowBots bool `protobuf:"varint,5,opt,name=allow_bots,json=allowBots" json:"allow_bots,omitempty"`
+	// Optional, if provided then the player is seated as this account,
+	// and the result of the game counts towards its stats and rating.
+	AccountName  string `protobuf:"bytes,6,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	AccountToken string `protobuf:"bytes,7,opt,name=account_token,json=accountToken" json:"account_token,omitempty"`
+}
+
+func (m *FindMatchRequest) Reset()                    { *m = FindMatchRequest{} }
+func (m *FindMatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*FindMatchRequest) ProtoMessage()               {}
+func (*FindMatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+func (m *FindMatchRequest) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
+	}
+	return ""
+}
+
+func (m *FindMatchRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *FindMatchRequest) GetNumPlayers() int32 {
+	if m != nil {
+		return m.NumPlayers
+	}
+	return 0
+}
+
+func (m *FindMatchRequest) GetRulesVariant() GameOptions_RulesVariant {
+	if m != nil {
+		return m.RulesVariant
+	}
+	return GameOptions_STANDARD
+}
+
+func (m *FindMatchRequest) GetAllowBots() bool {
+	if m != nil {
+		return m.AllowBots
+	}
+	return false
+}
+
+func (m *FindMatchRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *FindMatchRequest) GetAccountToken() string {
+	if m != nil {
+		return m.AccountToken
+	}
+	return ""
+}
+
+// Responses are sent while waiting in the queue, and once the player
+// has been seated in a game that has started. The stream is then closed.
+type FindMatchResponse struct {
+	// Number of players waiting for this kind of table, including
+	// this player.
+	PlayersWaiting int32 `protobuf:"varint,1,opt,name=players_waiting,json=playersWaiting" json:"players_waiting,omitempty"`
+	// The following are set once the player has been seated.
+	GameName     string `protobuf:"bytes,2,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,4,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+}
+
+func (m *FindMatchResponse) Reset()                    { *m = FindMatchResponse{} }
+func (m *FindMatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*FindMatchResponse) ProtoMessage()               {}
+func (*FindMatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *FindMatchResponse) GetPlayersWaiting() int32 {
+	if m != nil {
+		return m.PlayersWaiting
+	}
+	return 0
+}
+
+func (m *FindMatchResponse) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *FindMatchResponse) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *FindMatchResponse) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+// Join a game as a spectator, to observe it without playing.
+// The game's spectator policy must allow spectators, and an invite
+// code is required for private games.
+type JoinSpectatorRequest struct {
+	GameName      string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	SpectatorName string `protobuf:"bytes,2,opt,name=spectator_name,json=spectatorName" json:"spectator_name,omitempty"`
+	InviteCode    string `protobuf:"bytes,3,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
+}
+
+func (m *JoinSpectatorRequest) Reset()                    { *m = JoinSpectatorRequest{} }
+func (m *JoinSpectatorRequest) String() string            { return proto.CompactTextString(m) }
+func (*JoinSpectatorRequest) ProtoMessage()               {}
+func (*JoinSpectatorRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *JoinSpectatorRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *JoinSpectatorRequest) GetSpectatorName() string {
+	if m != nil {
+		return m.SpectatorName
+	}
+	return ""
+}
+
+func (m *JoinSpectatorRequest) GetInviteCode() string {
+	if m != nil {
+		return m.InviteCode
+	}
+	return ""
+}
+
+type JoinSpectatorResponse struct {
+	// Must be included in requests to observe the game.
+	SpectatorToken string `protobuf:"bytes,1,opt,name=spectator_token,json=spectatorToken" json:"spectator_token,omitempty"`
+}
+
+func (m *JoinSpectatorResponse) Reset()                    { *m = JoinSpectatorResponse{} }
+func (m *JoinSpectatorResponse) String() string            { return proto.CompactTextString(m) }
+func (*JoinSpectatorResponse) ProtoMessage()               {}
+func (*JoinSpectatorResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *JoinSpectatorResponse) GetSpectatorToken() string {
+	if m != nil {
+		return m.SpectatorToken
+	}
+	return ""
+}
+
 // Start the given name, dealing cards to each of the joined players.
 // Once a game has been started, no additional players may join.
-// TODO(palpant): Only let game creator start the game.
+// The game may be started by its creator, with the host secret,
+// or by any player who has joined it, with their player secret.
 type StartGameRequest struct {
-	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret   string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+	PlayerId     int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,4,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
 }
 
 func (m *StartGameRequest) Reset()                    { *m = StartGameRequest{} }
 func (m *StartGameRequest) String() string            { return proto.CompactTextString(m) }
 func (*StartGameRequest) ProtoMessage()               {}
-func (*StartGameRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*StartGameRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
 
 func (m *StartGameRequest) GetGameName() string {
 	if m != nil {
@@ -136,23 +474,50 @@ func (m *StartGameRequest) GetGameName() string {
 	return ""
 }
 
+func (m *StartGameRequest) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
+}
+
+func (m *StartGameRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *StartGameRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
 type StartGameResponse struct {
 }
 
 func (m *StartGameResponse) Reset()                    { *m = StartGameResponse{} }
 func (m *StartGameResponse) String() string            { return proto.CompactTextString(m) }
 func (*StartGameResponse) ProtoMessage()               {}
-func (*StartGameResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (*StartGameResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
 
 // Get the publicly-observable game state.
 type GetGameStateRequest struct {
 	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	// Credentials of the player or spectator requesting the state.
+	// They may be omitted only for public games that allow spectators.
+	IsPlayer       bool   `protobuf:"varint,2,opt,name=is_player,json=isPlayer" json:"is_player,omitempty"`
+	PlayerId       int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret   string `protobuf:"bytes,4,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	SpectatorToken string `protobuf:"bytes,5,opt,name=spectator_token,json=spectatorToken" json:"spectator_token,omitempty"`
 }
 
 func (m *GetGameStateRequest) Reset()                    { *m = GetGameStateRequest{} }
 func (m *GetGameStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetGameStateRequest) ProtoMessage()               {}
-func (*GetGameStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*GetGameStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
 
 func (m *GetGameStateRequest) GetGameName() string {
 	if m != nil {
@@ -161,6 +526,34 @@ func (m *GetGameStateRequest) GetGameName() string {
 	return ""
 }
 
+func (m *GetGameStateRequest) GetIsPlayer() bool {
+	if m != nil {
+		return m.IsPlayer
+	}
+	return false
+}
+
+func (m *GetGameStateRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *GetGameStateRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *GetGameStateRequest) GetSpectatorToken() string {
+	if m != nil {
+		return m.SpectatorToken
+	}
+	return ""
+}
+
 // Get the cards currently in a player's hand.
 type GetHandCardsRequest struct {
 	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
@@ -171,7 +564,7 @@ type GetHandCardsRequest struct {
 func (m *GetHandCardsRequest) Reset()                    { *m = GetHandCardsRequest{} }
 func (m *GetHandCardsRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetHandCardsRequest) ProtoMessage()               {}
-func (*GetHandCardsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+func (*GetHandCardsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
 
 func (m *GetHandCardsRequest) GetGameName() string {
 	if m != nil {
@@ -201,7 +594,7 @@ type GetHandCardsResponse struct {
 func (m *GetHandCardsResponse) Reset()                    { *m = GetHandCardsResponse{} }
 func (m *GetHandCardsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetHandCardsResponse) ProtoMessage()               {}
-func (*GetHandCardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+func (*GetHandCardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
 
 func (m *GetHandCardsResponse) GetCards() []*deck.Card {
 	if m != nil {
@@ -210,404 +603,2345 @@ func (m *GetHandCardsResponse) GetCards() []*deck.Card {
 	return nil
 }
 
-// Subscribe to game events. This allows players to observe the
-// gameplay of other players.
-type SubscribeGameRequest struct {
-	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
-}
-
-func (m *SubscribeGameRequest) Reset()                    { *m = SubscribeGameRequest{} }
-func (m *SubscribeGameRequest) String() string            { return proto.CompactTextString(m) }
-func (*SubscribeGameRequest) ProtoMessage()               {}
-func (*SubscribeGameRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
-
-func (m *SubscribeGameRequest) GetGameName() string {
-	if m != nil {
-		return m.GameName
-	}
-	return ""
-}
-
-// Pick up a card from the stock. A player should initiate this request
-// when beginning their turn. Alternatively, a player may issue a
-// PickUpDiscardRequest.
-type PickUpStockRequest struct {
+// Get a player's view of the game, including their hand and the
+// actions they may currently take.
+type GetPlayerViewRequest struct {
 	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
 	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
 	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
 }
 
-func (m *PickUpStockRequest) Reset()                    { *m = PickUpStockRequest{} }
-func (m *PickUpStockRequest) String() string            { return proto.CompactTextString(m) }
-func (*PickUpStockRequest) ProtoMessage()               {}
-func (*PickUpStockRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+func (m *GetPlayerViewRequest) Reset()                    { *m = GetPlayerViewRequest{} }
+func (m *GetPlayerViewRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetPlayerViewRequest) ProtoMessage()               {}
+func (*GetPlayerViewRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
 
-func (m *PickUpStockRequest) GetGameName() string {
+func (m *GetPlayerViewRequest) GetGameName() string {
 	if m != nil {
 		return m.GameName
 	}
 	return ""
 }
 
-func (m *PickUpStockRequest) GetPlayerId() int32 {
+func (m *GetPlayerViewRequest) GetPlayerId() int32 {
 	if m != nil {
 		return m.PlayerId
 	}
 	return 0
 }
 
-func (m *PickUpStockRequest) GetPlayerSecret() string {
+func (m *GetPlayerViewRequest) GetPlayerSecret() string {
 	if m != nil {
 		return m.PlayerSecret
 	}
 	return ""
 }
 
-// Returns the card that was picked up from the stock.
-type PickUpStockResponse struct {
-	Card *deck.Card `protobuf:"bytes,1,opt,name=card" json:"card,omitempty"`
+// List the public games on the server.
+// Private games are never listed.
+type ListGamesRequest struct {
 }
 
-func (m *PickUpStockResponse) Reset()                    { *m = PickUpStockResponse{} }
-func (m *PickUpStockResponse) String() string            { return proto.CompactTextString(m) }
-func (*PickUpStockResponse) ProtoMessage()               {}
-func (*PickUpStockResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+func (m *ListGamesRequest) Reset()                    { *m = ListGamesRequest{} }
+func (m *ListGamesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGamesRequest) ProtoMessage()               {}
+func (*ListGamesRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
 
-func (m *PickUpStockResponse) GetCard() *deck.Card {
+type ListGamesResponse struct {
+	Games []*ListGamesResponse_Game `protobuf:"bytes,1,rep,name=games" json:"games,omitempty"`
+}
+
+func (m *ListGamesResponse) Reset()                    { *m = ListGamesResponse{} }
+func (m *ListGamesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGamesResponse) ProtoMessage()               {}
+func (*ListGamesResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *ListGamesResponse) GetGames() []*ListGamesResponse_Game {
 	if m != nil {
-		return m.Card
+		return m.Games
 	}
 	return nil
 }
 
-// Pick up N cards from the discard pile. A player may initiate this
-// request when beginning their turn. Alternatively, a player may pick
-// up a card from the stock. The N cards picked up are from the top of
-// the discard stack. The bottom-most card must be played this turn.
-type PickUpDiscardRequest struct {
-	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
-	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
-	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
-	NCards       int32  `protobuf:"varint,4,opt,name=n_cards,json=nCards" json:"n_cards,omitempty"`
+type ListGamesResponse_Game struct {
+	GameName   string           `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	Status     GameState_Status `protobuf:"varint,2,opt,name=status,enum=rummy.GameState_Status" json:"status,omitempty"`
+	NumPlayers int32            `protobuf:"varint,3,opt,name=num_players,json=numPlayers" json:"num_players,omitempty"`
+	Options    *GameOptions     `protobuf:"bytes,4,opt,name=options" json:"options,omitempty"`
 }
 
-func (m *PickUpDiscardRequest) Reset()                    { *m = PickUpDiscardRequest{} }
-func (m *PickUpDiscardRequest) String() string            { return proto.CompactTextString(m) }
-func (*PickUpDiscardRequest) ProtoMessage()               {}
-func (*PickUpDiscardRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+func (m *ListGamesResponse_Game) Reset()                    { *m = ListGamesResponse_Game{} }
+func (m *ListGamesResponse_Game) String() string            { return proto.CompactTextString(m) }
+func (*ListGamesResponse_Game) ProtoMessage()               {}
+func (*ListGamesResponse_Game) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15, 0} }
 
-func (m *PickUpDiscardRequest) GetGameName() string {
+func (m *ListGamesResponse_Game) GetGameName() string {
 	if m != nil {
 		return m.GameName
 	}
 	return ""
 }
 
-func (m *PickUpDiscardRequest) GetPlayerId() int32 {
+func (m *ListGamesResponse_Game) GetStatus() GameState_Status {
 	if m != nil {
-		return m.PlayerId
+		return m.Status
 	}
-	return 0
+	return GameState_LOBBY
 }
 
-func (m *PickUpDiscardRequest) GetPlayerSecret() string {
+func (m *ListGamesResponse_Game) GetNumPlayers() int32 {
 	if m != nil {
-		return m.PlayerSecret
+		return m.NumPlayers
 	}
-	return ""
+	return 0
 }
 
-func (m *PickUpDiscardRequest) GetNCards() int32 {
+func (m *ListGamesResponse_Game) GetOptions() *GameOptions {
 	if m != nil {
-		return m.NCards
+		return m.Options
 	}
-	return 0
+	return nil
 }
 
-// Returns the cards picked up from the discard pile.
-type PickUpDiscardResponse struct {
-	Cards []*deck.Card `protobuf:"bytes,1,rep,name=cards" json:"cards,omitempty"`
+// Change the invite code of a private game. Only the host of the
+// game may do this. If revoke is true, then the current code is
+// revoked and no one may join until a new code is set. Otherwise,
+// the invite code is replaced by the given code, or by a new
+// random code if none is given.
+type UpdateInviteCodeRequest struct {
+	GameName   string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+	Revoke     bool   `protobuf:"varint,3,opt,name=revoke" json:"revoke,omitempty"`
+	InviteCode string `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
 }
 
-func (m *PickUpDiscardResponse) Reset()                    { *m = PickUpDiscardResponse{} }
-func (m *PickUpDiscardResponse) String() string            { return proto.CompactTextString(m) }
-func (*PickUpDiscardResponse) ProtoMessage()               {}
-func (*PickUpDiscardResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+func (m *UpdateInviteCodeRequest) Reset()                    { *m = UpdateInviteCodeRequest{} }
+func (m *UpdateInviteCodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateInviteCodeRequest) ProtoMessage()               {}
+func (*UpdateInviteCodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
 
-func (m *PickUpDiscardResponse) GetCards() []*deck.Card {
+func (m *UpdateInviteCodeRequest) GetGameName() string {
 	if m != nil {
-		return m.Cards
+		return m.GameName
 	}
-	return nil
+	return ""
 }
 
-// Play cards for points. The cards must either form a new Meld,
-// or rummy off of a meld that has previously been played (by any player).
-// Players may issue this request only when it is their turn and they have
-// picked up cards (either from the stock or the discard pile).
-// This request may be issued multiple times in a single turn.
-type PlayCardsRequest struct {
-	GameName     string       `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
-	PlayerId     int32        `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
-	PlayerSecret string       `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
-	Cards        []*deck.Card `protobuf:"bytes,4,rep,name=cards" json:"cards,omitempty"`
+func (m *UpdateInviteCodeRequest) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
 }
 
-func (m *PlayCardsRequest) Reset()                    { *m = PlayCardsRequest{} }
-func (m *PlayCardsRequest) String() string            { return proto.CompactTextString(m) }
-func (*PlayCardsRequest) ProtoMessage()               {}
-func (*PlayCardsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+func (m *UpdateInviteCodeRequest) GetRevoke() bool {
+	if m != nil {
+		return m.Revoke
+	}
+	return false
+}
 
-func (m *PlayCardsRequest) GetGameName() string {
+func (m *UpdateInviteCodeRequest) GetInviteCode() string {
 	if m != nil {
-		return m.GameName
+		return m.InviteCode
 	}
 	return ""
 }
 
-func (m *PlayCardsRequest) GetPlayerId() int32 {
+type UpdateInviteCodeResponse struct {
+	// The new invite code, or empty if it was revoked.
+	InviteCode string `protobuf:"bytes,1,opt,name=invite_code,json=inviteCode" json:"invite_code,omitempty"`
+}
+
+func (m *UpdateInviteCodeResponse) Reset()                    { *m = UpdateInviteCodeResponse{} }
+func (m *UpdateInviteCodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateInviteCodeResponse) ProtoMessage()               {}
+func (*UpdateInviteCodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
+
+func (m *UpdateInviteCodeResponse) GetInviteCode() string {
 	if m != nil {
-		return m.PlayerId
+		return m.InviteCode
 	}
-	return 0
+	return ""
 }
 
-func (m *PlayCardsRequest) GetPlayerSecret() string {
+// Hand the seat of a player who is away to a computer player with
+// the given strategy. Only the host of the game may do this.
+type TakeOverSeatRequest struct {
+	GameName   string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+	PlayerId   int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	Strategy   string `protobuf:"bytes,4,opt,name=strategy" json:"strategy,omitempty"`
+}
+
+func (m *TakeOverSeatRequest) Reset()                    { *m = TakeOverSeatRequest{} }
+func (m *TakeOverSeatRequest) String() string            { return proto.CompactTextString(m) }
+func (*TakeOverSeatRequest) ProtoMessage()               {}
+func (*TakeOverSeatRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
+
+func (m *TakeOverSeatRequest) GetGameName() string {
 	if m != nil {
-		return m.PlayerSecret
+		return m.GameName
 	}
 	return ""
 }
 
-func (m *PlayCardsRequest) GetCards() []*deck.Card {
+func (m *TakeOverSeatRequest) GetHostSecret() string {
 	if m != nil {
-		return m.Cards
+		return m.HostSecret
 	}
-	return nil
+	return ""
 }
 
-type PlayCardsResponse struct {
-	Score int32 `protobuf:"varint,1,opt,name=score" json:"score,omitempty"`
+func (m *TakeOverSeatRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
 }
 
-func (m *PlayCardsResponse) Reset()                    { *m = PlayCardsResponse{} }
-func (m *PlayCardsResponse) String() string            { return proto.CompactTextString(m) }
-func (*PlayCardsResponse) ProtoMessage()               {}
-func (*PlayCardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
-
-func (m *PlayCardsResponse) GetScore() int32 {
+func (m *TakeOverSeatRequest) GetStrategy() string {
 	if m != nil {
-		return m.Score
+		return m.Strategy
 	}
-	return 0
+	return ""
 }
 
-// Discard a card from hand into the discard pile.
-// Players may play this card after picking up cards and (optionally)
-// playing cards for points. Discarding a card ends the player's turn.
-type DiscardCardRequest struct {
-	GameName     string     `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
-	PlayerId     int32      `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
-	PlayerSecret string     `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
-	Card         *deck.Card `protobuf:"bytes,4,opt,name=card" json:"card,omitempty"`
+type TakeOverSeatResponse struct {
 }
 
-func (m *DiscardCardRequest) Reset()                    { *m = DiscardCardRequest{} }
-func (m *DiscardCardRequest) String() string            { return proto.CompactTextString(m) }
-func (*DiscardCardRequest) ProtoMessage()               {}
-func (*DiscardCardRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+func (m *TakeOverSeatResponse) Reset()                    { *m = TakeOverSeatResponse{} }
+func (m *TakeOverSeatResponse) String() string            { return proto.CompactTextString(m) }
+func (*TakeOverSeatResponse) ProtoMessage()               {}
+func (*TakeOverSeatResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
 
-func (m *DiscardCardRequest) GetGameName() string {
+// Return control of a seat that was taken over by a computer player
+// to the player who originally joined in it.
+type ReclaimSeatRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+}
+
+func (m *ReclaimSeatRequest) Reset()                    { *m = ReclaimSeatRequest{} }
+func (m *ReclaimSeatRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReclaimSeatRequest) ProtoMessage()               {}
+func (*ReclaimSeatRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{20} }
+
+func (m *ReclaimSeatRequest) GetGameName() string {
 	if m != nil {
 		return m.GameName
 	}
 	return ""
 }
 
-func (m *DiscardCardRequest) GetPlayerId() int32 {
+func (m *ReclaimSeatRequest) GetPlayerId() int32 {
 	if m != nil {
 		return m.PlayerId
 	}
 	return 0
 }
 
-func (m *DiscardCardRequest) GetPlayerSecret() string {
+func (m *ReclaimSeatRequest) GetPlayerSecret() string {
 	if m != nil {
 		return m.PlayerSecret
 	}
 	return ""
 }
 
-func (m *DiscardCardRequest) GetCard() *deck.Card {
-	if m != nil {
-		return m.Card
-	}
-	return nil
+type ReclaimSeatResponse struct {
 }
 
-type DiscardCardResponse struct {
+func (m *ReclaimSeatResponse) Reset()                    { *m = ReclaimSeatResponse{} }
+func (m *ReclaimSeatResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReclaimSeatResponse) ProtoMessage()               {}
+func (*ReclaimSeatResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{21} }
+
+// A tunable parameter of a computer player strategy.
+type StrategyParam struct {
+	Name        string             `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Description string             `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Type        StrategyParam_Type `protobuf:"varint,3,opt,name=type,enum=rummy.StrategyParam_Type" json:"type,omitempty"`
+	// The value used if none is given, formatted in the same way.
+	DefaultValue string `protobuf:"bytes,4,opt,name=default_value,json=defaultValue" json:"default_value,omitempty"`
+	// Bounds on the values of INT and FLOAT parameters, inclusive.
+	Min float64 `protobuf:"fixed64,5,opt,name=min" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,6,opt,name=max" json:"max,omitempty"`
 }
 
-func (m *DiscardCardResponse) Reset()                    { *m = DiscardCardResponse{} }
-func (m *DiscardCardResponse) String() string            { return proto.CompactTextString(m) }
-func (*DiscardCardResponse) ProtoMessage()               {}
-func (*DiscardCardResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
+func (m *StrategyParam) Reset()                    { *m = StrategyParam{} }
+func (m *StrategyParam) String() string            { return proto.CompactTextString(m) }
+func (*StrategyParam) ProtoMessage()               {}
+func (*StrategyParam) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{22} }
 
-// Call a rummy observed in the discard pile. This request may be performed
-// at any time when a player observes that a possible rummy has been created
-// either as the result of a discard or newly played cards.
-type CallRummyRequest struct {
-	GameName     string       `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
-	PlayerId     int32        `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
-	PlayerSecret string       `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
-	Cards        []*deck.Card `protobuf:"bytes,4,rep,name=cards" json:"cards,omitempty"`
+func (m *StrategyParam) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *CallRummyRequest) Reset()                    { *m = CallRummyRequest{} }
-func (m *CallRummyRequest) String() string            { return proto.CompactTextString(m) }
-func (*CallRummyRequest) ProtoMessage()               {}
-func (*CallRummyRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
+func (m *StrategyParam) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
 
-func (m *CallRummyRequest) GetGameName() string {
+func (m *StrategyParam) GetType() StrategyParam_Type {
 	if m != nil {
-		return m.GameName
+		return m.Type
+	}
+	return StrategyParam_INT
+}
+
+func (m *StrategyParam) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
 	}
 	return ""
 }
 
-func (m *CallRummyRequest) GetPlayerId() int32 {
+func (m *StrategyParam) GetMin() float64 {
 	if m != nil {
-		return m.PlayerId
+		return m.Min
 	}
 	return 0
 }
 
-func (m *CallRummyRequest) GetPlayerSecret() string {
+func (m *StrategyParam) GetMax() float64 {
 	if m != nil {
-		return m.PlayerSecret
+		return m.Max
+	}
+	return 0
+}
+
+// A strategy that computer players may use.
+type StrategyInfo struct {
+	Name        string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Description string           `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Params      []*StrategyParam `protobuf:"bytes,3,rep,name=params" json:"params,omitempty"`
+}
+
+func (m *StrategyInfo) Reset()                    { *m = StrategyInfo{} }
+func (m *StrategyInfo) String() string            { return proto.CompactTextString(m) }
+func (*StrategyInfo) ProtoMessage()               {}
+func (*StrategyInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{23} }
+
+func (m *StrategyInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
 	return ""
 }
 
-func (m *CallRummyRequest) GetCards() []*deck.Card {
+func (m *StrategyInfo) GetDescription() string {
 	if m != nil {
-		return m.Cards
+		return m.Description
+	}
+	return ""
+}
+
+func (m *StrategyInfo) GetParams() []*StrategyParam {
+	if m != nil {
+		return m.Params
 	}
 	return nil
 }
 
-type CallRummyResponse struct {
+// List the strategies available for computer players.
+type ListStrategiesRequest struct {
 }
 
-func (m *CallRummyResponse) Reset()                    { *m = CallRummyResponse{} }
-func (m *CallRummyResponse) String() string            { return proto.CompactTextString(m) }
-func (*CallRummyResponse) ProtoMessage()               {}
-func (*CallRummyResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
+func (m *ListStrategiesRequest) Reset()                    { *m = ListStrategiesRequest{} }
+func (m *ListStrategiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListStrategiesRequest) ProtoMessage()               {}
+func (*ListStrategiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{24} }
 
-func init() {
-	proto.RegisterType((*CreateGameRequest)(nil), "rummy.CreateGameRequest")
-	proto.RegisterType((*CreateGameResponse)(nil), "rummy.CreateGameResponse")
-	proto.RegisterType((*JoinGameRequest)(nil), "rummy.JoinGameRequest")
-	proto.RegisterType((*JoinGameResponse)(nil), "rummy.JoinGameResponse")
-	proto.RegisterType((*StartGameRequest)(nil), "rummy.StartGameRequest")
-	proto.RegisterType((*StartGameResponse)(nil), "rummy.StartGameResponse")
-	proto.RegisterType((*GetGameStateRequest)(nil), "rummy.GetGameStateRequest")
-	proto.RegisterType((*GetHandCardsRequest)(nil), "rummy.GetHandCardsRequest")
-	proto.RegisterType((*GetHandCardsResponse)(nil), "rummy.GetHandCardsResponse")
-	proto.RegisterType((*SubscribeGameRequest)(nil), "rummy.SubscribeGameRequest")
-	proto.RegisterType((*PickUpStockRequest)(nil), "rummy.PickUpStockRequest")
-	proto.RegisterType((*PickUpStockResponse)(nil), "rummy.PickUpStockResponse")
-	proto.RegisterType((*PickUpDiscardRequest)(nil), "rummy.PickUpDiscardRequest")
-	proto.RegisterType((*PickUpDiscardResponse)(nil), "rummy.PickUpDiscardResponse")
-	proto.RegisterType((*PlayCardsRequest)(nil), "rummy.PlayCardsRequest")
-	proto.RegisterType((*PlayCardsResponse)(nil), "rummy.PlayCardsResponse")
-	proto.RegisterType((*DiscardCardRequest)(nil), "rummy.DiscardCardRequest")
-	proto.RegisterType((*DiscardCardResponse)(nil), "rummy.DiscardCardResponse")
-	proto.RegisterType((*CallRummyRequest)(nil), "rummy.CallRummyRequest")
-	proto.RegisterType((*CallRummyResponse)(nil), "rummy.CallRummyResponse")
+type ListStrategiesResponse struct {
+	// In order of name.
+	Strategies []*StrategyInfo `protobuf:"bytes,1,rep,name=strategies" json:"strategies,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ListStrategiesResponse) Reset()                    { *m = ListStrategiesResponse{} }
+func (m *ListStrategiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListStrategiesResponse) ProtoMessage()               {}
+func (*ListStrategiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{25} }
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ListStrategiesResponse) GetStrategies() []*StrategyInfo {
+	if m != nil {
+		return m.Strategies
+	}
+	return nil
+}
 
-// Client API for RummyService service
+// List the computer players in a game. Only the host of the game
+// may manage its computer players.
+type ListBotsRequest struct {
+	GameName   string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+}
 
-type RummyServiceClient interface {
-	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
-	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
-	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
-	SubscribeGame(ctx context.Context, in *SubscribeGameRequest, opts ...grpc.CallOption) (RummyService_SubscribeGameClient, error)
-	GetGameState(ctx context.Context, in *GetGameStateRequest, opts ...grpc.CallOption) (*GameState, error)
-	GetHandCards(ctx context.Context, in *GetHandCardsRequest, opts ...grpc.CallOption) (*GetHandCardsResponse, error)
-	PickUpStock(ctx context.Context, in *PickUpStockRequest, opts ...grpc.CallOption) (*PickUpStockResponse, error)
-	PickUpDiscard(ctx context.Context, in *PickUpDiscardRequest, opts ...grpc.CallOption) (*PickUpDiscardResponse, error)
-	PlayCards(ctx context.Context, in *PlayCardsRequest, opts ...grpc.CallOption) (*PlayCardsResponse, error)
-	DiscardCard(ctx context.Context, in *DiscardCardRequest, opts ...grpc.CallOption) (*DiscardCardResponse, error)
-	CallRummy(ctx context.Context, in *CallRummyRequest, opts ...grpc.CallOption) (*CallRummyResponse, error)
+func (m *ListBotsRequest) Reset()                    { *m = ListBotsRequest{} }
+func (m *ListBotsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListBotsRequest) ProtoMessage()               {}
+func (*ListBotsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{26} }
+
+func (m *ListBotsRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
 }
 
-type rummyServiceClient struct {
-	cc *grpc.ClientConn
+func (m *ListBotsRequest) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
 }
 
-func NewRummyServiceClient(cc *grpc.ClientConn) RummyServiceClient {
-	return &rummyServiceClient{cc}
+type ListBotsResponse struct {
+	// In order of player id.
+	Bots []*ListBotsResponse_Bot `protobuf:"bytes,1,rep,name=bots" json:"bots,omitempty"`
 }
 
-func (c *rummyServiceClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error) {
-	out := new(CreateGameResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/CreateGame", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListBotsResponse) Reset()                    { *m = ListBotsResponse{} }
+func (m *ListBotsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListBotsResponse) ProtoMessage()               {}
+func (*ListBotsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{27} }
+
+func (m *ListBotsResponse) GetBots() []*ListBotsResponse_Bot {
+	if m != nil {
+		return m.Bots
 	}
-	return out, nil
+	return nil
 }
 
-func (c *rummyServiceClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error) {
-	out := new(JoinGameResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/JoinGame", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ListBotsResponse_Bot struct {
+	PlayerId   int32  `protobuf:"varint,1,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerName string `protobuf:"bytes,2,opt,name=player_name,json=playerName" json:"player_name,omitempty"`
+	Strategy   string `protobuf:"bytes,3,opt,name=strategy" json:"strategy,omitempty"`
+	// Paused computer players take no actions. Their turns are
+	// only played if they run out of time.
+	Paused bool `protobuf:"varint,4,opt,name=paused" json:"paused,omitempty"`
+	// True if the computer player has taken over the seat of
+	// a player who is away.
+	TakenOver      bool              `protobuf:"varint,5,opt,name=taken_over,json=takenOver" json:"taken_over,omitempty"`
+	StrategyParams map[string]string `protobuf:"bytes,6,rep,name=strategy_params,json=strategyParams" json:"strategy_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ListBotsResponse_Bot) Reset()                    { *m = ListBotsResponse_Bot{} }
+func (m *ListBotsResponse_Bot) String() string            { return proto.CompactTextString(m) }
+func (*ListBotsResponse_Bot) ProtoMessage()               {}
+func (*ListBotsResponse_Bot) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{27, 0} }
+
+func (m *ListBotsResponse_Bot) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *rummyServiceClient) StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error) {
-	out := new(StartGameResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/StartGame", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListBotsResponse_Bot) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
 	}
-	return out, nil
+	return ""
 }
 
-func (c *rummyServiceClient) SubscribeGame(ctx context.Context, in *SubscribeGameRequest, opts ...grpc.CallOption) (RummyService_SubscribeGameClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_RummyService_serviceDesc.Streams[0], c.cc, "/rummy.RummyService/SubscribeGame", opts...)
-	if err != nil {
-		return nil, err
+func (m *ListBotsResponse_Bot) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
 	}
-	x := &rummyServiceSubscribeGameClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return ""
+}
+
+func (m *ListBotsResponse_Bot) GetPaused() bool {
+	if m != nil {
+		return m.Paused
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return false
+}
+
+func (m *ListBotsResponse_Bot) GetTakenOver() bool {
+	if m != nil {
+		return m.TakenOver
 	}
-	return x, nil
+	return false
 }
 
-type RummyService_SubscribeGameClient interface {
-	Recv() (*GameEvent, error)
-	grpc.ClientStream
+func (m *ListBotsResponse_Bot) GetStrategyParams() map[string]string {
+	if m != nil {
+		return m.StrategyParams
+	}
+	return nil
 }
 
-type rummyServiceSubscribeGameClient struct {
-	grpc.ClientStream
+// Pause or resume a computer player.
+type PauseBotRequest struct {
+	GameName   string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+	PlayerId   int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	Paused     bool   `protobuf:"varint,4,opt,name=paused" json:"paused,omitempty"`
+}
+
+func (m *PauseBotRequest) Reset()                    { *m = PauseBotRequest{} }
+func (m *PauseBotRequest) String() string            { return proto.CompactTextString(m) }
+func (*PauseBotRequest) ProtoMessage()               {}
+func (*PauseBotRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{28} }
+
+func (m *PauseBotRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PauseBotRequest) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
+}
+
+func (m *PauseBotRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PauseBotRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type PauseBotResponse struct {
+}
+
+func (m *PauseBotResponse) Reset()                    { *m = PauseBotResponse{} }
+func (m *PauseBotResponse) String() string            { return proto.CompactTextString(m) }
+func (*PauseBotResponse) ProtoMessage()               {}
+func (*PauseBotResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{29} }
+
+// Stop a computer player. A seat that was taken over is returned to
+// the player who is away; other computer players leave the game,
+// forfeiting it.
+type RemoveBotRequest struct {
+	GameName   string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	HostSecret string `protobuf:"bytes,2,opt,name=host_secret,json=hostSecret" json:"host_secret,omitempty"`
+	PlayerId   int32  `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+}
+
+func (m *RemoveBotRequest) Reset()                    { *m = RemoveBotRequest{} }
+func (m *RemoveBotRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveBotRequest) ProtoMessage()               {}
+func (*RemoveBotRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{30} }
+
+func (m *RemoveBotRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *RemoveBotRequest) GetHostSecret() string {
+	if m != nil {
+		return m.HostSecret
+	}
+	return ""
+}
+
+func (m *RemoveBotRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+type RemoveBotResponse struct {
+}
+
+func (m *RemoveBotResponse) Reset()                    { *m = RemoveBotResponse{} }
+func (m *RemoveBotResponse) String() string            { return proto.CompactTextString(m) }
+func (*RemoveBotResponse) ProtoMessage()               {}
+func (*RemoveBotResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{31} }
+
+// Send a chat message to everyone observing a game.
+// Messages are published as CHAT events and retained with the game.
+type SendChatRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	Message      string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *SendChatRequest) Reset()                    { *m = SendChatRequest{} }
+func (m *SendChatRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendChatRequest) ProtoMessage()               {}
+func (*SendChatRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{32} }
+
+func (m *SendChatRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *SendChatRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *SendChatRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *SendChatRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type SendChatResponse struct {
+	// Sequence number of the CHAT event.
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+}
+
+func (m *SendChatResponse) Reset()                    { *m = SendChatResponse{} }
+func (m *SendChatResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendChatResponse) ProtoMessage()               {}
+func (*SendChatResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{33} }
+
+func (m *SendChatResponse) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+// Leave a game, freeing the seat if it has not started,
+// or forfeiting it if it is in progress. See Game.Forfeit.
+type LeaveGameRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+}
+
+func (m *LeaveGameRequest) Reset()                    { *m = LeaveGameRequest{} }
+func (m *LeaveGameRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaveGameRequest) ProtoMessage()               {}
+func (*LeaveGameRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{34} }
+
+func (m *LeaveGameRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *LeaveGameRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *LeaveGameRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+type LeaveGameResponse struct {
+}
+
+func (m *LeaveGameResponse) Reset()                    { *m = LeaveGameResponse{} }
+func (m *LeaveGameResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaveGameResponse) ProtoMessage()               {}
+func (*LeaveGameResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{35} }
+
+// Subscribe to game events. This allows players to observe the
+// gameplay of other players.
+type SubscribeGameRequest struct {
+	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	// If non-zero, the events in the game's history starting from
+	// this sequence number are sent before any new events. Clients that
+	// are reconnecting should pass the sequence number following the
+	// last event they received; new clients may pass 1 to receive
+	// all events, or GameState.last_sequence to start from the
+	// most recent event.
+	FromSequence int64 `protobuf:"varint,2,opt,name=from_sequence,json=fromSequence" json:"from_sequence,omitempty"`
+	// Players should subscribe as themselves, so that the server can
+	// tell when they lose their connection to the game. If a player
+	// has no open subscriptions for longer than the server's grace
+	// period, they are marked as away.
+	IsPlayer     bool   `protobuf:"varint,3,opt,name=is_player,json=isPlayer" json:"is_player,omitempty"`
+	PlayerId     int32  `protobuf:"varint,4,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,5,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	// Spectators must provide the token they were given when they
+	// joined. Credentials may be omitted only for public games that
+	// allow spectators.
+	SpectatorToken string `protobuf:"bytes,6,opt,name=spectator_token,json=spectatorToken" json:"spectator_token,omitempty"`
+}
+
+func (m *SubscribeGameRequest) Reset()                    { *m = SubscribeGameRequest{} }
+func (m *SubscribeGameRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeGameRequest) ProtoMessage()               {}
+func (*SubscribeGameRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{36} }
+
+func (m *SubscribeGameRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *SubscribeGameRequest) GetFromSequence() int64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+func (m *SubscribeGameRequest) GetIsPlayer() bool {
+	if m != nil {
+		return m.IsPlayer
+	}
+	return false
+}
+
+func (m *SubscribeGameRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *SubscribeGameRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *SubscribeGameRequest) GetSpectatorToken() string {
+	if m != nil {
+		return m.SpectatorToken
+	}
+	return ""
+}
+
+// Pick up a card from the stock. A player should initiate this request
+// when beginning their turn. Alternatively, a player may issue a
+// PickUpDiscardRequest.
+type PickUpStockRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	// Optional. Retrying a request with the same request_id returns
+	// the result of the first successful attempt rather than acting
+	// again. The server remembers each player's recent request ids,
+	// but not across restarts.
+	RequestId string `protobuf:"bytes,10,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// Optional. If non-zero, the action fails with ABORTED unless the
+	// game's last_sequence is expected_sequence, i.e. no events have
+	// happened since the state the client acted on.
+	ExpectedSequence int64 `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	// Optional. If check_turn is set, the action fails with ABORTED
+	// unless the game's turn is expected_turn.
+	CheckTurn    bool  `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn int32 `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *PickUpStockRequest) Reset()                    { *m = PickUpStockRequest{} }
+func (m *PickUpStockRequest) String() string            { return proto.CompactTextString(m) }
+func (*PickUpStockRequest) ProtoMessage()               {}
+func (*PickUpStockRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{37} }
+
+func (m *PickUpStockRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PickUpStockRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PickUpStockRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *PickUpStockRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PickUpStockRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *PickUpStockRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *PickUpStockRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+// Returns the card that was picked up from the stock.
+type PickUpStockResponse struct {
+	Card *deck.Card `protobuf:"bytes,1,opt,name=card" json:"card,omitempty"`
+}
+
+func (m *PickUpStockResponse) Reset()                    { *m = PickUpStockResponse{} }
+func (m *PickUpStockResponse) String() string            { return proto.CompactTextString(m) }
+func (*PickUpStockResponse) ProtoMessage()               {}
+func (*PickUpStockResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{38} }
+
+func (m *PickUpStockResponse) GetCard() *deck.Card {
+	if m != nil {
+		return m.Card
+	}
+	return nil
+}
+
+// Pick up N cards from the discard pile. A player may initiate this
+// request when beginning their turn. Alternatively, a player may pick
+// up a card from the stock. The N cards picked up are from the top of
+// the discard stack. The bottom-most card must be played this turn.
+type PickUpDiscardRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	NCards       int32  `protobuf:"varint,4,opt,name=n_cards,json=nCards" json:"n_cards,omitempty"`
+	// See PickUpStockRequest.
+	RequestId        string `protobuf:"bytes,10,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ExpectedSequence int64  `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	CheckTurn        bool   `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn     int32  `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *PickUpDiscardRequest) Reset()                    { *m = PickUpDiscardRequest{} }
+func (m *PickUpDiscardRequest) String() string            { return proto.CompactTextString(m) }
+func (*PickUpDiscardRequest) ProtoMessage()               {}
+func (*PickUpDiscardRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{39} }
+
+func (m *PickUpDiscardRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PickUpDiscardRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PickUpDiscardRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *PickUpDiscardRequest) GetNCards() int32 {
+	if m != nil {
+		return m.NCards
+	}
+	return 0
+}
+
+func (m *PickUpDiscardRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PickUpDiscardRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *PickUpDiscardRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *PickUpDiscardRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+// Returns the cards picked up from the discard pile.
+type PickUpDiscardResponse struct {
+	Cards []*deck.Card `protobuf:"bytes,1,rep,name=cards" json:"cards,omitempty"`
+}
+
+func (m *PickUpDiscardResponse) Reset()                    { *m = PickUpDiscardResponse{} }
+func (m *PickUpDiscardResponse) String() string            { return proto.CompactTextString(m) }
+func (*PickUpDiscardResponse) ProtoMessage()               {}
+func (*PickUpDiscardResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{40} }
+
+func (m *PickUpDiscardResponse) GetCards() []*deck.Card {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+// Play cards for points. The cards must either form a new Meld,
+// or rummy off of a meld that has previously been played (by any player).
+// Players may issue this request only when it is their turn and they have
+// picked up cards (either from the stock or the discard pile).
+// This request may be issued multiple times in a single turn.
+type PlayCardsRequest struct {
+	GameName     string       `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32        `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string       `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	Cards        []*deck.Card `protobuf:"bytes,4,rep,name=cards" json:"cards,omitempty"`
+	// TODO(palpant): If rummying, we need to specify the meld the player
+	// is choosing to rummy off of. In some cases it may be possible to
+	// rummyoff of either a set or a run.
+	// See PickUpStockRequest.
+	RequestId        string `protobuf:"bytes,10,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ExpectedSequence int64  `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	CheckTurn        bool   `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn     int32  `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *PlayCardsRequest) Reset()                    { *m = PlayCardsRequest{} }
+func (m *PlayCardsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlayCardsRequest) ProtoMessage()               {}
+func (*PlayCardsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{41} }
+
+func (m *PlayCardsRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PlayCardsRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PlayCardsRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *PlayCardsRequest) GetCards() []*deck.Card {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+func (m *PlayCardsRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PlayCardsRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *PlayCardsRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *PlayCardsRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+type PlayCardsResponse struct {
+	Score int32 `protobuf:"varint,1,opt,name=score" json:"score,omitempty"`
+}
+
+func (m *PlayCardsResponse) Reset()                    { *m = PlayCardsResponse{} }
+func (m *PlayCardsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PlayCardsResponse) ProtoMessage()               {}
+func (*PlayCardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{42} }
+
+func (m *PlayCardsResponse) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+// Discard a card from hand into the discard pile.
+// Players may play this card after picking up cards and (optionally)
+// playing cards for points. Discarding a card ends the player's turn.
+type DiscardCardRequest struct {
+	GameName     string     `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32      `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string     `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	Card         *deck.Card `protobuf:"bytes,4,opt,name=card" json:"card,omitempty"`
+	// See PickUpStockRequest.
+	RequestId        string `protobuf:"bytes,10,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ExpectedSequence int64  `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	CheckTurn        bool   `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn     int32  `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *DiscardCardRequest) Reset()                    { *m = DiscardCardRequest{} }
+func (m *DiscardCardRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiscardCardRequest) ProtoMessage()               {}
+func (*DiscardCardRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{43} }
+
+func (m *DiscardCardRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *DiscardCardRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *DiscardCardRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *DiscardCardRequest) GetCard() *deck.Card {
+	if m != nil {
+		return m.Card
+	}
+	return nil
+}
+
+func (m *DiscardCardRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *DiscardCardRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *DiscardCardRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *DiscardCardRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+type DiscardCardResponse struct {
+}
+
+func (m *DiscardCardResponse) Reset()                    { *m = DiscardCardResponse{} }
+func (m *DiscardCardResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiscardCardResponse) ProtoMessage()               {}
+func (*DiscardCardResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{44} }
+
+// Call a rummy observed in the discard pile. This request may be performed
+// at any time when a player observes that a possible rummy has been created
+// either as the result of a discard or newly played cards.
+type CallRummyRequest struct {
+	GameName     string       `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32        `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string       `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	Cards        []*deck.Card `protobuf:"bytes,4,rep,name=cards" json:"cards,omitempty"`
+	// See PickUpStockRequest.
+	RequestId        string `protobuf:"bytes,10,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	ExpectedSequence int64  `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	CheckTurn        bool   `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn     int32  `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *CallRummyRequest) Reset()                    { *m = CallRummyRequest{} }
+func (m *CallRummyRequest) String() string            { return proto.CompactTextString(m) }
+func (*CallRummyRequest) ProtoMessage()               {}
+func (*CallRummyRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{45} }
+
+func (m *CallRummyRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *CallRummyRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *CallRummyRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *CallRummyRequest) GetCards() []*deck.Card {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+func (m *CallRummyRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CallRummyRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *CallRummyRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *CallRummyRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+type CallRummyResponse struct {
+}
+
+func (m *CallRummyResponse) Reset()                    { *m = CallRummyResponse{} }
+func (m *CallRummyResponse) String() string            { return proto.CompactTextString(m) }
+func (*CallRummyResponse) ProtoMessage()               {}
+func (*CallRummyResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{46} }
+
+// A message sent by the client on a Play stream. The first message on
+// the stream identifies the game and player; these fields are ignored
+// in later messages. Every message, including the first, may request
+// an action, and the server replies to each with an ActionResult.
+type PlayRequest struct {
+	GameName     string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	PlayerId     int32  `protobuf:"varint,2,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+	// As in SubscribeGameRequest.
+	FromSequence int64 `protobuf:"varint,4,opt,name=from_sequence,json=fromSequence" json:"from_sequence,omitempty"`
+	// Chosen by the client, and returned in the result of the action.
+	RequestId string `protobuf:"bytes,5,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// If UNKNOWN_ACTION, no action is taken, and the result only
+	// contains the player's current view of the game.
+	Action PlayerView_Action `protobuf:"varint,6,opt,name=action,enum=rummy.PlayerView_Action" json:"action,omitempty"`
+	// For PICK_UP_DISCARD.
+	NCards int32 `protobuf:"varint,7,opt,name=n_cards,json=nCards" json:"n_cards,omitempty"`
+	// For PLAY_CARDS and CALL_RUMMY.
+	Cards []*deck.Card `protobuf:"bytes,8,rep,name=cards" json:"cards,omitempty"`
+	// For DISCARD.
+	Card *deck.Card `protobuf:"bytes,9,opt,name=card" json:"card,omitempty"`
+	// As in PickUpStockRequest. Since request_id is also used to
+	// match results to requests, it should always be unique.
+	ExpectedSequence int64 `protobuf:"varint,11,opt,name=expected_sequence,json=expectedSequence" json:"expected_sequence,omitempty"`
+	CheckTurn        bool  `protobuf:"varint,12,opt,name=check_turn,json=checkTurn" json:"check_turn,omitempty"`
+	ExpectedTurn     int32 `protobuf:"varint,13,opt,name=expected_turn,json=expectedTurn" json:"expected_turn,omitempty"`
+}
+
+func (m *PlayRequest) Reset()                    { *m = PlayRequest{} }
+func (m *PlayRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlayRequest) ProtoMessage()               {}
+func (*PlayRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{47} }
+
+func (m *PlayRequest) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PlayRequest) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+func (m *PlayRequest) GetFromSequence() int64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PlayRequest) GetAction() PlayerView_Action {
+	if m != nil {
+		return m.Action
+	}
+	return PlayerView_UNKNOWN_ACTION
+}
+
+func (m *PlayRequest) GetNCards() int32 {
+	if m != nil {
+		return m.NCards
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetCards() []*deck.Card {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+func (m *PlayRequest) GetCard() *deck.Card {
+	if m != nil {
+		return m.Card
+	}
+	return nil
+}
+
+func (m *PlayRequest) GetExpectedSequence() int64 {
+	if m != nil {
+		return m.ExpectedSequence
+	}
+	return 0
+}
+
+func (m *PlayRequest) GetCheckTurn() bool {
+	if m != nil {
+		return m.CheckTurn
+	}
+	return false
+}
+
+func (m *PlayRequest) GetExpectedTurn() int32 {
+	if m != nil {
+		return m.ExpectedTurn
+	}
+	return 0
+}
+
+// The result of an action requested on a Play stream.
+type ActionResult struct {
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// Empty if the action succeeded.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// The gRPC status code of the error, e.g. ABORTED if the action's
+	// expectations were not met.
+	Code int32 `protobuf:"varint,6,opt,name=code" json:"code,omitempty"`
+	// The cards picked up, for PICK_UP_STOCK and PICK_UP_DISCARD.
+	Cards []*deck.Card `protobuf:"bytes,3,rep,name=cards" json:"cards,omitempty"`
+	// The points scored, for PLAY_CARDS.
+	Score int32 `protobuf:"varint,4,opt,name=score" json:"score,omitempty"`
+	// The player's view of the game after the action. All events up to
+	// view.game_state.last_sequence are sent on the stream before the
+	// result.
+	View *PlayerView `protobuf:"bytes,5,opt,name=view" json:"view,omitempty"`
+}
+
+func (m *ActionResult) Reset()                    { *m = ActionResult{} }
+func (m *ActionResult) String() string            { return proto.CompactTextString(m) }
+func (*ActionResult) ProtoMessage()               {}
+func (*ActionResult) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{48} }
+
+func (m *ActionResult) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ActionResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ActionResult) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ActionResult) GetCards() []*deck.Card {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+func (m *ActionResult) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *ActionResult) GetView() *PlayerView {
+	if m != nil {
+		return m.View
+	}
+	return nil
+}
+
+// A message sent by the server on a Play stream: exactly one of event
+// or result is set.
+type PlayResponse struct {
+	Event  *GameEvent    `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+	Result *ActionResult `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *PlayResponse) Reset()                    { *m = PlayResponse{} }
+func (m *PlayResponse) String() string            { return proto.CompactTextString(m) }
+func (*PlayResponse) ProtoMessage()               {}
+func (*PlayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{49} }
+
+func (m *PlayResponse) GetEvent() *GameEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *PlayResponse) GetResult() *ActionResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// The state of a tournament run by the server. Tournament games are
+// played head-to-head, and are created and started automatically.
+type Tournament struct {
+	Name   string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Format Tournament_Format `protobuf:"varint,2,opt,name=format,enum=rummy.Tournament_Format" json:"format,omitempty"`
+	Status Tournament_Status `protobuf:"varint,3,opt,name=status,enum=rummy.Tournament_Status" json:"status,omitempty"`
+	// Options for each game in the tournament.
+	GameOptions *GameOptions `protobuf:"bytes,4,opt,name=game_options,json=gameOptions" json:"game_options,omitempty"`
+	// The round currently being played, starting from 1.
+	Round     int32 `protobuf:"varint,5,opt,name=round" json:"round,omitempty"`
+	NumRounds int32 `protobuf:"varint,6,opt,name=num_rounds,json=numRounds" json:"num_rounds,omitempty"`
+	// Entrants in seed order.
+	Entrants []*Tournament_Entrant `protobuf:"bytes,7,rep,name=entrants" json:"entrants,omitempty"`
+	// Indices of the entrants, ordered by their current standing.
+	Standings []int32             `protobuf:"varint,8,rep,name=standings,packed" json:"standings,omitempty"`
+	Matches   []*Tournament_Match `protobuf:"bytes,9,rep,name=matches" json:"matches,omitempty"`
+}
+
+func (m *Tournament) Reset()                    { *m = Tournament{} }
+func (m *Tournament) String() string            { return proto.CompactTextString(m) }
+func (*Tournament) ProtoMessage()               {}
+func (*Tournament) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{50} }
+
+func (m *Tournament) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tournament) GetFormat() Tournament_Format {
+	if m != nil {
+		return m.Format
+	}
+	return Tournament_ROUND_ROBIN
+}
+
+func (m *Tournament) GetStatus() Tournament_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Tournament_IN_PROGRESS
+}
+
+func (m *Tournament) GetGameOptions() *GameOptions {
+	if m != nil {
+		return m.GameOptions
+	}
+	return nil
+}
+
+func (m *Tournament) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Tournament) GetNumRounds() int32 {
+	if m != nil {
+		return m.NumRounds
+	}
+	return 0
+}
+
+func (m *Tournament) GetEntrants() []*Tournament_Entrant {
+	if m != nil {
+		return m.Entrants
+	}
+	return nil
+}
+
+func (m *Tournament) GetStandings() []int32 {
+	if m != nil {
+		return m.Standings
+	}
+	return nil
+}
+
+func (m *Tournament) GetMatches() []*Tournament_Match {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type Tournament_Entrant struct {
+	Name       string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	IsComputer bool   `protobuf:"varint,2,opt,name=is_computer,json=isComputer" json:"is_computer,omitempty"`
+	Wins       int32  `protobuf:"varint,3,opt,name=wins" json:"wins,omitempty"`
+	Losses     int32  `protobuf:"varint,4,opt,name=losses" json:"losses,omitempty"`
+	// Byes are also counted as wins.
+	Byes int32 `protobuf:"varint,5,opt,name=byes" json:"byes,omitempty"`
+	// Total score across all of the entrant's games.
+	Points     int32 `protobuf:"varint,6,opt,name=points" json:"points,omitempty"`
+	Eliminated bool  `protobuf:"varint,7,opt,name=eliminated" json:"eliminated,omitempty"`
+}
+
+func (m *Tournament_Entrant) Reset()                    { *m = Tournament_Entrant{} }
+func (m *Tournament_Entrant) String() string            { return proto.CompactTextString(m) }
+func (*Tournament_Entrant) ProtoMessage()               {}
+func (*Tournament_Entrant) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{50, 0} }
+
+func (m *Tournament_Entrant) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tournament_Entrant) GetIsComputer() bool {
+	if m != nil {
+		return m.IsComputer
+	}
+	return false
+}
+
+func (m *Tournament_Entrant) GetWins() int32 {
+	if m != nil {
+		return m.Wins
+	}
+	return 0
+}
+
+func (m *Tournament_Entrant) GetLosses() int32 {
+	if m != nil {
+		return m.Losses
+	}
+	return 0
+}
+
+func (m *Tournament_Entrant) GetByes() int32 {
+	if m != nil {
+		return m.Byes
+	}
+	return 0
+}
+
+func (m *Tournament_Entrant) GetPoints() int32 {
+	if m != nil {
+		return m.Points
+	}
+	return 0
+}
+
+func (m *Tournament_Entrant) GetEliminated() bool {
+	if m != nil {
+		return m.Eliminated
+	}
+	return false
+}
+
+type Tournament_Match struct {
+	Round int32 `protobuf:"varint,1,opt,name=round" json:"round,omitempty"`
+	// Empty for byes.
+	GameName string `protobuf:"bytes,2,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	// Indices of the entrants in the match, in order of their
+	// player ids in the game.
+	Entrants  []int32 `protobuf:"varint,3,rep,name=entrants,packed" json:"entrants,omitempty"`
+	Completed bool    `protobuf:"varint,4,opt,name=completed" json:"completed,omitempty"`
+	// Index of the entrant who won the match, once it is completed.
+	Winner int32 `protobuf:"varint,5,opt,name=winner" json:"winner,omitempty"`
+	// Final score of each entrant, in the same order as entrants.
+	Scores []int32 `protobuf:"varint,6,rep,name=scores,packed" json:"scores,omitempty"`
+}
+
+func (m *Tournament_Match) Reset()                    { *m = Tournament_Match{} }
+func (m *Tournament_Match) String() string            { return proto.CompactTextString(m) }
+func (*Tournament_Match) ProtoMessage()               {}
+func (*Tournament_Match) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{50, 1} }
+
+func (m *Tournament_Match) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Tournament_Match) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *Tournament_Match) GetEntrants() []int32 {
+	if m != nil {
+		return m.Entrants
+	}
+	return nil
+}
+
+func (m *Tournament_Match) GetCompleted() bool {
+	if m != nil {
+		return m.Completed
+	}
+	return false
+}
+
+func (m *Tournament_Match) GetWinner() int32 {
+	if m != nil {
+		return m.Winner
+	}
+	return 0
+}
+
+func (m *Tournament_Match) GetScores() []int32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type TournamentEntrant struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// If set, the entrant is a computer player with this strategy.
+	Strategy string `protobuf:"bytes,2,opt,name=strategy" json:"strategy,omitempty"`
+	// Secret that a human entrant must use to play their games.
+	// If empty, a random one is generated.
+	PlayerSecret string `protobuf:"bytes,3,opt,name=player_secret,json=playerSecret" json:"player_secret,omitempty"`
+}
+
+func (m *TournamentEntrant) Reset()                    { *m = TournamentEntrant{} }
+func (m *TournamentEntrant) String() string            { return proto.CompactTextString(m) }
+func (*TournamentEntrant) ProtoMessage()               {}
+func (*TournamentEntrant) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{51} }
+
+func (m *TournamentEntrant) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TournamentEntrant) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *TournamentEntrant) GetPlayerSecret() string {
+	if m != nil {
+		return m.PlayerSecret
+	}
+	return ""
+}
+
+// Create a tournament, which starts immediately.
+type CreateTournamentRequest struct {
+	TournamentName string `protobuf:"bytes,1,opt,name=tournament_name,json=tournamentName" json:"tournament_name,omitempty"`
+	// Entrants in seed order.
+	Entrants []*TournamentEntrant `protobuf:"bytes,2,rep,name=entrants" json:"entrants,omitempty"`
+	Format   Tournament_Format    `protobuf:"varint,3,opt,name=format,enum=rummy.Tournament_Format" json:"format,omitempty"`
+	// Options for each game. Games are always played by two players,
+	// so min_players and max_players must be zero or 2.
+	GameOptions *GameOptions `protobuf:"bytes,4,opt,name=game_options,json=gameOptions" json:"game_options,omitempty"`
+	// Number of rounds for Swiss tournaments. If zero, enough rounds
+	// are played to determine a single winner.
+	NumRounds int32 `protobuf:"varint,5,opt,name=num_rounds,json=numRounds" json:"num_rounds,omitempty"`
+}
+
+func (m *CreateTournamentRequest) Reset()                    { *m = CreateTournamentRequest{} }
+func (m *CreateTournamentRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateTournamentRequest) ProtoMessage()               {}
+func (*CreateTournamentRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{52} }
+
+func (m *CreateTournamentRequest) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *CreateTournamentRequest) GetEntrants() []*TournamentEntrant {
+	if m != nil {
+		return m.Entrants
+	}
+	return nil
+}
+
+func (m *CreateTournamentRequest) GetFormat() Tournament_Format {
+	if m != nil {
+		return m.Format
+	}
+	return Tournament_ROUND_ROBIN
+}
+
+func (m *CreateTournamentRequest) GetGameOptions() *GameOptions {
+	if m != nil {
+		return m.GameOptions
+	}
+	return nil
+}
+
+func (m *CreateTournamentRequest) GetNumRounds() int32 {
+	if m != nil {
+		return m.NumRounds
+	}
+	return 0
+}
+
+type CreateTournamentResponse struct {
+	// The secret of each entrant, in the same order as the request.
+	// Empty for computer players.
+	PlayerSecrets []string `protobuf:"bytes,1,rep,name=player_secrets,json=playerSecrets" json:"player_secrets,omitempty"`
+}
+
+func (m *CreateTournamentResponse) Reset()                    { *m = CreateTournamentResponse{} }
+func (m *CreateTournamentResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateTournamentResponse) ProtoMessage()               {}
+func (*CreateTournamentResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{53} }
+
+func (m *CreateTournamentResponse) GetPlayerSecrets() []string {
+	if m != nil {
+		return m.PlayerSecrets
+	}
+	return nil
+}
+
+type GetTournamentRequest struct {
+	TournamentName string `protobuf:"bytes,1,opt,name=tournament_name,json=tournamentName" json:"tournament_name,omitempty"`
+}
+
+func (m *GetTournamentRequest) Reset()                    { *m = GetTournamentRequest{} }
+func (m *GetTournamentRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetTournamentRequest) ProtoMessage()               {}
+func (*GetTournamentRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{54} }
+
+func (m *GetTournamentRequest) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+// Subscribe to updates of a tournament. The current state is sent
+// immediately, and again whenever a match starts or completes.
+// The stream is closed once the tournament is completed.
+type SubscribeTournamentRequest struct {
+	TournamentName string `protobuf:"bytes,1,opt,name=tournament_name,json=tournamentName" json:"tournament_name,omitempty"`
+}
+
+func (m *SubscribeTournamentRequest) Reset()                    { *m = SubscribeTournamentRequest{} }
+func (m *SubscribeTournamentRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeTournamentRequest) ProtoMessage()               {}
+func (*SubscribeTournamentRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{55} }
+
+func (m *SubscribeTournamentRequest) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+// Create an account, which identifies a player across games.
+type CreateAccountRequest struct {
+	// Must be unique within the server.
+	AccountName string `protobuf:"bytes,1,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+}
+
+func (m *CreateAccountRequest) Reset()                    { *m = CreateAccountRequest{} }
+func (m *CreateAccountRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateAccountRequest) ProtoMessage()               {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{56} }
+
+func (m *CreateAccountRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+type CreateAccountResponse struct {
+	// Must be provided to join games as this account.
+	AccountToken string `protobuf:"bytes,1,opt,name=account_token,json=accountToken" json:"account_token,omitempty"`
+}
+
+func (m *CreateAccountResponse) Reset()                    { *m = CreateAccountResponse{} }
+func (m *CreateAccountResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateAccountResponse) ProtoMessage()               {}
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{57} }
+
+func (m *CreateAccountResponse) GetAccountToken() string {
+	if m != nil {
+		return m.AccountToken
+	}
+	return ""
+}
+
+// The skill rating and statistics of an account, from the games
+// that it has finished.
+type PlayerStats struct {
+	AccountName string  `protobuf:"bytes,1,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	Rating      float64 `protobuf:"fixed64,2,opt,name=rating" json:"rating,omitempty"`
+	GamesPlayed int32   `protobuf:"varint,3,opt,name=games_played,json=gamesPlayed" json:"games_played,omitempty"`
+	Wins        int32   `protobuf:"varint,4,opt,name=wins" json:"wins,omitempty"`
+	WinRate     float64 `protobuf:"fixed64,5,opt,name=win_rate,json=winRate" json:"win_rate,omitempty"`
+	// Each game is a single hand.
+	AveragePoints   float64 `protobuf:"fixed64,6,opt,name=average_points,json=averagePoints" json:"average_points,omitempty"`
+	AverageDeadwood float64 `protobuf:"fixed64,7,opt,name=average_deadwood,json=averageDeadwood" json:"average_deadwood,omitempty"`
+	// Finished games, most recent first.
+	// Only included by GetPlayerStats.
+	History []*PlayerStats_Game `protobuf:"bytes,8,rep,name=history" json:"history,omitempty"`
+}
+
+func (m *PlayerStats) Reset()                    { *m = PlayerStats{} }
+func (m *PlayerStats) String() string            { return proto.CompactTextString(m) }
+func (*PlayerStats) ProtoMessage()               {}
+func (*PlayerStats) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{58} }
+
+func (m *PlayerStats) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *PlayerStats) GetRating() float64 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetGamesPlayed() int32 {
+	if m != nil {
+		return m.GamesPlayed
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetWins() int32 {
+	if m != nil {
+		return m.Wins
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetWinRate() float64 {
+	if m != nil {
+		return m.WinRate
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetAveragePoints() float64 {
+	if m != nil {
+		return m.AveragePoints
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetAverageDeadwood() float64 {
+	if m != nil {
+		return m.AverageDeadwood
+	}
+	return 0
+}
+
+func (m *PlayerStats) GetHistory() []*PlayerStats_Game {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type PlayerStats_Game struct {
+	GameName string `protobuf:"bytes,1,opt,name=game_name,json=gameName" json:"game_name,omitempty"`
+	// Time at which the game ended, in milliseconds since the epoch.
+	FinishedAtMs int64 `protobuf:"varint,2,opt,name=finished_at_ms,json=finishedAtMs" json:"finished_at_ms,omitempty"`
+	PlayerId     int32 `protobuf:"varint,3,opt,name=player_id,json=playerId" json:"player_id,omitempty"`
+	Score        int32 `protobuf:"varint,4,opt,name=score" json:"score,omitempty"`
+	// Total value of the cards left in the player's hand.
+	Deadwood     int32   `protobuf:"varint,5,opt,name=deadwood" json:"deadwood,omitempty"`
+	Won          bool    `protobuf:"varint,6,opt,name=won" json:"won,omitempty"`
+	Forfeited    bool    `protobuf:"varint,7,opt,name=forfeited" json:"forfeited,omitempty"`
+	RatingChange float64 `protobuf:"fixed64,8,opt,name=rating_change,json=ratingChange" json:"rating_change,omitempty"`
+	// Names of the other players in the game.
+	Opponents []string `protobuf:"bytes,9,rep,name=opponents" json:"opponents,omitempty"`
+}
+
+func (m *PlayerStats_Game) Reset()                    { *m = PlayerStats_Game{} }
+func (m *PlayerStats_Game) String() string            { return proto.CompactTextString(m) }
+func (*PlayerStats_Game) ProtoMessage()               {}
+func (*PlayerStats_Game) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{58, 0} }
+
+func (m *PlayerStats_Game) GetGameName() string {
+	if m != nil {
+		return m.GameName
+	}
+	return ""
+}
+
+func (m *PlayerStats_Game) GetFinishedAtMs() int64 {
+	if m != nil {
+		return m.FinishedAtMs
+	}
+	return 0
+}
+
+func (m *PlayerStats_Game) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PlayerStats_Game) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PlayerStats_Game) GetDeadwood() int32 {
+	if m != nil {
+		return m.Deadwood
+	}
+	return 0
+}
+
+func (m *PlayerStats_Game) GetWon() bool {
+	if m != nil {
+		return m.Won
+	}
+	return false
+}
+
+func (m *PlayerStats_Game) GetForfeited() bool {
+	if m != nil {
+		return m.Forfeited
+	}
+	return false
+}
+
+func (m *PlayerStats_Game) GetRatingChange() float64 {
+	if m != nil {
+		return m.RatingChange
+	}
+	return 0
+}
+
+func (m *PlayerStats_Game) GetOpponents() []string {
+	if m != nil {
+		return m.Opponents
+	}
+	return nil
+}
+
+type GetLeaderboardRequest struct {
+	// Maximum number of players to return. If zero, all players
+	// who have finished a game are returned.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetLeaderboardRequest) Reset()                    { *m = GetLeaderboardRequest{} }
+func (m *GetLeaderboardRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetLeaderboardRequest) ProtoMessage()               {}
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{59} }
+
+func (m *GetLeaderboardRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	// Players in order of their rating, highest first.
+	Players []*PlayerStats `protobuf:"bytes,1,rep,name=players" json:"players,omitempty"`
+}
+
+func (m *GetLeaderboardResponse) Reset()                    { *m = GetLeaderboardResponse{} }
+func (m *GetLeaderboardResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetLeaderboardResponse) ProtoMessage()               {}
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{60} }
+
+func (m *GetLeaderboardResponse) GetPlayers() []*PlayerStats {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+type GetPlayerStatsRequest struct {
+	AccountName string `protobuf:"bytes,1,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+}
+
+func (m *GetPlayerStatsRequest) Reset()                    { *m = GetPlayerStatsRequest{} }
+func (m *GetPlayerStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetPlayerStatsRequest) ProtoMessage()               {}
+func (*GetPlayerStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{61} }
+
+func (m *GetPlayerStatsRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*CreateGameRequest)(nil), "rummy.CreateGameRequest")
+	proto.RegisterType((*CreateGameResponse)(nil), "rummy.CreateGameResponse")
+	proto.RegisterType((*JoinGameRequest)(nil), "rummy.JoinGameRequest")
+	proto.RegisterType((*JoinGameResponse)(nil), "rummy.JoinGameResponse")
+	proto.RegisterType((*FindMatchRequest)(nil), "rummy.FindMatchRequest")
+	proto.RegisterType((*FindMatchResponse)(nil), "rummy.FindMatchResponse")
+	proto.RegisterType((*JoinSpectatorRequest)(nil), "rummy.JoinSpectatorRequest")
+	proto.RegisterType((*JoinSpectatorResponse)(nil), "rummy.JoinSpectatorResponse")
+	proto.RegisterType((*StartGameRequest)(nil), "rummy.StartGameRequest")
+	proto.RegisterType((*StartGameResponse)(nil), "rummy.StartGameResponse")
+	proto.RegisterType((*GetGameStateRequest)(nil), "rummy.GetGameStateRequest")
+	proto.RegisterType((*GetHandCardsRequest)(nil), "rummy.GetHandCardsRequest")
+	proto.RegisterType((*GetHandCardsResponse)(nil), "rummy.GetHandCardsResponse")
+	proto.RegisterType((*GetPlayerViewRequest)(nil), "rummy.GetPlayerViewRequest")
+	proto.RegisterType((*ListGamesRequest)(nil), "rummy.ListGamesRequest")
+	proto.RegisterType((*ListGamesResponse)(nil), "rummy.ListGamesResponse")
+	proto.RegisterType((*ListGamesResponse_Game)(nil), "rummy.ListGamesResponse.Game")
+	proto.RegisterType((*UpdateInviteCodeRequest)(nil), "rummy.UpdateInviteCodeRequest")
+	proto.RegisterType((*UpdateInviteCodeResponse)(nil), "rummy.UpdateInviteCodeResponse")
+	proto.RegisterType((*TakeOverSeatRequest)(nil), "rummy.TakeOverSeatRequest")
+	proto.RegisterType((*TakeOverSeatResponse)(nil), "rummy.TakeOverSeatResponse")
+	proto.RegisterType((*ReclaimSeatRequest)(nil), "rummy.ReclaimSeatRequest")
+	proto.RegisterType((*ReclaimSeatResponse)(nil), "rummy.ReclaimSeatResponse")
+	proto.RegisterType((*StrategyParam)(nil), "rummy.StrategyParam")
+	proto.RegisterType((*StrategyInfo)(nil), "rummy.StrategyInfo")
+	proto.RegisterType((*ListStrategiesRequest)(nil), "rummy.ListStrategiesRequest")
+	proto.RegisterType((*ListStrategiesResponse)(nil), "rummy.ListStrategiesResponse")
+	proto.RegisterType((*ListBotsRequest)(nil), "rummy.ListBotsRequest")
+	proto.RegisterType((*ListBotsResponse)(nil), "rummy.ListBotsResponse")
+	proto.RegisterType((*ListBotsResponse_Bot)(nil), "rummy.ListBotsResponse.Bot")
+	proto.RegisterType((*PauseBotRequest)(nil), "rummy.PauseBotRequest")
+	proto.RegisterType((*PauseBotResponse)(nil), "rummy.PauseBotResponse")
+	proto.RegisterType((*RemoveBotRequest)(nil), "rummy.RemoveBotRequest")
+	proto.RegisterType((*RemoveBotResponse)(nil), "rummy.RemoveBotResponse")
+	proto.RegisterType((*SendChatRequest)(nil), "rummy.SendChatRequest")
+	proto.RegisterType((*SendChatResponse)(nil), "rummy.SendChatResponse")
+	proto.RegisterType((*LeaveGameRequest)(nil), "rummy.LeaveGameRequest")
+	proto.RegisterType((*LeaveGameResponse)(nil), "rummy.LeaveGameResponse")
+	proto.RegisterType((*SubscribeGameRequest)(nil), "rummy.SubscribeGameRequest")
+	proto.RegisterType((*PickUpStockRequest)(nil), "rummy.PickUpStockRequest")
+	proto.RegisterType((*PickUpStockResponse)(nil), "rummy.PickUpStockResponse")
+	proto.RegisterType((*PickUpDiscardRequest)(nil), "rummy.PickUpDiscardRequest")
+	proto.RegisterType((*PickUpDiscardResponse)(nil), "rummy.PickUpDiscardResponse")
+	proto.RegisterType((*PlayCardsRequest)(nil), "rummy.PlayCardsRequest")
+	proto.RegisterType((*PlayCardsResponse)(nil), "rummy.PlayCardsResponse")
+	proto.RegisterType((*DiscardCardRequest)(nil), "rummy.DiscardCardRequest")
+	proto.RegisterType((*DiscardCardResponse)(nil), "rummy.DiscardCardResponse")
+	proto.RegisterType((*CallRummyRequest)(nil), "rummy.CallRummyRequest")
+	proto.RegisterType((*CallRummyResponse)(nil), "rummy.CallRummyResponse")
+	proto.RegisterType((*PlayRequest)(nil), "rummy.PlayRequest")
+	proto.RegisterType((*ActionResult)(nil), "rummy.ActionResult")
+	proto.RegisterType((*PlayResponse)(nil), "rummy.PlayResponse")
+	proto.RegisterType((*Tournament)(nil), "rummy.Tournament")
+	proto.RegisterType((*Tournament_Entrant)(nil), "rummy.Tournament.Entrant")
+	proto.RegisterType((*Tournament_Match)(nil), "rummy.Tournament.Match")
+	proto.RegisterType((*TournamentEntrant)(nil), "rummy.TournamentEntrant")
+	proto.RegisterType((*CreateTournamentRequest)(nil), "rummy.CreateTournamentRequest")
+	proto.RegisterType((*CreateTournamentResponse)(nil), "rummy.CreateTournamentResponse")
+	proto.RegisterType((*GetTournamentRequest)(nil), "rummy.GetTournamentRequest")
+	proto.RegisterType((*SubscribeTournamentRequest)(nil), "rummy.SubscribeTournamentRequest")
+	proto.RegisterType((*CreateAccountRequest)(nil), "rummy.CreateAccountRequest")
+	proto.RegisterType((*CreateAccountResponse)(nil), "rummy.CreateAccountResponse")
+	proto.RegisterType((*PlayerStats)(nil), "rummy.PlayerStats")
+	proto.RegisterType((*PlayerStats_Game)(nil), "rummy.PlayerStats.Game")
+	proto.RegisterType((*GetLeaderboardRequest)(nil), "rummy.GetLeaderboardRequest")
+	proto.RegisterType((*GetLeaderboardResponse)(nil), "rummy.GetLeaderboardResponse")
+	proto.RegisterType((*GetPlayerStatsRequest)(nil), "rummy.GetPlayerStatsRequest")
+	proto.RegisterEnum("rummy.StrategyParam_Type", StrategyParam_Type_name, StrategyParam_Type_value)
+	proto.RegisterEnum("rummy.Tournament_Format", Tournament_Format_name, Tournament_Format_value)
+	proto.RegisterEnum("rummy.Tournament_Status", Tournament_Status_name, Tournament_Status_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for RummyService service
+
+type RummyServiceClient interface {
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
+	ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error)
+	UpdateInviteCode(ctx context.Context, in *UpdateInviteCodeRequest, opts ...grpc.CallOption) (*UpdateInviteCodeResponse, error)
+	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
+	FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (RummyService_FindMatchClient, error)
+	JoinSpectator(ctx context.Context, in *JoinSpectatorRequest, opts ...grpc.CallOption) (*JoinSpectatorResponse, error)
+	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
+	LeaveGame(ctx context.Context, in *LeaveGameRequest, opts ...grpc.CallOption) (*LeaveGameResponse, error)
+	TakeOverSeat(ctx context.Context, in *TakeOverSeatRequest, opts ...grpc.CallOption) (*TakeOverSeatResponse, error)
+	ReclaimSeat(ctx context.Context, in *ReclaimSeatRequest, opts ...grpc.CallOption) (*ReclaimSeatResponse, error)
+	ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error)
+	ListBots(ctx context.Context, in *ListBotsRequest, opts ...grpc.CallOption) (*ListBotsResponse, error)
+	PauseBot(ctx context.Context, in *PauseBotRequest, opts ...grpc.CallOption) (*PauseBotResponse, error)
+	RemoveBot(ctx context.Context, in *RemoveBotRequest, opts ...grpc.CallOption) (*RemoveBotResponse, error)
+	SendChat(ctx context.Context, in *SendChatRequest, opts ...grpc.CallOption) (*SendChatResponse, error)
+	// Play a game over a single stream, on which the server sends the
+	// results of the player's actions in order with the game's events.
+	// It is not available through the HTTP gateway.
+	Play(ctx context.Context, opts ...grpc.CallOption) (RummyService_PlayClient, error)
+	SubscribeGame(ctx context.Context, in *SubscribeGameRequest, opts ...grpc.CallOption) (RummyService_SubscribeGameClient, error)
+	GetGameState(ctx context.Context, in *GetGameStateRequest, opts ...grpc.CallOption) (*GameState, error)
+	GetHandCards(ctx context.Context, in *GetHandCardsRequest, opts ...grpc.CallOption) (*GetHandCardsResponse, error)
+	GetPlayerView(ctx context.Context, in *GetPlayerViewRequest, opts ...grpc.CallOption) (*PlayerView, error)
+	PickUpStock(ctx context.Context, in *PickUpStockRequest, opts ...grpc.CallOption) (*PickUpStockResponse, error)
+	PickUpDiscard(ctx context.Context, in *PickUpDiscardRequest, opts ...grpc.CallOption) (*PickUpDiscardResponse, error)
+	PlayCards(ctx context.Context, in *PlayCardsRequest, opts ...grpc.CallOption) (*PlayCardsResponse, error)
+	DiscardCard(ctx context.Context, in *DiscardCardRequest, opts ...grpc.CallOption) (*DiscardCardResponse, error)
+	CallRummy(ctx context.Context, in *CallRummyRequest, opts ...grpc.CallOption) (*CallRummyResponse, error)
+	CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*CreateTournamentResponse, error)
+	GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	SubscribeTournament(ctx context.Context, in *SubscribeTournamentRequest, opts ...grpc.CallOption) (RummyService_SubscribeTournamentClient, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	GetPlayerStats(ctx context.Context, in *GetPlayerStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error)
+}
+
+type rummyServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRummyServiceClient(cc *grpc.ClientConn) RummyServiceClient {
+	return &rummyServiceClient{cc}
+}
+
+func (c *rummyServiceClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error) {
+	out := new(CreateGameResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/CreateGame", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error) {
+	out := new(ListGamesResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/ListGames", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) UpdateInviteCode(ctx context.Context, in *UpdateInviteCodeRequest, opts ...grpc.CallOption) (*UpdateInviteCodeResponse, error) {
+	out := new(UpdateInviteCodeResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/UpdateInviteCode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error) {
+	out := new(JoinGameResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/JoinGame", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (RummyService_FindMatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RummyService_serviceDesc.Streams[0], c.cc, "/rummy.RummyService/FindMatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rummyServiceFindMatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RummyService_FindMatchClient interface {
+	Recv() (*FindMatchResponse, error)
+	grpc.ClientStream
+}
+
+type rummyServiceFindMatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *rummyServiceFindMatchClient) Recv() (*FindMatchResponse, error) {
+	m := new(FindMatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rummyServiceClient) JoinSpectator(ctx context.Context, in *JoinSpectatorRequest, opts ...grpc.CallOption) (*JoinSpectatorResponse, error) {
+	out := new(JoinSpectatorResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/JoinSpectator", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error) {
+	out := new(StartGameResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/StartGame", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) LeaveGame(ctx context.Context, in *LeaveGameRequest, opts ...grpc.CallOption) (*LeaveGameResponse, error) {
+	out := new(LeaveGameResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/LeaveGame", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) TakeOverSeat(ctx context.Context, in *TakeOverSeatRequest, opts ...grpc.CallOption) (*TakeOverSeatResponse, error) {
+	out := new(TakeOverSeatResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/TakeOverSeat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) ReclaimSeat(ctx context.Context, in *ReclaimSeatRequest, opts ...grpc.CallOption) (*ReclaimSeatResponse, error) {
+	out := new(ReclaimSeatResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/ReclaimSeat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error) {
+	out := new(ListStrategiesResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/ListStrategies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) ListBots(ctx context.Context, in *ListBotsRequest, opts ...grpc.CallOption) (*ListBotsResponse, error) {
+	out := new(ListBotsResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/ListBots", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) PauseBot(ctx context.Context, in *PauseBotRequest, opts ...grpc.CallOption) (*PauseBotResponse, error) {
+	out := new(PauseBotResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/PauseBot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) RemoveBot(ctx context.Context, in *RemoveBotRequest, opts ...grpc.CallOption) (*RemoveBotResponse, error) {
+	out := new(RemoveBotResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/RemoveBot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) SendChat(ctx context.Context, in *SendChatRequest, opts ...grpc.CallOption) (*SendChatResponse, error) {
+	out := new(SendChatResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/SendChat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) Play(ctx context.Context, opts ...grpc.CallOption) (RummyService_PlayClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RummyService_serviceDesc.Streams[1], c.cc, "/rummy.RummyService/Play", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rummyServicePlayClient{stream}
+	return x, nil
+}
+
+type RummyService_PlayClient interface {
+	Send(*PlayRequest) error
+	Recv() (*PlayResponse, error)
+	grpc.ClientStream
+}
+
+type rummyServicePlayClient struct {
+	grpc.ClientStream
+}
+
+func (x *rummyServicePlayClient) Send(m *PlayRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *rummyServicePlayClient) Recv() (*PlayResponse, error) {
+	m := new(PlayResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rummyServiceClient) SubscribeGame(ctx context.Context, in *SubscribeGameRequest, opts ...grpc.CallOption) (RummyService_SubscribeGameClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RummyService_serviceDesc.Streams[2], c.cc, "/rummy.RummyService/SubscribeGame", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rummyServiceSubscribeGameClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RummyService_SubscribeGameClient interface {
+	Recv() (*GameEvent, error)
+	grpc.ClientStream
+}
+
+type rummyServiceSubscribeGameClient struct {
+	grpc.ClientStream
 }
 
 func (x *rummyServiceSubscribeGameClient) Recv() (*GameEvent, error) {
@@ -636,125 +2970,479 @@ func (c *rummyServiceClient) GetHandCards(ctx context.Context, in *GetHandCardsR
 	return out, nil
 }
 
-func (c *rummyServiceClient) PickUpStock(ctx context.Context, in *PickUpStockRequest, opts ...grpc.CallOption) (*PickUpStockResponse, error) {
-	out := new(PickUpStockResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/PickUpStock", in, out, c.cc, opts...)
-	if err != nil {
+func (c *rummyServiceClient) GetPlayerView(ctx context.Context, in *GetPlayerViewRequest, opts ...grpc.CallOption) (*PlayerView, error) {
+	out := new(PlayerView)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/GetPlayerView", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) PickUpStock(ctx context.Context, in *PickUpStockRequest, opts ...grpc.CallOption) (*PickUpStockResponse, error) {
+	out := new(PickUpStockResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/PickUpStock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) PickUpDiscard(ctx context.Context, in *PickUpDiscardRequest, opts ...grpc.CallOption) (*PickUpDiscardResponse, error) {
+	out := new(PickUpDiscardResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/PickUpDiscard", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) PlayCards(ctx context.Context, in *PlayCardsRequest, opts ...grpc.CallOption) (*PlayCardsResponse, error) {
+	out := new(PlayCardsResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/PlayCards", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) DiscardCard(ctx context.Context, in *DiscardCardRequest, opts ...grpc.CallOption) (*DiscardCardResponse, error) {
+	out := new(DiscardCardResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/DiscardCard", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) CallRummy(ctx context.Context, in *CallRummyRequest, opts ...grpc.CallOption) (*CallRummyResponse, error) {
+	out := new(CallRummyResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/CallRummy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*CreateTournamentResponse, error) {
+	out := new(CreateTournamentResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/CreateTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/GetTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) SubscribeTournament(ctx context.Context, in *SubscribeTournamentRequest, opts ...grpc.CallOption) (RummyService_SubscribeTournamentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RummyService_serviceDesc.Streams[3], c.cc, "/rummy.RummyService/SubscribeTournament", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rummyServiceSubscribeTournamentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RummyService_SubscribeTournamentClient interface {
+	Recv() (*Tournament, error)
+	grpc.ClientStream
+}
+
+type rummyServiceSubscribeTournamentClient struct {
+	grpc.ClientStream
+}
+
+func (x *rummyServiceSubscribeTournamentClient) Recv() (*Tournament, error) {
+	m := new(Tournament)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rummyServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
+	out := new(CreateAccountResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/CreateAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	out := new(GetLeaderboardResponse)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/GetLeaderboard", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rummyServiceClient) GetPlayerStats(ctx context.Context, in *GetPlayerStatsRequest, opts ...grpc.CallOption) (*PlayerStats, error) {
+	out := new(PlayerStats)
+	err := grpc.Invoke(ctx, "/rummy.RummyService/GetPlayerStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RummyService service
+
+type RummyServiceServer interface {
+	CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
+	ListGames(context.Context, *ListGamesRequest) (*ListGamesResponse, error)
+	UpdateInviteCode(context.Context, *UpdateInviteCodeRequest) (*UpdateInviteCodeResponse, error)
+	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
+	FindMatch(*FindMatchRequest, RummyService_FindMatchServer) error
+	JoinSpectator(context.Context, *JoinSpectatorRequest) (*JoinSpectatorResponse, error)
+	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
+	LeaveGame(context.Context, *LeaveGameRequest) (*LeaveGameResponse, error)
+	TakeOverSeat(context.Context, *TakeOverSeatRequest) (*TakeOverSeatResponse, error)
+	ReclaimSeat(context.Context, *ReclaimSeatRequest) (*ReclaimSeatResponse, error)
+	ListStrategies(context.Context, *ListStrategiesRequest) (*ListStrategiesResponse, error)
+	ListBots(context.Context, *ListBotsRequest) (*ListBotsResponse, error)
+	PauseBot(context.Context, *PauseBotRequest) (*PauseBotResponse, error)
+	RemoveBot(context.Context, *RemoveBotRequest) (*RemoveBotResponse, error)
+	SendChat(context.Context, *SendChatRequest) (*SendChatResponse, error)
+	// Play a game over a single stream, on which the server sends the
+	// results of the player's actions in order with the game's events.
+	// It is not available through the HTTP gateway.
+	Play(RummyService_PlayServer) error
+	SubscribeGame(*SubscribeGameRequest, RummyService_SubscribeGameServer) error
+	GetGameState(context.Context, *GetGameStateRequest) (*GameState, error)
+	GetHandCards(context.Context, *GetHandCardsRequest) (*GetHandCardsResponse, error)
+	GetPlayerView(context.Context, *GetPlayerViewRequest) (*PlayerView, error)
+	PickUpStock(context.Context, *PickUpStockRequest) (*PickUpStockResponse, error)
+	PickUpDiscard(context.Context, *PickUpDiscardRequest) (*PickUpDiscardResponse, error)
+	PlayCards(context.Context, *PlayCardsRequest) (*PlayCardsResponse, error)
+	DiscardCard(context.Context, *DiscardCardRequest) (*DiscardCardResponse, error)
+	CallRummy(context.Context, *CallRummyRequest) (*CallRummyResponse, error)
+	CreateTournament(context.Context, *CreateTournamentRequest) (*CreateTournamentResponse, error)
+	GetTournament(context.Context, *GetTournamentRequest) (*Tournament, error)
+	SubscribeTournament(*SubscribeTournamentRequest, RummyService_SubscribeTournamentServer) error
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	GetPlayerStats(context.Context, *GetPlayerStatsRequest) (*PlayerStats, error)
+}
+
+func RegisterRummyServiceServer(s *grpc.Server, srv RummyServiceServer) {
+	s.RegisterService(&_RummyService_serviceDesc, srv)
+}
+
+func _RummyService_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/CreateGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_ListGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).ListGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/ListGames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).ListGames(ctx, req.(*ListGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_UpdateInviteCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInviteCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).UpdateInviteCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/UpdateInviteCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).UpdateInviteCode(ctx, req.(*UpdateInviteCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).JoinGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/JoinGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).JoinGame(ctx, req.(*JoinGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_FindMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RummyServiceServer).FindMatch(m, &rummyServiceFindMatchServer{stream})
+}
+
+type RummyService_FindMatchServer interface {
+	Send(*FindMatchResponse) error
+	grpc.ServerStream
+}
+
+type rummyServiceFindMatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *rummyServiceFindMatchServer) Send(m *FindMatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RummyService_JoinSpectator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinSpectatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).JoinSpectator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/JoinSpectator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).JoinSpectator(ctx, req.(*JoinSpectatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/StartGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).StartGame(ctx, req.(*StartGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_LeaveGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveGameRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RummyServiceServer).LeaveGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/LeaveGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).LeaveGame(ctx, req.(*LeaveGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rummyServiceClient) PickUpDiscard(ctx context.Context, in *PickUpDiscardRequest, opts ...grpc.CallOption) (*PickUpDiscardResponse, error) {
-	out := new(PickUpDiscardResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/PickUpDiscard", in, out, c.cc, opts...)
-	if err != nil {
+func _RummyService_TakeOverSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeOverSeatRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RummyServiceServer).TakeOverSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/TakeOverSeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).TakeOverSeat(ctx, req.(*TakeOverSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rummyServiceClient) PlayCards(ctx context.Context, in *PlayCardsRequest, opts ...grpc.CallOption) (*PlayCardsResponse, error) {
-	out := new(PlayCardsResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/PlayCards", in, out, c.cc, opts...)
-	if err != nil {
+func _RummyService_ReclaimSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReclaimSeatRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RummyServiceServer).ReclaimSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/ReclaimSeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).ReclaimSeat(ctx, req.(*ReclaimSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rummyServiceClient) DiscardCard(ctx context.Context, in *DiscardCardRequest, opts ...grpc.CallOption) (*DiscardCardResponse, error) {
-	out := new(DiscardCardResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/DiscardCard", in, out, c.cc, opts...)
-	if err != nil {
+func _RummyService_ListStrategies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStrategiesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RummyServiceServer).ListStrategies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/ListStrategies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).ListStrategies(ctx, req.(*ListStrategiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rummyServiceClient) CallRummy(ctx context.Context, in *CallRummyRequest, opts ...grpc.CallOption) (*CallRummyResponse, error) {
-	out := new(CallRummyResponse)
-	err := grpc.Invoke(ctx, "/rummy.RummyService/CallRummy", in, out, c.cc, opts...)
-	if err != nil {
+func _RummyService_ListBots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBotsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// Server API for RummyService service
-
-type RummyServiceServer interface {
-	CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
-	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
-	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
-	SubscribeGame(*SubscribeGameRequest, RummyService_SubscribeGameServer) error
-	GetGameState(context.Context, *GetGameStateRequest) (*GameState, error)
-	GetHandCards(context.Context, *GetHandCardsRequest) (*GetHandCardsResponse, error)
-	PickUpStock(context.Context, *PickUpStockRequest) (*PickUpStockResponse, error)
-	PickUpDiscard(context.Context, *PickUpDiscardRequest) (*PickUpDiscardResponse, error)
-	PlayCards(context.Context, *PlayCardsRequest) (*PlayCardsResponse, error)
-	DiscardCard(context.Context, *DiscardCardRequest) (*DiscardCardResponse, error)
-	CallRummy(context.Context, *CallRummyRequest) (*CallRummyResponse, error)
-}
-
-func RegisterRummyServiceServer(s *grpc.Server, srv RummyServiceServer) {
-	s.RegisterService(&_RummyService_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(RummyServiceServer).ListBots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/ListBots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).ListBots(ctx, req.(*ListBotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _RummyService_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGameRequest)
+func _RummyService_PauseBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseBotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RummyServiceServer).CreateGame(ctx, in)
+		return srv.(RummyServiceServer).PauseBot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rummy.RummyService/CreateGame",
+		FullMethod: "/rummy.RummyService/PauseBot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RummyServiceServer).CreateGame(ctx, req.(*CreateGameRequest))
+		return srv.(RummyServiceServer).PauseBot(ctx, req.(*PauseBotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RummyService_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinGameRequest)
+func _RummyService_RemoveBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RummyServiceServer).JoinGame(ctx, in)
+		return srv.(RummyServiceServer).RemoveBot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rummy.RummyService/JoinGame",
+		FullMethod: "/rummy.RummyService/RemoveBot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RummyServiceServer).JoinGame(ctx, req.(*JoinGameRequest))
+		return srv.(RummyServiceServer).RemoveBot(ctx, req.(*RemoveBotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RummyService_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartGameRequest)
+func _RummyService_SendChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendChatRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RummyServiceServer).StartGame(ctx, in)
+		return srv.(RummyServiceServer).SendChat(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rummy.RummyService/StartGame",
+		FullMethod: "/rummy.RummyService/SendChat",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RummyServiceServer).StartGame(ctx, req.(*StartGameRequest))
+		return srv.(RummyServiceServer).SendChat(ctx, req.(*SendChatRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RummyService_Play_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RummyServiceServer).Play(&rummyServicePlayServer{stream})
+}
+
+type RummyService_PlayServer interface {
+	Send(*PlayResponse) error
+	Recv() (*PlayRequest, error)
+	grpc.ServerStream
+}
+
+type rummyServicePlayServer struct {
+	grpc.ServerStream
+}
+
+func (x *rummyServicePlayServer) Send(m *PlayResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *rummyServicePlayServer) Recv() (*PlayRequest, error) {
+	m := new(PlayRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _RummyService_SubscribeGame_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeGameRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -812,6 +3500,24 @@ func _RummyService_GetHandCards_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RummyService_GetPlayerView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).GetPlayerView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/GetPlayerView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).GetPlayerView(ctx, req.(*GetPlayerViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RummyService_PickUpStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PickUpStockRequest)
 	if err := dec(in); err != nil {
@@ -902,6 +3608,117 @@ func _RummyService_CallRummy_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RummyService_CreateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).CreateTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/CreateTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).CreateTournament(ctx, req.(*CreateTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_GetTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).GetTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/GetTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).GetTournament(ctx, req.(*GetTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_SubscribeTournament_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTournamentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RummyServiceServer).SubscribeTournament(m, &rummyServiceSubscribeTournamentServer{stream})
+}
+
+type RummyService_SubscribeTournamentServer interface {
+	Send(*Tournament) error
+	grpc.ServerStream
+}
+
+type rummyServiceSubscribeTournamentServer struct {
+	grpc.ServerStream
+}
+
+func (x *rummyServiceSubscribeTournamentServer) Send(m *Tournament) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RummyService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RummyService_GetPlayerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RummyServiceServer).GetPlayerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rummy.RummyService/GetPlayerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RummyServiceServer).GetPlayerStats(ctx, req.(*GetPlayerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RummyService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rummy.RummyService",
 	HandlerType: (*RummyServiceServer)(nil),
@@ -910,14 +3727,58 @@ var _RummyService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateGame",
 			Handler:    _RummyService_CreateGame_Handler,
 		},
+		{
+			MethodName: "ListGames",
+			Handler:    _RummyService_ListGames_Handler,
+		},
+		{
+			MethodName: "UpdateInviteCode",
+			Handler:    _RummyService_UpdateInviteCode_Handler,
+		},
 		{
 			MethodName: "JoinGame",
 			Handler:    _RummyService_JoinGame_Handler,
 		},
+		{
+			MethodName: "JoinSpectator",
+			Handler:    _RummyService_JoinSpectator_Handler,
+		},
 		{
 			MethodName: "StartGame",
 			Handler:    _RummyService_StartGame_Handler,
 		},
+		{
+			MethodName: "LeaveGame",
+			Handler:    _RummyService_LeaveGame_Handler,
+		},
+		{
+			MethodName: "TakeOverSeat",
+			Handler:    _RummyService_TakeOverSeat_Handler,
+		},
+		{
+			MethodName: "ReclaimSeat",
+			Handler:    _RummyService_ReclaimSeat_Handler,
+		},
+		{
+			MethodName: "ListStrategies",
+			Handler:    _RummyService_ListStrategies_Handler,
+		},
+		{
+			MethodName: "ListBots",
+			Handler:    _RummyService_ListBots_Handler,
+		},
+		{
+			MethodName: "PauseBot",
+			Handler:    _RummyService_PauseBot_Handler,
+		},
+		{
+			MethodName: "RemoveBot",
+			Handler:    _RummyService_RemoveBot_Handler,
+		},
+		{
+			MethodName: "SendChat",
+			Handler:    _RummyService_SendChat_Handler,
+		},
 		{
 			MethodName: "GetGameState",
 			Handler:    _RummyService_GetGameState_Handler,
@@ -926,6 +3787,10 @@ var _RummyService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHandCards",
 			Handler:    _RummyService_GetHandCards_Handler,
 		},
+		{
+			MethodName: "GetPlayerView",
+			Handler:    _RummyService_GetPlayerView_Handler,
+		},
 		{
 			MethodName: "PickUpStock",
 			Handler:    _RummyService_PickUpStock_Handler,
@@ -946,13 +3811,49 @@ var _RummyService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CallRummy",
 			Handler:    _RummyService_CallRummy_Handler,
 		},
+		{
+			MethodName: "CreateTournament",
+			Handler:    _RummyService_CreateTournament_Handler,
+		},
+		{
+			MethodName: "GetTournament",
+			Handler:    _RummyService_GetTournament_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _RummyService_CreateAccount_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _RummyService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetPlayerStats",
+			Handler:    _RummyService_GetPlayerStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FindMatch",
+			Handler:       _RummyService_FindMatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Play",
+			Handler:       _RummyService_Play_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SubscribeGame",
 			Handler:       _RummyService_SubscribeGame_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeTournament",
+			Handler:       _RummyService_SubscribeTournament_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "service.proto",
 }
@@ -960,59 +3861,215 @@ var _RummyService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("service.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 852 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcd, 0x6a, 0x23, 0x47,
-	0x10, 0x66, 0x6c, 0xc9, 0x91, 0x4a, 0x56, 0x22, 0xb5, 0x64, 0x59, 0x1e, 0x39, 0x8e, 0xd2, 0xb9,
-	0x38, 0x0e, 0x68, 0xfc, 0x43, 0x88, 0xe3, 0x43, 0x2e, 0x4a, 0x70, 0x92, 0x43, 0x30, 0x12, 0x39,
-	0xac, 0x77, 0x17, 0xd1, 0x9a, 0x69, 0xe4, 0xb1, 0xe6, 0x6f, 0x67, 0x5a, 0x06, 0x61, 0x0c, 0xcb,
-	0x3e, 0xc0, 0xb2, 0xe0, 0x47, 0xdb, 0x57, 0xd8, 0x67, 0xd8, 0xf3, 0xd2, 0x3d, 0x3d, 0xe3, 0xf9,
-	0x33, 0xe8, 0x24, 0xf6, 0x62, 0x50, 0xfd, 0x7c, 0x5f, 0x55, 0x75, 0xcd, 0x57, 0x86, 0x7a, 0x40,
-	0xfd, 0x3b, 0x53, 0xa7, 0x03, 0xcf, 0x77, 0x99, 0x8b, 0xca, 0xfe, 0xc2, 0xb6, 0x97, 0xea, 0xfe,
-	0xcc, 0x75, 0x67, 0x16, 0xd5, 0x88, 0x67, 0x6a, 0xc4, 0x71, 0x5c, 0x46, 0x98, 0xe9, 0x3a, 0x41,
-	0x18, 0xa4, 0xfe, 0x32, 0x33, 0xd9, 0xcd, 0x62, 0x3a, 0xd0, 0x5d, 0x5b, 0x63, 0xa6, 0xed, 0x11,
-	0xcb, 0x23, 0x0e, 0xd3, 0x44, 0xaa, 0x66, 0x50, 0x7d, 0x2e, 0xfe, 0xc8, 0x60, 0x98, 0x11, 0x5b,
-	0xa2, 0xe3, 0x63, 0x68, 0x0e, 0x7d, 0x4a, 0x18, 0xbd, 0x24, 0x36, 0x1d, 0xd1, 0x37, 0x0b, 0x1a,
-	0x30, 0xd4, 0x83, 0x2a, 0x0f, 0x99, 0x38, 0xc4, 0xa6, 0x5d, 0xa5, 0xaf, 0x1c, 0x56, 0x47, 0x15,
-	0x6e, 0xf8, 0x8f, 0xd8, 0x14, 0xb7, 0x01, 0x25, 0x33, 0x02, 0xcf, 0x75, 0x02, 0x8a, 0x3f, 0x28,
-	0xf0, 0xdd, 0xbf, 0xae, 0xe9, 0xac, 0x0a, 0x83, 0x7e, 0x80, 0x9a, 0x67, 0x91, 0x25, 0xf5, 0x43,
-	0xf7, 0x86, 0x70, 0x43, 0x68, 0x12, 0x01, 0x3f, 0x41, 0x5d, 0x06, 0x04, 0x54, 0xf7, 0x29, 0xeb,
-	0x6e, 0x8a, 0x90, 0xed, 0xd0, 0x38, 0x16, 0x36, 0xa4, 0x42, 0x25, 0x60, 0x3e, 0x61, 0x74, 0xb6,
-	0xec, 0x96, 0x42, 0x86, 0xe8, 0x37, 0xd6, 0xa0, 0xf1, 0x54, 0x51, 0x58, 0x26, 0x2f, 0x49, 0x82,
-	0x9a, 0x86, 0x28, 0xa9, 0x3c, 0xaa, 0x84, 0x86, 0x7f, 0x0c, 0x9e, 0x30, 0x66, 0xc4, 0x67, 0x2b,
-	0x8f, 0xa2, 0x05, 0xcd, 0x44, 0x82, 0x9c, 0xc4, 0x29, 0xb4, 0x2e, 0xa9, 0x30, 0x8d, 0x19, 0x61,
-	0xab, 0x01, 0x31, 0x91, 0xf3, 0x37, 0x71, 0x8c, 0x21, 0xf1, 0x8d, 0x60, 0xa5, 0x01, 0xa6, 0x5a,
-	0xd9, 0x48, 0xb7, 0xb2, 0xd2, 0xf0, 0xf0, 0x39, 0xb4, 0xd3, 0xac, 0x72, 0x48, 0x7d, 0x28, 0xeb,
-	0xdc, 0xd0, 0x55, 0xfa, 0x9b, 0x87, 0xb5, 0x53, 0x18, 0x88, 0xdd, 0xe1, 0x31, 0xa3, 0xd0, 0x81,
-	0xcf, 0xa0, 0x3d, 0x5e, 0x4c, 0x03, 0xdd, 0x37, 0xa7, 0xab, 0x2f, 0x4e, 0x00, 0xe8, 0xca, 0xd4,
-	0xe7, 0xff, 0x7b, 0x63, 0xe6, 0xea, 0xf3, 0x35, 0xf5, 0xf8, 0x2b, 0xb4, 0x52, 0xa4, 0xb2, 0xc5,
-	0x03, 0x28, 0xf1, 0x4e, 0x04, 0x61, 0xba, 0x43, 0x61, 0xc7, 0xef, 0x15, 0x68, 0x87, 0x79, 0x7f,
-	0x9a, 0x01, 0xb7, 0xac, 0xa7, 0x5c, 0xb4, 0x0b, 0xdf, 0x38, 0x93, 0x70, 0xf8, 0x25, 0x91, 0xbf,
-	0xe5, 0x88, 0xb7, 0xc1, 0xbf, 0xc3, 0x4e, 0xa6, 0x9e, 0x95, 0x1f, 0xeb, 0x51, 0x81, 0xc6, 0x95,
-	0x45, 0x96, 0x6b, 0x5c, 0xad, 0xa7, 0xaa, 0x4a, 0xcf, 0x55, 0xf5, 0x33, 0x34, 0x13, 0x45, 0xc9,
-	0x66, 0xda, 0x50, 0x0e, 0x74, 0xd7, 0xa7, 0xf2, 0xd3, 0x0c, 0x7f, 0xf0, 0x06, 0x90, 0x6c, 0x7b,
-	0xb8, 0xbe, 0xa7, 0x88, 0x56, 0xa4, 0xf4, 0xcc, 0x8a, 0xec, 0x40, 0x2b, 0x55, 0x94, 0xfc, 0xfc,
-	0xf9, 0xb4, 0x87, 0xc4, 0xb2, 0x46, 0x5c, 0x7a, 0xbf, 0x9a, 0x69, 0xb7, 0xa0, 0x99, 0x28, 0x2a,
-	0x2c, 0xf5, 0xf4, 0x73, 0x05, 0xb6, 0x85, 0x65, 0x1c, 0x1e, 0x1c, 0x44, 0x00, 0x9e, 0xa4, 0x1d,
-	0x75, 0x07, 0xe2, 0x7c, 0x0c, 0x72, 0xf7, 0x41, 0xdd, 0x2b, 0xf0, 0xc8, 0xf6, 0x0f, 0xde, 0x7d,
-	0xfc, 0xf4, 0xb8, 0xd1, 0xc5, 0x1d, 0xed, 0xee, 0x44, 0xd3, 0x85, 0x5f, 0xbb, 0x8f, 0x7b, 0x7f,
-	0x40, 0xf7, 0x50, 0x89, 0x44, 0x19, 0x75, 0x24, 0x4c, 0xe6, 0x6e, 0xa8, 0xbb, 0x39, 0xbb, 0x04,
-	0xff, 0x43, 0x80, 0x9f, 0x63, 0xcc, 0xc1, 0x6f, 0x5d, 0xd3, 0x49, 0x42, 0x6b, 0xf7, 0x89, 0x7b,
-	0xf2, 0x70, 0x8d, 0x70, 0x3d, 0x8a, 0x9a, 0xf0, 0xa0, 0x0b, 0xe5, 0x08, 0xbd, 0x86, 0x6a, 0xac,
-	0xd7, 0x28, 0x62, 0xc9, 0x4a, 0xbe, 0xda, 0xcd, 0x3b, 0x24, 0xff, 0xf7, 0x82, 0x7f, 0x17, 0xef,
-	0x70, 0xe4, 0x80, 0xbb, 0x53, 0xbd, 0xe9, 0x50, 0x4f, 0xa9, 0x22, 0xea, 0x45, 0x48, 0x05, 0x5a,
-	0xa9, 0x36, 0xa4, 0x93, 0xdb, 0xfe, 0xba, 0xa3, 0x0e, 0xc3, 0x3f, 0x0a, 0xf8, 0x1e, 0xda, 0x13,
-	0xf0, 0x51, 0x4e, 0x92, 0xe2, 0x58, 0x41, 0x2f, 0x61, 0x3b, 0x79, 0x5e, 0x90, 0x1a, 0xc1, 0xe4,
-	0x6f, 0x4e, 0x8a, 0x42, 0x38, 0xa2, 0x0e, 0x50, 0xd4, 0x41, 0xe6, 0x75, 0xde, 0x2a, 0x02, 0x3d,
-	0x3e, 0x09, 0x49, 0xf4, 0xec, 0x75, 0x52, 0x7b, 0x85, 0x3e, 0x39, 0xaa, 0xdf, 0x04, 0xd1, 0x09,
-	0xea, 0x73, 0xa2, 0x1b, 0xe2, 0x18, 0x85, 0x4f, 0x65, 0x1a, 0x0f, 0xd7, 0x75, 0x5c, 0x89, 0x62,
-	0xf8, 0x1b, 0x4d, 0xa1, 0x96, 0x10, 0x6c, 0x14, 0xad, 0x5a, 0xfe, 0x72, 0xa8, 0x6a, 0x91, 0x4b,
-	0xd2, 0xef, 0x0b, 0xfa, 0x0e, 0x6e, 0x72, 0x68, 0xcf, 0xd4, 0xe7, 0x93, 0x85, 0x37, 0x09, 0x78,
-	0x08, 0xe7, 0xb8, 0x85, 0x7a, 0x4a, 0x4c, 0xe3, 0x87, 0x2a, 0x92, 0x7c, 0x75, 0xbf, 0xd8, 0x99,
-	0x59, 0xf8, 0x56, 0x92, 0xc9, 0x08, 0x83, 0x38, 0xd7, 0x0b, 0xa8, 0xc6, 0x3a, 0x17, 0xef, 0x5c,
-	0x56, 0x8e, 0xe3, 0x9d, 0xcb, 0x49, 0x22, 0xde, 0x13, 0xf8, 0x2d, 0xfc, 0xad, 0xc0, 0xb7, 0xc8,
-	0x32, 0x3c, 0x0f, 0x1c, 0xfa, 0x15, 0xd4, 0x12, 0x0a, 0x14, 0x8f, 0x2a, 0x2f, 0x95, 0xf1, 0xa8,
-	0x8a, 0x04, 0xab, 0x23, 0x08, 0x1a, 0xb8, 0xc6, 0x09, 0xd2, 0x85, 0xc7, 0x92, 0x11, 0x17, 0x9e,
-	0x55, 0xb6, 0xb8, 0xf0, 0x9c, 0xba, 0xa4, 0x0b, 0xd7, 0x89, 0x65, 0x4d, 0x44, 0xe4, 0x85, 0x72,
-	0x34, 0xdd, 0x12, 0xff, 0x7b, 0x9e, 0x7d, 0x09, 0x00, 0x00, 0xff, 0xff, 0x71, 0x1c, 0x83, 0x93,
-	0xea, 0x0a, 0x00, 0x00,
+	// 3358 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0x4b, 0x73, 0x1b, 0xc7,
+	0xd1, 0x5a, 0xbc, 0x08, 0x34, 0x00, 0x12, 0x1c, 0xf0, 0x01, 0x2d, 0xa9, 0xd7, 0xda, 0xfe, 0x2c,
+	0xcb, 0x32, 0x29, 0xd3, 0x56, 0x7d, 0xb6, 0x3e, 0xd7, 0xf7, 0x7d, 0x12, 0x45, 0x29, 0x4c, 0x51,
+	0x24, 0xb3, 0xa0, 0xe4, 0xc4, 0x4e, 0xd5, 0x66, 0x09, 0x0c, 0xc9, 0x35, 0x81, 0x5d, 0x78, 0x77,
+	0x40, 0x8a, 0xa5, 0x52, 0xc5, 0x79, 0x9c, 0x92, 0x1c, 0xf2, 0x38, 0xa6, 0x2a, 0x17, 0xa7, 0x72,
+	0x4b, 0x2e, 0xa9, 0xf2, 0x21, 0xa7, 0xfc, 0x83, 0x1c, 0xf2, 0x07, 0x52, 0x95, 0x9c, 0x73, 0xcf,
+	0x2d, 0x35, 0x3d, 0xb3, 0xef, 0x05, 0x89, 0x58, 0x0a, 0xcb, 0x87, 0x5c, 0x58, 0x98, 0xee, 0xde,
+	0xee, 0x9e, 0x9e, 0xee, 0xde, 0xee, 0xe9, 0x25, 0xd4, 0x3d, 0xea, 0x1e, 0x59, 0x1d, 0xba, 0x34,
+	0x70, 0x1d, 0xe6, 0x90, 0xa2, 0x3b, 0xec, 0xf7, 0x4f, 0xd4, 0xc5, 0x7d, 0xc7, 0xd9, 0xef, 0xd1,
+	0x65, 0x73, 0x60, 0x2d, 0x9b, 0xb6, 0xed, 0x30, 0x93, 0x59, 0x8e, 0xed, 0x09, 0x22, 0xf5, 0xcd,
+	0x7d, 0x8b, 0x1d, 0x0c, 0x77, 0x97, 0x3a, 0x4e, 0x7f, 0x99, 0x59, 0xfd, 0x81, 0xd9, 0x1b, 0x98,
+	0x36, 0x5b, 0xc6, 0x47, 0x97, 0xbb, 0xb4, 0x73, 0x88, 0x7f, 0x24, 0x31, 0xec, 0x9b, 0x7d, 0xc9,
+	0x5d, 0xfb, 0x2e, 0x4c, 0xaf, 0xba, 0xd4, 0x64, 0xf4, 0xa1, 0xd9, 0xa7, 0x3a, 0xfd, 0x74, 0x48,
+	0x3d, 0x46, 0x16, 0xa0, 0xc2, 0x49, 0x0c, 0xdb, 0xec, 0xd3, 0x96, 0x72, 0x55, 0xb9, 0x5e, 0xd1,
+	0xcb, 0x1c, 0xb0, 0x69, 0xf6, 0x29, 0xb9, 0x09, 0x13, 0xce, 0x00, 0x65, 0xb7, 0x72, 0x57, 0x95,
+	0xeb, 0xd5, 0x15, 0xb2, 0x84, 0x62, 0x96, 0x38, 0x87, 0x2d, 0x81, 0xd1, 0x7d, 0x12, 0x72, 0x05,
+	0xaa, 0x96, 0x7d, 0x64, 0x31, 0x6a, 0x74, 0x9c, 0x2e, 0x6d, 0xe5, 0x91, 0x19, 0x08, 0xd0, 0xaa,
+	0xd3, 0xa5, 0xda, 0x13, 0x20, 0x51, 0x05, 0xbc, 0x81, 0x63, 0x7b, 0x34, 0xf9, 0x98, 0x92, 0x7c,
+	0x8c, 0x13, 0x1c, 0x38, 0x1e, 0x33, 0x3c, 0xda, 0x71, 0x29, 0x43, 0x4d, 0x2a, 0x3a, 0x70, 0x50,
+	0x1b, 0x21, 0xda, 0xcf, 0xf2, 0x30, 0xf5, 0x75, 0xc7, 0xb2, 0xc7, 0xde, 0xd7, 0x15, 0xa8, 0x0e,
+	0x7a, 0xe6, 0x09, 0x75, 0x05, 0x5a, 0x72, 0x14, 0x20, 0x24, 0x78, 0x05, 0xea, 0x92, 0x40, 0x0a,
+	0x15, 0x9b, 0xa9, 0x09, 0xa0, 0x10, 0x4b, 0x54, 0x28, 0x7b, 0xcc, 0x35, 0x19, 0xdd, 0x3f, 0x69,
+	0x15, 0x84, 0x04, 0x7f, 0x9d, 0xdc, 0x54, 0x31, 0xb5, 0xa9, 0x6b, 0x50, 0x33, 0x3b, 0x1d, 0x67,
+	0x68, 0x33, 0xa1, 0x43, 0x09, 0x29, 0xaa, 0x12, 0xe6, 0x2b, 0xe1, 0x93, 0x30, 0xe7, 0x90, 0xda,
+	0xad, 0x09, 0xa1, 0x84, 0x04, 0xee, 0x70, 0x18, 0x69, 0xc3, 0x94, 0x2f, 0xd4, 0x18, 0x98, 0xae,
+	0xd9, 0xf7, 0x5a, 0xe5, 0xab, 0xf9, 0xeb, 0xd5, 0x95, 0x1b, 0xf2, 0xa8, 0x12, 0x86, 0x59, 0x6a,
+	0x4b, 0xea, 0x6d, 0x24, 0x5e, 0xb3, 0x99, 0x7b, 0xa2, 0x4f, 0x7a, 0x31, 0xa0, 0x7a, 0x17, 0x9a,
+	0x19, 0x64, 0xa4, 0x01, 0xf9, 0x43, 0x7a, 0x22, 0xad, 0xc9, 0x7f, 0x92, 0x19, 0x28, 0x1e, 0x99,
+	0xbd, 0xa1, 0x6f, 0x42, 0xb1, 0xb8, 0x93, 0x7b, 0x4f, 0xd1, 0x76, 0xa0, 0x11, 0x4a, 0x96, 0x27,
+	0xbd, 0x00, 0x15, 0x69, 0x55, 0xab, 0x8b, 0x5c, 0x8a, 0x7a, 0x59, 0x00, 0xd6, 0xbb, 0x69, 0x93,
+	0xe7, 0xd2, 0x26, 0xd7, 0x7e, 0x93, 0x83, 0xc6, 0x03, 0xcb, 0xee, 0x3e, 0x32, 0x59, 0xe7, 0xc0,
+	0x3f, 0xea, 0xc4, 0x69, 0x2a, 0x67, 0x9f, 0x66, 0x06, 0x6b, 0xce, 0xc5, 0x1e, 0xf6, 0x0d, 0x01,
+	0xf3, 0xf0, 0xc0, 0x8b, 0x3a, 0xd8, 0xc3, 0xfe, 0xb6, 0x80, 0x90, 0xfb, 0x50, 0x77, 0x87, 0x3d,
+	0xea, 0x19, 0x47, 0xa6, 0x6b, 0x99, 0x36, 0xc3, 0x33, 0x9f, 0x5c, 0xb9, 0x92, 0x0e, 0x89, 0x25,
+	0x9d, 0xd3, 0x3d, 0x11, 0x64, 0x7a, 0xcd, 0x8d, 0xac, 0xc8, 0x25, 0x00, 0xb3, 0xd7, 0x73, 0x8e,
+	0x8d, 0x5d, 0x87, 0x79, 0xe8, 0x17, 0x65, 0xbd, 0x82, 0x90, 0x7b, 0x0e, 0xf3, 0x5e, 0x96, 0x5b,
+	0x68, 0xbf, 0x54, 0x60, 0x3a, 0x62, 0x28, 0x79, 0x00, 0xaf, 0xc3, 0x94, 0xdc, 0x9f, 0x71, 0x6c,
+	0x5a, 0xcc, 0xb2, 0xf7, 0xe5, 0x31, 0x4c, 0x4a, 0xf0, 0x87, 0x02, 0x1a, 0x8f, 0x9e, 0x5c, 0x22,
+	0x7a, 0x62, 0xc7, 0x98, 0x3f, 0xeb, 0x18, 0x0b, 0x19, 0xc7, 0xf8, 0x0c, 0x66, 0xb8, 0x73, 0xb4,
+	0x07, 0xb4, 0xc3, 0x4c, 0xe6, 0xb8, 0x63, 0x05, 0xed, 0x6b, 0x30, 0xe9, 0xf9, 0x0f, 0x44, 0x15,
+	0xab, 0x07, 0x50, 0x3f, 0xb6, 0x4f, 0xcf, 0x42, 0xff, 0x0f, 0xb3, 0x09, 0xe1, 0xa1, 0x75, 0x42,
+	0x01, 0xc2, 0xb4, 0x42, 0x87, 0x50, 0xae, 0x30, 0xee, 0xcf, 0x15, 0x68, 0xb4, 0x99, 0xe9, 0xb2,
+	0x7f, 0x25, 0xe1, 0x9c, 0x9a, 0xc2, 0x5e, 0x82, 0x4d, 0x9b, 0x30, 0x1d, 0xd1, 0x49, 0x6c, 0x49,
+	0xfb, 0x83, 0x02, 0xcd, 0x87, 0x14, 0x61, 0x6d, 0x66, 0xb2, 0xf1, 0x94, 0x5d, 0x80, 0x8a, 0xe5,
+	0xc9, 0x40, 0x40, 0x55, 0xcb, 0x7a, 0xd9, 0xf2, 0x44, 0x18, 0xbc, 0xb8, 0xa2, 0x59, 0x66, 0x2e,
+	0x66, 0x9a, 0x99, 0xa1, 0xee, 0x5f, 0x33, 0xed, 0xee, 0xaa, 0xe9, 0x76, 0xbd, 0x71, 0x75, 0x0f,
+	0xd5, 0xcb, 0x9d, 0xa5, 0x5e, 0x46, 0x56, 0xd7, 0xde, 0x83, 0x99, 0xb8, 0x54, 0xe9, 0x1d, 0x57,
+	0xa1, 0xd8, 0xe1, 0x80, 0x96, 0x82, 0xe9, 0x15, 0x96, 0xf0, 0x2d, 0xcb, 0x69, 0x74, 0x81, 0xd0,
+	0x86, 0xf8, 0xa4, 0xb0, 0xd3, 0x13, 0x8b, 0x1e, 0x9f, 0x93, 0xc2, 0x04, 0x1a, 0x1b, 0x96, 0x87,
+	0x67, 0xec, 0xdb, 0x48, 0xfb, 0xbb, 0x02, 0xd3, 0x11, 0xa0, 0xdc, 0xc2, 0x3b, 0x50, 0xe4, 0x72,
+	0xfd, 0x2d, 0x5c, 0x92, 0x99, 0x2b, 0x45, 0x88, 0xb9, 0x4c, 0x17, 0xb4, 0xea, 0xe7, 0x0a, 0x14,
+	0x1e, 0x4a, 0x4d, 0x47, 0x6f, 0x63, 0x19, 0x4a, 0x1e, 0x33, 0xd9, 0x50, 0x14, 0x0a, 0x93, 0x2b,
+	0xf3, 0x91, 0xac, 0x88, 0x9e, 0xb7, 0xd4, 0x46, 0xb4, 0x2e, 0xc9, 0xce, 0x4e, 0xb7, 0x91, 0xda,
+	0xa3, 0x70, 0x66, 0xed, 0xa1, 0xfd, 0x54, 0x81, 0xf9, 0xc7, 0x83, 0xae, 0xc9, 0xe8, 0x7a, 0x10,
+	0xe9, 0x2f, 0x27, 0x32, 0xe7, 0xa0, 0xe4, 0xd2, 0x23, 0xe7, 0x50, 0xa4, 0x92, 0xb2, 0x2e, 0x57,
+	0xc9, 0x3c, 0x53, 0x48, 0xe5, 0x99, 0xff, 0x81, 0x56, 0x5a, 0xa3, 0x31, 0x6b, 0x1e, 0xed, 0x27,
+	0x0a, 0x34, 0x77, 0xcc, 0x43, 0xba, 0x75, 0xc4, 0xcf, 0xd9, 0x64, 0xe7, 0x90, 0x65, 0x4e, 0x29,
+	0x67, 0xb4, 0x39, 0x98, 0x89, 0x6b, 0x23, 0xf3, 0x8b, 0x07, 0x44, 0xa7, 0x9d, 0x9e, 0x69, 0xf5,
+	0xc7, 0x56, 0xf2, 0xc5, 0x1d, 0x7e, 0x16, 0x9a, 0x31, 0xa1, 0x52, 0x97, 0xbf, 0x2a, 0x50, 0x8f,
+	0x55, 0x2d, 0x84, 0x40, 0x21, 0xa2, 0x02, 0xfe, 0x26, 0x57, 0xa1, 0xda, 0xa5, 0x5e, 0xc7, 0xb5,
+	0xd0, 0x71, 0xa4, 0x8d, 0xa2, 0x20, 0xf2, 0x16, 0x14, 0xd8, 0xc9, 0x40, 0x1c, 0xf7, 0xe4, 0xca,
+	0x45, 0xe9, 0x75, 0x31, 0xce, 0x4b, 0x3b, 0x27, 0x03, 0xaa, 0x23, 0x19, 0x57, 0xb9, 0x4b, 0xf7,
+	0xcc, 0x61, 0x8f, 0x19, 0xa2, 0x14, 0x92, 0x39, 0x4f, 0x02, 0x9f, 0x70, 0x18, 0xaf, 0x9c, 0xfa,
+	0x96, 0xc8, 0x73, 0x8a, 0xce, 0x7f, 0x22, 0xc4, 0x7c, 0xda, 0x2a, 0x49, 0x88, 0xf9, 0x54, 0x7b,
+	0x15, 0x0a, 0x9c, 0x2d, 0x99, 0x80, 0xfc, 0xfa, 0xe6, 0x4e, 0xe3, 0x02, 0xa9, 0x40, 0xf1, 0xc1,
+	0xc6, 0xd6, 0xdd, 0x9d, 0x86, 0x42, 0xca, 0x50, 0xb8, 0xb7, 0xb5, 0xb5, 0xd1, 0xc8, 0x69, 0x2e,
+	0xd4, 0x7c, 0x55, 0xd6, 0xed, 0x3d, 0xe7, 0x4b, 0xee, 0xf1, 0x26, 0x94, 0x64, 0xb1, 0x98, 0xc7,
+	0x54, 0x30, 0x93, 0xb5, 0x4b, 0x5d, 0xd2, 0x68, 0xf3, 0x30, 0xcb, 0x73, 0x84, 0x44, 0x5a, 0x61,
+	0x9a, 0x79, 0x04, 0x73, 0x49, 0x44, 0x90, 0x6a, 0xc0, 0x0b, 0xa0, 0x32, 0xdf, 0x34, 0x13, 0x42,
+	0xb8, 0xfe, 0x7a, 0x84, 0x4c, 0xdb, 0x82, 0x29, 0xce, 0x8e, 0x17, 0x42, 0x2f, 0xc5, 0xdf, 0xb5,
+	0x7f, 0xe4, 0xa0, 0x11, 0x72, 0x94, 0xaa, 0x2d, 0x43, 0x01, 0x6b, 0x2f, 0xa1, 0xd4, 0x42, 0x24,
+	0x09, 0x46, 0xc9, 0x96, 0xee, 0x39, 0x4c, 0x47, 0x42, 0xf5, 0xf7, 0x39, 0xc8, 0xdf, 0x73, 0xd8,
+	0xe9, 0xe5, 0xeb, 0x99, 0x2d, 0x45, 0x34, 0xbc, 0xf2, 0x89, 0x6e, 0x61, 0x8e, 0x1f, 0xc7, 0xd0,
+	0xa3, 0x5d, 0x74, 0x9e, 0xb2, 0x2e, 0x57, 0xbc, 0x58, 0x64, 0xe6, 0x21, 0xb5, 0x0d, 0xe7, 0x88,
+	0xba, 0x7e, 0xb1, 0x88, 0x10, 0x1e, 0x89, 0xe4, 0x9b, 0xe9, 0xda, 0xbf, 0x84, 0x9b, 0x5a, 0x3e,
+	0x65, 0x53, 0xe7, 0xd5, 0x00, 0xfc, 0x50, 0x81, 0xa9, 0x6d, 0xbe, 0x0d, 0x6e, 0xc8, 0x7f, 0x7f,
+	0xf6, 0x1a, 0x61, 0x42, 0xfe, 0x76, 0x0c, 0xb5, 0x90, 0x99, 0xa2, 0x0f, 0x0d, 0x9d, 0xf6, 0x9d,
+	0xa3, 0xf3, 0x51, 0x8d, 0x57, 0x66, 0x11, 0x71, 0x52, 0x87, 0x1f, 0x29, 0x30, 0xd5, 0xa6, 0x76,
+	0x77, 0xf5, 0xe0, 0xbc, 0xf2, 0x26, 0x69, 0xc1, 0x44, 0x9f, 0x7a, 0x9e, 0xb9, 0xef, 0xe7, 0x28,
+	0x7f, 0xa9, 0x2d, 0x41, 0x23, 0xd4, 0x45, 0x86, 0x09, 0xf7, 0x57, 0xae, 0x97, 0xdd, 0x11, 0xba,
+	0xe4, 0xf5, 0x60, 0xad, 0x7d, 0x0a, 0x8d, 0x0d, 0x6a, 0x1e, 0x8d, 0x7f, 0x91, 0xf0, 0xe2, 0x49,
+	0xbf, 0x09, 0xd3, 0x11, 0x91, 0xd2, 0x88, 0x7f, 0x51, 0x60, 0xa6, 0x3d, 0xdc, 0xe5, 0x79, 0x6d,
+	0x77, 0x7c, 0x65, 0x5e, 0x81, 0xfa, 0x9e, 0xeb, 0xf4, 0x8d, 0x60, 0x7b, 0x39, 0xdc, 0x5e, 0x8d,
+	0x03, 0xdb, 0x12, 0x16, 0x2f, 0x82, 0xf3, 0xa7, 0x15, 0xc1, 0x85, 0xb3, 0xb6, 0x53, 0x1c, 0xaf,
+	0x08, 0x2e, 0x65, 0x16, 0xc1, 0x3f, 0xc8, 0x01, 0xd9, 0xb6, 0x3a, 0x87, 0x8f, 0x07, 0x6d, 0xe6,
+	0x74, 0x0e, 0xcf, 0xc9, 0x55, 0x2e, 0x01, 0xb8, 0x42, 0x12, 0x67, 0x01, 0x48, 0x51, 0x91, 0x90,
+	0xf5, 0x2e, 0x79, 0x13, 0xa6, 0xe9, 0x53, 0xae, 0x27, 0xed, 0x86, 0x56, 0xac, 0xa2, 0x15, 0x1b,
+	0x3e, 0x22, 0xb0, 0xe4, 0x25, 0x80, 0xce, 0x01, 0xed, 0x1c, 0x1a, 0x6c, 0xe8, 0xda, 0xad, 0x9a,
+	0x48, 0x62, 0x08, 0xd9, 0x19, 0xba, 0x36, 0xd7, 0x27, 0xe0, 0x85, 0x14, 0x75, 0x54, 0xb8, 0xe6,
+	0x03, 0x39, 0x91, 0x76, 0x1b, 0x9a, 0x31, 0x23, 0x48, 0x1f, 0xbd, 0x0c, 0x05, 0x5e, 0x7a, 0xa3,
+	0x01, 0xe2, 0x25, 0x39, 0xc2, 0xb5, 0x5f, 0xe5, 0x60, 0x46, 0x3c, 0x77, 0xdf, 0xf2, 0x38, 0xe4,
+	0x9c, 0xcc, 0x37, 0x0f, 0x13, 0xb6, 0x21, 0xba, 0x05, 0xe1, 0x1d, 0x25, 0x1b, 0x9b, 0x89, 0xaf,
+	0x9c, 0x5d, 0xdf, 0x87, 0xd9, 0x84, 0x7d, 0xc6, 0xee, 0x76, 0x3e, 0xcf, 0x41, 0x83, 0x87, 0xc3,
+	0x39, 0xf6, 0x66, 0xa1, 0x56, 0x85, 0x11, 0x5a, 0x7d, 0xe5, 0x0c, 0xfc, 0x06, 0x4c, 0x47, 0x8c,
+	0x24, 0x8d, 0x3b, 0x03, 0x45, 0xaf, 0xe3, 0xb8, 0x54, 0x16, 0x11, 0x62, 0xc1, 0x0d, 0x4a, 0xe4,
+	0x31, 0xac, 0x9e, 0x9f, 0xab, 0xfa, 0x21, 0x54, 0xc8, 0x0e, 0xa1, 0xaf, 0x9c, 0x41, 0x67, 0xa1,
+	0x19, 0x33, 0x92, 0x7c, 0x13, 0x70, 0x6f, 0x5c, 0x35, 0x7b, 0x3d, 0x9d, 0xd7, 0x3d, 0xff, 0xf1,
+	0xc6, 0x11, 0xc6, 0x6b, 0xc2, 0x74, 0xc4, 0x48, 0xd2, 0x74, 0xbf, 0xcb, 0x43, 0x95, 0xfb, 0xe8,
+	0x39, 0x59, 0x2d, 0xf5, 0xf6, 0x2d, 0x64, 0xbc, 0x7d, 0xe3, 0x86, 0x2b, 0x26, 0x0d, 0x77, 0x0b,
+	0x4a, 0x66, 0x07, 0x7b, 0x9b, 0x12, 0x36, 0x69, 0x2d, 0x59, 0xef, 0x86, 0x77, 0x2f, 0x4b, 0x77,
+	0x11, 0xaf, 0x4b, 0xba, 0x68, 0x46, 0x9e, 0x88, 0x65, 0xe4, 0xe0, 0x10, 0xcb, 0xa3, 0x0e, 0xd1,
+	0x8f, 0x90, 0xca, 0x88, 0x08, 0x39, 0xf7, 0x53, 0xfc, 0x42, 0x81, 0x9a, 0xdc, 0x1e, 0xf5, 0x86,
+	0xbd, 0xe4, 0xdb, 0x5a, 0x49, 0x5a, 0x6b, 0x06, 0x8a, 0xd4, 0x75, 0x1d, 0xd7, 0xaf, 0xd1, 0x71,
+	0xc1, 0x1b, 0x47, 0xbc, 0x7b, 0x28, 0xa1, 0x04, 0xfc, 0x1d, 0x1a, 0x23, 0x3f, 0xca, 0x18, 0x41,
+	0xea, 0x2a, 0x44, 0x52, 0x17, 0x79, 0x0d, 0x0a, 0x47, 0x16, 0x3d, 0xc6, 0x83, 0xaa, 0xae, 0x4c,
+	0xa7, 0x4e, 0x43, 0x47, 0xb4, 0xd6, 0x81, 0x9a, 0x70, 0x34, 0x99, 0x07, 0xff, 0x0b, 0x8a, 0xf4,
+	0x88, 0xda, 0x4c, 0xbe, 0xbf, 0x1b, 0x91, 0x0b, 0x9e, 0x35, 0x0e, 0xd7, 0x05, 0x9a, 0xbc, 0xc9,
+	0xaf, 0x60, 0xf8, 0x4e, 0xe5, 0x14, 0xca, 0x6f, 0x24, 0xa3, 0x46, 0xd0, 0x25, 0x89, 0xf6, 0xc7,
+	0x12, 0xc0, 0x8e, 0x33, 0x74, 0xb9, 0xfb, 0xda, 0x2c, 0xb3, 0x3f, 0xbe, 0x05, 0xa5, 0x3d, 0xc7,
+	0xed, 0x9b, 0xac, 0x95, 0x8b, 0xb9, 0x4f, 0xf8, 0xd8, 0xd2, 0x03, 0xc4, 0xeb, 0x92, 0x8e, 0x3f,
+	0x21, 0xaf, 0xb7, 0xf2, 0xa3, 0x9e, 0x48, 0xdc, 0x6f, 0xdd, 0x86, 0x1a, 0x46, 0xd1, 0xd9, 0x77,
+	0x58, 0xd5, 0xfd, 0x70, 0xc1, 0xed, 0xeb, 0x3a, 0x43, 0x5b, 0xf8, 0x7c, 0x51, 0x17, 0x0b, 0x7e,
+	0xc0, 0xfc, 0xb2, 0x0c, 0x17, 0x9e, 0x3c, 0xb1, 0x8a, 0x3d, 0xec, 0xeb, 0x08, 0x20, 0xb7, 0xa1,
+	0x4c, 0x6d, 0xe6, 0x9a, 0x36, 0xe3, 0xde, 0xcd, 0x4f, 0xee, 0x62, 0x5a, 0xbf, 0x35, 0x41, 0xa1,
+	0x07, 0xa4, 0x64, 0x11, 0x2a, 0x1e, 0x33, 0xed, 0xae, 0x65, 0xef, 0x0b, 0xf7, 0x2f, 0xea, 0x21,
+	0x80, 0xbc, 0x0d, 0x13, 0x7d, 0x3e, 0x3c, 0xa0, 0x5e, 0xab, 0x82, 0x3c, 0xe7, 0xd3, 0x3c, 0xc5,
+	0x74, 0xc1, 0xa7, 0x53, 0xbf, 0x50, 0x60, 0x42, 0x8a, 0xc9, 0xb4, 0x3b, 0xbf, 0xf5, 0xf2, 0x8c,
+	0x8e, 0xd3, 0x1f, 0x0c, 0x59, 0x70, 0xb5, 0x0c, 0x96, 0xb7, 0x2a, 0x21, 0xfc, 0xa1, 0x63, 0xcb,
+	0xf6, 0x6f, 0x03, 0xf1, 0x37, 0x6f, 0xec, 0x7a, 0x8e, 0xe7, 0xd1, 0xa0, 0x94, 0x12, 0x2b, 0x4e,
+	0xbb, 0x7b, 0x42, 0x3d, 0x69, 0x28, 0xfc, 0xcd, 0x69, 0x07, 0x8e, 0x65, 0x33, 0xdf, 0x46, 0x72,
+	0x45, 0x2e, 0x03, 0xd0, 0x9e, 0xd5, 0xb7, 0x6c, 0x93, 0xd1, 0x2e, 0x26, 0x80, 0xb2, 0x1e, 0x81,
+	0xa8, 0xbf, 0x56, 0xa0, 0x88, 0x7b, 0x09, 0xed, 0xaf, 0x44, 0xed, 0x7f, 0xea, 0x38, 0x44, 0x8d,
+	0x58, 0x3f, 0x8f, 0x56, 0x8c, 0x99, 0x98, 0x6f, 0xb7, 0x47, 0x59, 0xd0, 0x98, 0x86, 0x00, 0xae,
+	0xee, 0xb1, 0x65, 0xdb, 0xb2, 0xb5, 0x2f, 0xea, 0x72, 0xc5, 0xe1, 0x18, 0x57, 0xa2, 0x9d, 0x2f,
+	0xea, 0x72, 0xa5, 0x7d, 0x00, 0x25, 0xe1, 0x97, 0x64, 0x0a, 0xaa, 0xfa, 0xd6, 0xe3, 0xcd, 0xfb,
+	0x86, 0xbe, 0x75, 0x6f, 0x7d, 0xb3, 0x71, 0x81, 0xcc, 0x01, 0x69, 0xaf, 0x6f, 0x3e, 0xdc, 0x58,
+	0x33, 0xd6, 0x36, 0xd6, 0x1f, 0xad, 0x6f, 0xde, 0xdd, 0x59, 0xdf, 0xda, 0x6c, 0x28, 0xfc, 0x0e,
+	0xa9, 0xfd, 0xe1, 0x7a, 0xbb, 0xdd, 0xc8, 0x69, 0xd7, 0xa1, 0x24, 0x7c, 0x94, 0x3f, 0xbd, 0xbe,
+	0x69, 0x6c, 0xeb, 0x5b, 0x0f, 0xf5, 0xb5, 0x76, 0xbb, 0x71, 0x81, 0xd4, 0xa1, 0xb2, 0xba, 0xf5,
+	0x68, 0x7b, 0x63, 0x6d, 0x67, 0xed, 0x7e, 0x43, 0xd1, 0x0e, 0x60, 0x3a, 0x3c, 0xe4, 0xd3, 0x0e,
+	0x34, 0x7a, 0xa7, 0x91, 0x4b, 0xdc, 0x69, 0x8c, 0xd5, 0xd5, 0x7d, 0x96, 0x83, 0x79, 0x31, 0x12,
+	0x0e, 0x05, 0xfa, 0xef, 0xa1, 0xd7, 0x61, 0x8a, 0x05, 0xc0, 0xe8, 0xdb, 0x68, 0x32, 0x04, 0xe3,
+	0x01, 0xbc, 0x1b, 0x39, 0x80, 0x1c, 0xba, 0x6a, 0x3a, 0x3c, 0xd3, 0xde, 0x1f, 0x26, 0x81, 0xfc,
+	0x98, 0x49, 0xe0, 0x4b, 0x86, 0x74, 0x3c, 0x78, 0x8b, 0x89, 0xe0, 0xd5, 0xee, 0x42, 0x2b, 0x6d,
+	0x01, 0x99, 0x20, 0x5f, 0x83, 0xc9, 0x98, 0x0d, 0x45, 0x39, 0x5e, 0xd1, 0xeb, 0x51, 0x23, 0x7a,
+	0xda, 0xff, 0xe1, 0xe0, 0xe1, 0xcb, 0x5b, 0x50, 0x5b, 0x03, 0x35, 0x68, 0xa3, 0x5f, 0x80, 0xcd,
+	0xfb, 0x30, 0x23, 0xb6, 0x72, 0x57, 0x8c, 0x22, 0x7d, 0x06, 0xc9, 0xa1, 0xa6, 0x92, 0x1a, 0x6a,
+	0x6a, 0x1f, 0xc0, 0x6c, 0xe2, 0x51, 0x69, 0x82, 0xd4, 0xb4, 0x53, 0xc9, 0x98, 0x76, 0xfe, 0xb6,
+	0x20, 0x4a, 0x18, 0xea, 0x72, 0x0f, 0xf7, 0xc6, 0x10, 0x88, 0xd7, 0xfa, 0x26, 0x4e, 0x40, 0x73,
+	0x78, 0x05, 0x2b, 0x57, 0xfc, 0x51, 0x9c, 0x7b, 0x88, 0xd6, 0xdf, 0xbf, 0xcc, 0xc1, 0x03, 0x15,
+	0xdd, 0x7f, 0x37, 0xc8, 0x52, 0x85, 0x48, 0x96, 0xba, 0x08, 0xe5, 0x63, 0xcb, 0x36, 0xb8, 0xef,
+	0xcb, 0x5b, 0xde, 0x89, 0x63, 0xcb, 0xd6, 0x4d, 0x86, 0x87, 0x68, 0x1e, 0x51, 0xd7, 0xdc, 0xa7,
+	0x46, 0x24, 0x39, 0x29, 0x7a, 0x5d, 0x42, 0xb7, 0x11, 0x48, 0xde, 0x80, 0x86, 0x4f, 0xd6, 0xa5,
+	0x66, 0xf7, 0xd8, 0x71, 0x44, 0xa6, 0x52, 0xf4, 0x29, 0x09, 0xbf, 0x2f, 0xc1, 0x3c, 0x35, 0x1f,
+	0x58, 0x1e, 0x73, 0xdc, 0x93, 0x56, 0x39, 0x96, 0x9a, 0x23, 0x36, 0x10, 0x33, 0x1c, 0x9f, 0x4e,
+	0xfd, 0x71, 0x6e, 0x9c, 0x29, 0xce, 0xab, 0x30, 0xb9, 0x67, 0xd9, 0x96, 0x77, 0x40, 0xbb, 0x86,
+	0xc9, 0x8c, 0xbe, 0x17, 0x5c, 0x8d, 0x48, 0xe8, 0x5d, 0xf6, 0xc8, 0x3b, 0xfd, 0x1e, 0x2e, 0xbb,
+	0x40, 0x50, 0xa1, 0x1c, 0x6c, 0x4a, 0x44, 0x40, 0xb0, 0xe6, 0x97, 0x8a, 0xc7, 0xb2, 0x92, 0x2b,
+	0xeb, 0xfc, 0x27, 0xcf, 0x9a, 0x7b, 0x8e, 0xbb, 0x47, 0xad, 0x30, 0x5b, 0x87, 0x00, 0xee, 0x11,
+	0xe2, 0xac, 0x8c, 0xce, 0x81, 0x69, 0xef, 0xd3, 0x56, 0x19, 0xad, 0x54, 0x13, 0xc0, 0x55, 0x84,
+	0x71, 0x16, 0xce, 0x60, 0xe0, 0xd8, 0xd4, 0x66, 0xe2, 0xfd, 0x55, 0xd1, 0x43, 0x80, 0xf6, 0x16,
+	0xcc, 0x3e, 0xa4, 0x6c, 0x83, 0x9a, 0x5d, 0xea, 0xee, 0x3a, 0x91, 0x66, 0x6b, 0x06, 0x8a, 0xfc,
+	0xad, 0xc0, 0xfc, 0xf4, 0x8f, 0x0b, 0xed, 0x01, 0xcc, 0x25, 0xc9, 0xa5, 0x77, 0xde, 0x84, 0x09,
+	0x7f, 0x82, 0x25, 0x1a, 0x65, 0x92, 0x3e, 0x09, 0xdd, 0x27, 0xd1, 0xee, 0xa0, 0xd8, 0x28, 0x6a,
+	0xec, 0x00, 0x59, 0xf9, 0x53, 0x0b, 0x6a, 0x58, 0xb7, 0xb7, 0xc5, 0x17, 0x43, 0xe4, 0x18, 0x20,
+	0xfc, 0x98, 0x86, 0xf8, 0xd9, 0x2b, 0xf5, 0x81, 0x8f, 0x7a, 0x31, 0x03, 0x23, 0x2b, 0xff, 0x77,
+	0xbf, 0xff, 0xe7, 0xbf, 0xfd, 0x22, 0xb7, 0xa4, 0xcd, 0x2d, 0x1f, 0xbd, 0xbd, 0xdc, 0x41, 0xfc,
+	0xf2, 0xb3, 0xc0, 0x3b, 0x9e, 0x7f, 0x34, 0xa3, 0x4d, 0x85, 0x18, 0x83, 0x23, 0xee, 0x28, 0x37,
+	0xc8, 0x37, 0xa0, 0x12, 0x4c, 0x0c, 0xc9, 0x7c, 0x7a, 0x86, 0x28, 0xc4, 0xb6, 0x46, 0x0d, 0x17,
+	0xb5, 0x69, 0x94, 0x5a, 0x25, 0x15, 0xce, 0x1b, 0xe3, 0x8a, 0x38, 0xd0, 0x48, 0x8e, 0xca, 0xc8,
+	0x65, 0xc9, 0x60, 0xc4, 0x54, 0x4f, 0xbd, 0x32, 0x12, 0x2f, 0xe5, 0xa8, 0x28, 0x47, 0xee, 0x21,
+	0x32, 0x6d, 0xe3, 0x7b, 0x78, 0x06, 0x65, 0xff, 0xeb, 0x14, 0x32, 0x97, 0xfd, 0xa1, 0x8c, 0x3a,
+	0x9f, 0x82, 0x4b, 0xc6, 0xff, 0x8b, 0x8c, 0xdf, 0xd3, 0x34, 0xce, 0xf8, 0x13, 0xc7, 0xb2, 0xa3,
+	0x46, 0x5b, 0x7e, 0x16, 0x19, 0x03, 0x3c, 0xff, 0x88, 0x68, 0x75, 0x9f, 0x2a, 0x30, 0xe0, 0xc7,
+	0x50, 0x09, 0x3e, 0xcd, 0x08, 0x0c, 0x98, 0xfc, 0xaa, 0x45, 0x6d, 0xa5, 0x11, 0x52, 0xfe, 0x45,
+	0x94, 0xdf, 0xd4, 0x26, 0x39, 0xe7, 0x3d, 0xcb, 0xee, 0x1a, 0x58, 0x80, 0xdd, 0x51, 0x6e, 0xdc,
+	0x52, 0x48, 0x07, 0xea, 0xb1, 0xaf, 0x1b, 0xc8, 0x42, 0x64, 0x1b, 0xc9, 0x0f, 0x2e, 0xd4, 0xc5,
+	0x6c, 0xa4, 0x14, 0x34, 0x8f, 0x82, 0xa6, 0xb5, 0x1a, 0x17, 0x24, 0xef, 0x25, 0x71, 0x07, 0xdf,
+	0x81, 0x4a, 0xf0, 0xad, 0x41, 0xb0, 0x83, 0xe4, 0x17, 0x11, 0x6a, 0x2b, 0x8d, 0x90, 0x8c, 0xaf,
+	0x22, 0x63, 0x55, 0x9b, 0x45, 0xc6, 0x1c, 0x1d, 0x35, 0x21, 0x97, 0xb0, 0x03, 0x95, 0xe0, 0xba,
+	0x37, 0x74, 0xb2, 0xc4, 0x9d, 0xb3, 0xda, 0x4a, 0x23, 0xa4, 0x84, 0x19, 0x94, 0x30, 0xa9, 0xa1,
+	0x93, 0xf5, 0x38, 0x9a, 0x73, 0xdd, 0x83, 0x5a, 0x74, 0x8c, 0x49, 0x54, 0xff, 0x9d, 0x9f, 0x9e,
+	0xb4, 0xaa, 0x0b, 0x99, 0x38, 0xc9, 0xfe, 0x12, 0xb2, 0x9f, 0xd7, 0x08, 0x67, 0xcf, 0xcc, 0x43,
+	0x8a, 0x13, 0x1a, 0xc3, 0xa3, 0x26, 0xe3, 0x72, 0x4c, 0xa8, 0x46, 0x26, 0x94, 0xc4, 0x0f, 0xc1,
+	0xf4, 0xa8, 0x54, 0x55, 0xb3, 0x50, 0x52, 0xc8, 0x02, 0x0a, 0x99, 0xd5, 0x1a, 0x5c, 0x88, 0x2b,
+	0x08, 0x02, 0x11, 0x14, 0x26, 0xe3, 0xa3, 0x37, 0xb2, 0x18, 0x89, 0xb8, 0xd4, 0xa8, 0x4e, 0xbd,
+	0x34, 0x02, 0x2b, 0x65, 0xcd, 0xa1, 0xac, 0x06, 0x99, 0x14, 0x27, 0x12, 0x30, 0x7d, 0x0c, 0x65,
+	0x7f, 0x88, 0x14, 0x04, 0x4a, 0x62, 0x46, 0xa7, 0xce, 0x8f, 0x98, 0x36, 0x69, 0x2d, 0x64, 0x2a,
+	0x43, 0xa0, 0x67, 0x79, 0x0c, 0x3f, 0x7a, 0xe2, 0xda, 0x3f, 0x86, 0xb2, 0x3f, 0x95, 0x09, 0xd8,
+	0x26, 0x86, 0x45, 0xea, 0x7c, 0x0a, 0x9e, 0xc5, 0x16, 0xc7, 0x3c, 0x9c, 0x2f, 0x67, 0xfb, 0x2d,
+	0xa8, 0x04, 0x93, 0x96, 0xc0, 0x6b, 0x92, 0xa3, 0x1e, 0xb5, 0x95, 0x46, 0x64, 0x45, 0x96, 0x8b,
+	0x68, 0x9f, 0xf5, 0x36, 0x94, 0xfd, 0x11, 0x49, 0xa0, 0x71, 0x62, 0x7e, 0xa3, 0xce, 0xa7, 0xe0,
+	0x92, 0x6f, 0x13, 0xf9, 0xd6, 0xb5, 0x32, 0xa6, 0xd3, 0x03, 0x71, 0x82, 0xb7, 0xa1, 0xc0, 0x5f,
+	0x05, 0x24, 0xfa, 0xca, 0xf0, 0x39, 0x35, 0x63, 0x30, 0xc9, 0xe5, 0xc2, 0x75, 0x45, 0x04, 0x78,
+	0x6c, 0xe4, 0x11, 0x04, 0x78, 0xd6, 0x20, 0x44, 0x4d, 0xf5, 0xd4, 0xda, 0x35, 0xd4, 0x65, 0x81,
+	0x5c, 0xc4, 0x93, 0xf6, 0x9f, 0x89, 0xc6, 0xdf, 0x2d, 0x85, 0x7c, 0x0c, 0xb5, 0xe8, 0x67, 0x43,
+	0x41, 0xa0, 0x64, 0x7c, 0x4b, 0x14, 0x13, 0x81, 0x08, 0x3f, 0x3a, 0x88, 0x1f, 0xde, 0xf1, 0xd7,
+	0x0a, 0xf9, 0x4c, 0x81, 0x5a, 0xf4, 0x13, 0x9b, 0x28, 0xf7, 0xe4, 0xd7, 0x3e, 0xea, 0x42, 0x26,
+	0x4e, 0x5a, 0xe4, 0xbf, 0x51, 0xd0, 0xdb, 0xe4, 0x2a, 0x17, 0x74, 0x60, 0xda, 0xdd, 0xcc, 0x4c,
+	0x6c, 0x75, 0x9f, 0x7f, 0x24, 0x6d, 0xcf, 0x69, 0xb8, 0xed, 0x9f, 0x42, 0x3d, 0xf6, 0xa9, 0x0e,
+	0x89, 0x88, 0x49, 0x7d, 0xc0, 0xa3, 0xa6, 0x2f, 0x34, 0xe2, 0x92, 0xf9, 0xe5, 0xc6, 0x59, 0x92,
+	0x39, 0x0d, 0x97, 0xbc, 0x0b, 0xd5, 0xc8, 0x24, 0x23, 0x48, 0x0d, 0xe9, 0x11, 0x8f, 0xaa, 0x66,
+	0xa1, 0xe4, 0xc6, 0x17, 0x51, 0xfc, 0x9c, 0x36, 0x8d, 0x21, 0x60, 0x75, 0x0e, 0x8d, 0xe1, 0xc0,
+	0xf0, 0x38, 0x09, 0x97, 0xf1, 0x09, 0xd4, 0x63, 0xb7, 0xfa, 0xc1, 0xee, 0xb2, 0x66, 0x21, 0xea,
+	0x62, 0x36, 0x52, 0x4a, 0xba, 0x8c, 0x92, 0x5a, 0x5a, 0x33, 0x2a, 0xa9, 0x2b, 0x88, 0x64, 0xc8,
+	0x05, 0x17, 0xdc, 0x24, 0x5a, 0x87, 0xc6, 0x4e, 0xb1, 0x95, 0x46, 0x64, 0x85, 0x1c, 0x37, 0x9b,
+	0xb8, 0xa5, 0xe3, 0xac, 0xbf, 0x0d, 0xd5, 0xc8, 0x55, 0x6f, 0x60, 0xaa, 0xf4, 0x1d, 0xb9, 0xaa,
+	0x66, 0xa1, 0xe2, 0x99, 0x4d, 0xab, 0x72, 0x01, 0x71, 0xc5, 0x83, 0xbb, 0xd0, 0x40, 0xf1, 0xe4,
+	0x15, 0xb2, 0xda, 0x4a, 0x23, 0xb2, 0x14, 0xef, 0x98, 0xbd, 0x9e, 0x81, 0x94, 0x9c, 0xf5, 0x10,
+	0x1a, 0xc9, 0x96, 0x2e, 0x28, 0x67, 0x46, 0x74, 0xbb, 0xea, 0x95, 0x91, 0xf8, 0xac, 0x77, 0xa6,
+	0x2c, 0xc9, 0xc2, 0xfe, 0x8b, 0x8b, 0xb5, 0xd0, 0xa9, 0x23, 0x32, 0x23, 0x4e, 0x9d, 0x16, 0x38,
+	0x9d, 0xea, 0x77, 0xb5, 0xeb, 0x28, 0x42, 0x13, 0x4e, 0x1d, 0xf2, 0x5e, 0x7e, 0x96, 0x68, 0xff,
+	0x9e, 0x93, 0xef, 0x29, 0xd0, 0xcc, 0xe8, 0x18, 0xc9, 0xb5, 0x64, 0x2e, 0x1a, 0x4b, 0xee, 0x0a,
+	0xca, 0xbd, 0x49, 0x6e, 0xc4, 0x52, 0x92, 0x71, 0x9a, 0x06, 0xb7, 0x14, 0xbe, 0xdd, 0x58, 0xcb,
+	0x18, 0x6c, 0x37, 0xab, 0x07, 0x55, 0x17, 0xb3, 0x91, 0x59, 0xef, 0x73, 0x69, 0x5c, 0x59, 0x7d,
+	0x8b, 0xba, 0x61, 0x32, 0xde, 0x00, 0x04, 0x2f, 0xdb, 0xcc, 0x36, 0x42, 0xbd, 0x34, 0x02, 0x1b,
+	0xaf, 0xab, 0xc8, 0x94, 0x2c, 0x4e, 0x02, 0xae, 0x87, 0x28, 0x27, 0xda, 0xc9, 0x2e, 0x26, 0xf3,
+	0x52, 0xb4, 0x6f, 0x50, 0x33, 0xba, 0x0d, 0xed, 0x75, 0x64, 0x7e, 0x8d, 0x5c, 0xf1, 0x03, 0x8a,
+	0xd7, 0x25, 0x1c, 0xb3, 0xfc, 0x2c, 0xda, 0x63, 0x3c, 0xdf, 0x2d, 0xe1, 0x7f, 0x05, 0xbc, 0xf3,
+	0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4c, 0xb9, 0x64, 0xac, 0x84, 0x30, 0x00, 0x00,
 }
@@ -0,0 +1,129 @@
+package rummy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timpalpant/rummy/deck"
+	"github.com/timpalpant/rummy/meld"
+)
+
+// Snapshot returns the complete state of the game, so that it
+// can be saved and later restored with RestoreGame.
+// Subscribers are not included in the snapshot, and the clock for
+// the current turn restarts when the game is restored.
+func (g *Game) Snapshot() *GameSnapshot {
+	players := make([]*GameSnapshot_Player, len(g.players))
+	for i, p := range g.players {
+		players[i] = &GameSnapshot_Player{
+			Name:    p.name,
+			Hand:    protoSlice(p.hand.AsSlice()),
+			Melds:   protoMelds(p.melds, p.meldIds),
+			Rummies: protoSlice(p.rummies),
+
+			TimeBankMs: millis(p.timeBank),
+			Away:       p.away,
+			Forfeited:  p.forfeited,
+
+			InitialHand: protoSlice(p.initialHand),
+		}
+	}
+
+	snapshot := &GameSnapshot{
+		Stock:         protoSlice(g.stock),
+		DiscardPile:   protoSlice(g.discard),
+		Players:       players,
+		Turn:          int32(g.turn),
+		CurrentPlayer: g.currentPlayer,
+		TurnState:     g.currentPlayerTurnState,
+		GameOver:      g.isOver,
+		History:       g.history,
+		Options:       g.options,
+
+		InitialStock: protoSlice(g.initialStock),
+	}
+	if g.mustPlayCard != nil {
+		mustPlayCard := *g.mustPlayCard
+		snapshot.MustPlayCard = &mustPlayCard
+	}
+	if g.initialDiscard != nil {
+		initialDiscard := *g.initialDiscard
+		snapshot.InitialDiscard = &initialDiscard
+	}
+	return snapshot
+}
+
+// RestoreGame recreates a Game from a snapshot previously
+// returned by Game.Snapshot.
+func RestoreGame(snapshot *GameSnapshot) (*Game, error) {
+	if snapshot.CurrentPlayer < -1 || snapshot.CurrentPlayer >= int32(len(snapshot.Players)) {
+		return nil, fmt.Errorf("invalid current player: %v", snapshot.CurrentPlayer)
+	}
+	opts, err := NormalizeGameOptions(snapshot.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, e := range snapshot.History {
+		if e.Sequence != int64(i+1) {
+			return nil, fmt.Errorf("invalid sequence number %v for event %v", e.Sequence, i)
+		}
+	}
+
+	g := &Game{
+		options:                opts,
+		stock:                  deck.Deck(valueSlice(snapshot.Stock)),
+		discard:                valueSlice(snapshot.DiscardPile),
+		name2id:                make(map[string]int32, len(snapshot.Players)),
+		turn:                   int(snapshot.Turn),
+		currentPlayer:          snapshot.CurrentPlayer,
+		currentPlayerTurnState: snapshot.TurnState,
+		isOver:                 snapshot.GameOver,
+		history:                snapshot.History,
+		initialStock:           deck.Deck(valueSlice(snapshot.InitialStock)),
+		now:                    time.Now,
+	}
+	if snapshot.MustPlayCard != nil {
+		mustPlayCard := *snapshot.MustPlayCard
+		g.mustPlayCard = &mustPlayCard
+	}
+	if snapshot.InitialDiscard != nil {
+		initialDiscard := *snapshot.InitialDiscard
+		g.initialDiscard = &initialDiscard
+	}
+	// Time spent while the game was not loaded does not count
+	// against the current player.
+	g.turnStartedAt = g.now()
+
+	for i, ps := range snapshot.Players {
+		p := &player{
+			name:      ps.Name,
+			hand:      NewHand(valueSlice(ps.Hand)),
+			rummies:   valueSlice(ps.Rummies),
+			timeBank:  time.Duration(ps.TimeBankMs) * time.Millisecond,
+			away:      ps.Away,
+			forfeited: ps.Forfeited,
+
+			initialHand: valueSlice(ps.InitialHand),
+		}
+		for _, m := range ps.Melds {
+			p.melds = append(p.melds, meld.Meld(valueSlice(m.Cards)))
+			p.meldIds = append(p.meldIds, m.Id)
+		}
+		// Seats left before the game started have no name.
+		if p.name != "" {
+			g.name2id[p.name] = int32(i)
+		}
+		g.players = append(g.players, p)
+	}
+
+	return g, nil
+}
+
+func valueSlice(cards []*deck.Card) []deck.Card {
+	result := make([]deck.Card, len(cards))
+	for i, c := range cards {
+		result[i] = *c
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package rummy
+
+import (
+	"time"
+
+	"github.com/timpalpant/rummy/deck"
+)
+
+// handSnapshot records the hands of all players after an event,
+// so that they can be revealed to spectators on a delay.
+type handSnapshot struct {
+	sequence  int64
+	timestamp time.Time
+	hands     [][]deck.Card
+}
+
+func (g *Game) recordHands(event *GameEvent) {
+	hands := make([][]deck.Card, len(g.players))
+	for i, p := range g.players {
+		hands[i] = sortedHand(p.hand)
+	}
+
+	g.handHistory = append(g.handHistory, handSnapshot{
+		sequence:  event.Sequence,
+		timestamp: g.now(),
+		hands:     hands,
+	})
+}
+
+// SpectatorState returns the game state as seen by spectators who have
+// joined the game. If the game reveals hands to spectators on a delay,
+// then each player's hand is included as it was that long before now.
+// Hands are not available for events before the game was last
+// restored from a snapshot.
+func (g *Game) SpectatorState(now time.Time) *GameState {
+	gs := g.GameState()
+	if g.isOver || g.options.SpectatorHandDelaySeconds == 0 {
+		return gs
+	}
+
+	delay := time.Duration(g.options.SpectatorHandDelaySeconds) * time.Second
+	cutoff := now.Add(-delay)
+	var revealed *handSnapshot
+	for i := range g.handHistory {
+		if g.handHistory[i].timestamp.After(cutoff) {
+			break
+		}
+		revealed = &g.handHistory[i]
+	}
+
+	if revealed != nil {
+		gs.HandsRevealedSequence = revealed.sequence
+		for i, hand := range revealed.hands {
+			gs.Players[i].Hand = protoSlice(hand)
+		}
+	}
+	return gs
+}
@@ -0,0 +1,112 @@
+package rummy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/timpalpant/rummy/deck"
+)
+
+// dealTestGame deals a game between two players with the given options,
+// whose clock is read from now.
+func dealTestGame(t *testing.T, opts *GameOptions, now *time.Time) *Game {
+	g, err := NewGameWithOptions(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.now = func() time.Time { return *now }
+	for _, name := range []string{"a", "b"} {
+		if _, err := g.AddPlayer(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// playStockTurn plays the current player's turn by picking up
+// from the stock and discarding the first card in their hand.
+func playStockTurn(t *testing.T, g *Game) {
+	playerId := g.currentPlayer
+	if _, err := g.PickUpStock(playerId); err != nil {
+		t.Fatal(err)
+	}
+	card := sortedHand(g.players[playerId].hand)[0]
+	if err := g.DiscardCard(playerId, card); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hands(g *Game) [][]deck.Card {
+	result := make([][]deck.Card, len(g.players))
+	for i, p := range g.players {
+		result[i] = sortedHand(p.hand)
+	}
+	return result
+}
+
+func revealedHands(gs *GameState) [][]deck.Card {
+	var result [][]deck.Card
+	for _, p := range gs.Players {
+		if len(p.Hand) > 0 {
+			result = append(result, valueSlice(p.Hand))
+		}
+	}
+	return result
+}
+
+func TestSpectatorState(t *testing.T) {
+	start := time.Now()
+	now := start
+	delay := time.Duration(minSpectatorHandDelaySeconds) * time.Second
+	g := dealTestGame(t, &GameOptions{SpectatorHandDelaySeconds: minSpectatorHandDelaySeconds}, &now)
+	dealt, dealtSequence := hands(g), g.LastSequence()
+	now = start.Add(time.Minute)
+	playStockTurn(t, g)
+	played, playedSequence := hands(g), g.LastSequence()
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		hands    [][]deck.Card
+		sequence int64
+	}{
+		{"before the delay", start.Add(delay - time.Second), nil, 0},
+		{"after the deal", start.Add(delay), dealt, dealtSequence},
+		{"before the turn", start.Add(time.Minute + delay - time.Second), dealt, dealtSequence},
+		{"after the turn", start.Add(time.Minute + delay), played, playedSequence},
+	}
+
+	for _, tc := range tests {
+		gs := g.SpectatorState(tc.at)
+		if result := revealedHands(gs); !reflect.DeepEqual(result, tc.hands) {
+			t.Errorf("%v: revealed hands %v, expected %v", tc.name, result, tc.hands)
+		}
+		if gs.HandsRevealedSequence != tc.sequence {
+			t.Errorf("%v: hands revealed at sequence %v, expected %v",
+				tc.name, gs.HandsRevealedSequence, tc.sequence)
+		}
+	}
+
+	// Hands are never revealed in the public game state.
+	if result := revealedHands(g.GameState()); result != nil {
+		t.Errorf("game state revealed hands %v", result)
+	}
+}
+
+func TestSpectatorStateWithoutDelay(t *testing.T) {
+	now := time.Now()
+	g := dealTestGame(t, nil, &now)
+	playStockTurn(t, g)
+
+	gs := g.SpectatorState(now.Add(24 * time.Hour))
+	if result := revealedHands(gs); result != nil {
+		t.Errorf("revealed hands %v without a delay", result)
+	}
+	if gs.HandsRevealedSequence != 0 {
+		t.Errorf("hands revealed at sequence %v without a delay", gs.HandsRevealedSequence)
+	}
+}
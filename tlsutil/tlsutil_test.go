@@ -0,0 +1,198 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testCerts holds the files of a CA and certificates signed by it.
+type testCerts struct {
+	caFile                string
+	serverCert, serverKey string
+	clientCert, clientKey string
+}
+
+// writeCert writes a certificate and its key to files in dir,
+// and returns their paths.
+func writeCert(t *testing.T, dir, name string, certPEM, keyPEM []byte) (certFile, keyFile string) {
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// generateCerts generates a CA with the given name, and a server and
+// client certificate signed by it, in dir.
+func generateCerts(t *testing.T, dir, name string) testCerts {
+	caCert, caKey, err := GenerateCA(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var certs testCerts
+	certs.caFile, _ = writeCert(t, dir, name+"-ca", caCert, caKey)
+
+	serverCert, serverKey, err := GenerateCert(caCert, caKey, "server", []string{"localhost", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs.serverCert, certs.serverKey = writeCert(t, dir, name+"-server", serverCert, serverKey)
+
+	clientCert, clientKey, err := GenerateCert(caCert, caKey, "client", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs.clientCert, certs.clientKey = writeCert(t, dir, name+"-client", clientCert, clientKey)
+	return certs
+}
+
+func TestGenerateCert(t *testing.T) {
+	caCert, caKey, err := GenerateCA("ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := GenerateCert(caCert, caKey, "server", []string{"localhost", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCert) {
+		t.Fatal("CA certificate is not PEM-encoded")
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("certificate is not PEM-encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, host := range []string{"localhost", "127.0.0.1"} {
+		_, err := cert.Verify(x509.VerifyOptions{
+			DNSName:   host,
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			t.Errorf("certificate is not valid for %v: %v", host, err)
+		}
+	}
+	if err := cert.VerifyHostname("example.com"); err == nil {
+		t.Error("certificate is valid for a host it was not issued for")
+	}
+}
+
+// handshake connects a client and server with the given configs,
+// and returns the first error either of them encounters.
+func handshake(serverConfig, clientConfig *tls.Config) error {
+	c, s := net.Pipe()
+	serverErr := make(chan error, 1)
+	go func() {
+		server := tls.Server(s, serverConfig)
+		err := server.Handshake()
+		if err == nil {
+			_, err = server.Write([]byte{0})
+		}
+		s.Close()
+		serverErr <- err
+	}()
+
+	client := tls.Client(c, clientConfig)
+	err := client.Handshake()
+	if err == nil {
+		// With TLS 1.3, the server may reject the client's
+		// certificate after the client's handshake has completed.
+		_, err = client.Read(make([]byte, 1))
+	}
+	c.Close()
+	if err := <-serverErr; err != nil {
+		return err
+	}
+	return err
+}
+
+func TestHandshake(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certs := generateCerts(t, dir, "trusted")
+	other := generateCerts(t, dir, "other")
+
+	tests := []struct {
+		name string
+		// The CAs that client certificates must be signed by, if required.
+		clientCA   string
+		caFile     string
+		serverName string
+		clientCert string
+		clientKey  string
+		ok         bool
+	}{
+		{"server certificate", "", certs.caFile, "localhost", "", "", true},
+		{"server certificate by IP", "", certs.caFile, "127.0.0.1", "", "", true},
+		{"untrusted server", "", other.caFile, "localhost", "", "", false},
+		{"wrong server name", "", certs.caFile, "example.com", "", "", false},
+		{"client certificate", certs.caFile, certs.caFile, "localhost", certs.clientCert, certs.clientKey, true},
+		{"missing client certificate", certs.caFile, certs.caFile, "localhost", "", "", false},
+		{"untrusted client certificate", certs.caFile, certs.caFile, "localhost", other.clientCert, other.clientKey, false},
+	}
+
+	for _, tc := range tests {
+		serverConfig, err := ServerConfig(certs.serverCert, certs.serverKey, tc.clientCA)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientConfig, err := ClientConfig(tc.caFile, tc.serverName, tc.clientCert, tc.clientKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = handshake(serverConfig, clientConfig)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: handshake returned %v, expected ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
+
+func TestLoadCertPool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certs := generateCerts(t, dir, "ca")
+	empty := filepath.Join(dir, "empty.pem")
+	if err := ioutil.WriteFile(empty, []byte("not a certificate\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file string
+		ok   bool
+	}{
+		{certs.caFile, true},
+		{empty, false},
+		{filepath.Join(dir, "missing.pem"), false},
+	}
+
+	for _, tc := range tests {
+		_, err := LoadCertPool(tc.file)
+		if (err == nil) != tc.ok {
+			t.Errorf("LoadCertPool(%v) returned %v, expected ok = %v", tc.file, err, tc.ok)
+		}
+	}
+}